@@ -5,11 +5,14 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/migrateconfig"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/basicInfo"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/migration/linux"
@@ -20,6 +23,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/stdin"
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+	"github.com/aws/amazon-cloudwatch-agent/tool/validateconfig"
 )
 
 type IMainProcessor interface {
@@ -35,7 +39,39 @@ var configOutputPath *string
 
 var isNonInteractiveXrayMigration *bool
 
+var checkPermissions *bool
+
+var mergeConfig *bool
+
+var editConfigPath *string
+
+var fromSSM *string
+
+var answersFile *string
+
+// answerFlags are the -answer flags, each a question=answer pair.
+type answerFlags []string
+
+func (a *answerFlags) String() string {
+	return strings.Join(*a, ",")
+}
+
+func (a *answerFlags) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return fmt.Errorf("%q is not a question=answer pair", value)
+	}
+	*a = append(*a, value)
+	return nil
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate-config" {
+		os.Exit(validateConfig(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate-config" {
+		os.Exit(migrateConfig(os.Args[2:]))
+	}
+
 	// Parse command line args for non-interactive Windows migration
 	isNonInteractiveWindowsMigration = flag.Bool("isNonInteractiveWindowsMigration", false,
 		"If true, it will use command line args to bypass the wizard. Default value is false.")
@@ -51,36 +87,88 @@ func main() {
 		fmt.Sprintf("The path of the old config file. Default is %s on Windows or %s on Linux", windows.DefaultFilePathWindowsConfiguration, linux.DefaultFilePathLinuxConfiguration))
 
 	configOutputPath = flag.String("configOutputPath", "", "Specifies where to write the configuration file generated by the wizard")
+	editConfigPath = flag.String("editConfigPath", "",
+		"The path of an existing config to edit instead of generating a new one. The edited config is written back to it unless configOutputPath is set.")
+	fromSSM = flag.String("from-ssm", "",
+		"The name of a parameter store parameter holding a config to edit and write back to as a new version. "+
+			"A name ending with * lists the parameters starting with the rest of it to choose from, e.g. AmazonCloudWatch-*.")
 	parameterStoreName := flag.String("parameterStoreName", "", "The parameter store name. Default is AmazonCloudWatch-windows")
 	parameterStoreRegion := flag.String("parameterStoreRegion", "", "The parameter store region. Default is us-east-1")
+	mergeConfig = flag.Bool("merge-config", false,
+		"If true, merge the generated config into the config already at the output path instead of overwriting it, keeping the sections edited by hand.")
+	checkPermissions = flag.Bool("checkPermissions", false, "If true, verify the credentials are allowed to call the APIs the generated config requires before confirming it.")
+	imdsIPv6 := flag.Bool("imdsIPv6", false, "If true, reach the instance metadata service over its IPv6 endpoint instead of detecting it.")
+	verbosity := flag.String("verbosity", util.VerbosityNormal.String(), "How much explanation the wizard prints: quiet, normal or verbose.")
+	answersFile = flag.String("answers-file", "",
+		"The path of a YAML or JSON file mapping the wizard questions to their answers, to generate the config without prompting. "+
+			"A question asked several times takes a list of answers, ending with the answer that leaves the loop, e.g. no.")
+	var answers answerFlags
+	flag.Var(&answers, "answer", "A question=answer pair answering a wizard question without prompting. May be repeated, and replaces the answers of the question in the answers file.")
+	output := flag.String("output", util.OutputText,
+		"How the wizard reports its result: text, or json to print the saved config and any error as a json object on stdout while the questions go to stderr.")
 
 	flag.Parse()
 
-	if *isNonInteractiveWindowsMigration {
-		addWindowsMigrationInputs(*configFilePath, *parameterStoreName, *parameterStoreRegion, *useParameterStore)
-	} else if *isNonInteractiveLinuxMigration {
-		ctx := new(runtime.Context)
-		config := new(data.Config)
-		ctx.HasExistingLinuxConfig = true
-		ctx.ConfigFilePath = *configFilePath
-		if ctx.ConfigFilePath == "" {
-			ctx.ConfigFilePath = linux.DefaultFilePathLinuxConfiguration
+	if err := util.SetOutputFormat(*output); err != nil {
+		os.Exit(util.Report(os.Stdout, &util.WizardError{Code: util.ErrCodeInvalidArgument, Err: err}))
+	}
+	stdout := os.Stdout
+	if util.JSONOutput() {
+		// stdout is left to the result, so the questions and progress go to stderr
+		os.Stdout = os.Stderr
+	}
+	// the wizard stops on the first error, which is reported as the output selects
+	err := func() error {
+		v, err := util.ParseVerbosity(*verbosity)
+		if err != nil {
+			return &util.WizardError{Code: util.ErrCodeInvalidArgument, Err: err}
 		}
-		process(ctx, config, linux.Processor, serialization.Processor)
-		return
-	} else if *tracesOnly {
-		ctx := new(runtime.Context)
-		config := new(data.Config)
-		ctx.TracesOnly = true
-		ctx.ConfigOutputPath = *configOutputPath
-		if *isNonInteractiveXrayMigration {
-			ctx.NonInteractiveXrayMigration = true
+		util.SetVerbosity(v)
+		util.SetIMDSIPv6(*imdsIPv6)
+
+		if *editConfigPath != "" && *fromSSM != "" {
+			return &util.WizardError{Code: util.ErrCodeInvalidArgument, Err: errors.New("only one of editConfigPath and from-ssm can be set")}
+		}
+		if *mergeConfig && (*editConfigPath != "" || *fromSSM != "") {
+			return &util.WizardError{Code: util.ErrCodeInvalidArgument, Err: errors.New("merge-config cannot be set with editConfigPath or from-ssm, which already merge the edits into the config edited")}
+		}
+
+		if *answersFile != "" || len(answers) > 0 {
+			if err := setUpAnswers(*answersFile, answers); err != nil {
+				return &util.WizardError{Code: util.ErrCodeInvalidArgument, Path: *answersFile, Err: err}
+			}
 		}
-		process(ctx, config, tracesconfig.Processor, serialization.Processor)
-		return
-	}
 
-	startProcessing()
+		if *isNonInteractiveWindowsMigration {
+			addWindowsMigrationInputs(*configFilePath, *parameterStoreName, *parameterStoreRegion, *useParameterStore)
+		} else if *isNonInteractiveLinuxMigration {
+			ctx := new(runtime.Context)
+			config := new(data.Config)
+			ctx.HasExistingLinuxConfig = true
+			ctx.MergeConfig = *mergeConfig
+			ctx.ConfigFilePath = *configFilePath
+			if ctx.ConfigFilePath == "" {
+				ctx.ConfigFilePath = linux.DefaultFilePathLinuxConfiguration
+			}
+			return process(ctx, config, linux.Processor, serialization.Processor)
+		} else if *tracesOnly {
+			ctx := new(runtime.Context)
+			config := new(data.Config)
+			ctx.TracesOnly = true
+			ctx.ConfigOutputPath = *configOutputPath
+			ctx.MergeConfig = *mergeConfig
+			if *isNonInteractiveXrayMigration {
+				ctx.NonInteractiveXrayMigration = true
+			}
+			return process(ctx, config, tracesconfig.Processor, serialization.Processor)
+		}
+
+		return startProcessing()
+	}()
+	os.Stdout = stdout
+	if code := util.Report(stdout, err); code != 0 {
+		os.Exit(code)
+	}
 }
 
 func init() {
@@ -100,22 +188,151 @@ func init() {
 func addWindowsMigrationInputs(configFilePath string, parameterStoreName string, parameterStoreRegion string, useParameterStore bool) {
 	inputChan := testutil.SetUpTestInputStream()
 	if useParameterStore {
-		testutil.Type(inputChan, "2", "1", "2", "1", configFilePath, "1", parameterStoreName, parameterStoreRegion, "1")
+		testutil.Type(inputChan, "2", "1", "2", "2", "1", configFilePath, "1", parameterStoreName, parameterStoreRegion, "", "1")
 	} else {
-		testutil.Type(inputChan, "2", "1", "2", "1", configFilePath, "2")
+		testutil.Type(inputChan, "2", "1", "2", "2", "1", configFilePath, "2")
 	}
 }
 
-func process(ctx *runtime.Context, config *data.Config, processors ...processors.Processor) {
+// setUpAnswers makes the wizard read its answers from answersFile and the -answer flags instead of
+// stdin. The wizard stops with answerError when one of them is missing.
+func setUpAnswers(answersFile string, flags answerFlags) error {
+	answers := make(map[string][]string)
+	if answersFile != "" {
+		var err error
+		if answers, err = util.ReadAnswersFile(answersFile); err != nil {
+			return err
+		}
+	}
+	fromFlags := make(map[string][]string)
+	for _, pair := range flags {
+		question, answer, _ := strings.Cut(pair, "=")
+		question = strings.TrimSpace(question)
+		fromFlags[question] = append(fromFlags[question], answer)
+	}
+	for question, list := range fromFlags {
+		answers[question] = list
+	}
+	util.SetAnswerSource(util.NewKeyedAnswerSource(answers))
+	return nil
+}
+
+// answerError returns the error naming the first question the answers file and the -answer flags had no
+// answer for, which stops the wizard once the processor asking it returns, or nil.
+func answerError() error {
+	err := util.AnswerErr()
+	if err == nil {
+		return nil
+	}
+	return &util.WizardError{Code: util.ErrCodeMissingAnswer, Path: *answersFile,
+		Err: fmt.Errorf("%w, add it to the answers file or pass it with -answer, then run the wizard again", err)}
+}
+
+// validateConfig runs the validate-config mode, which checks a config without starting the agent and
+// prints every issue found. It returns the exit code, 1 when the config has an error.
+func validateConfig(args []string) int {
+	flags := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	configPath := flags.String("config", util.ConfigFilePath(), "The path of the json config to validate.")
+	osType := flags.String("os", util.CurOS(), "The OS the config is translated for: linux, windows or darwin.")
+	mode := flags.String("mode", "ec2", "The mode the agent runs in: ec2 or onPremise.")
+	offline := flags.Bool("offline", false, "If true, skip resolving the region and the credentials of the host.")
+	probeEndpoints := flags.Bool("probeEndpoints", false, "If true, make sure the CloudWatch and CloudWatch Logs endpoints the config sends to can be reached.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	report, err := validateconfig.Validate(*configPath, validateconfig.Options{
+		OS:             *osType,
+		Mode:           *mode,
+		Offline:        *offline,
+		ProbeEndpoints: *probeEndpoints,
+	})
+	if err != nil {
+		fmt.Printf("Unable to read the config %s: %v\n", *configPath, err)
+		return 1
+	}
+	for _, issue := range report.Issues {
+		prefix := "E!"
+		if issue.Severity == util.SeverityWarning {
+			prefix = "W!"
+		}
+		path := issue.Path
+		if path == "" {
+			path = "(root)"
+		}
+		fmt.Printf("%s %s: %s\n", prefix, path, issue.Message)
+	}
+	if !report.Valid() {
+		fmt.Printf("The config %s is invalid: %d error(s), %d warning(s).\n", *configPath, len(report.Errors()), len(report.Warnings()))
+		return 1
+	}
+	fmt.Printf("The config %s is valid with %d warning(s).\n", *configPath, len(report.Warnings()))
+	return 0
+}
+
+// migrateConfig runs the migrate-config mode, which upgrades the config of an older agent, a json config,
+// an awslogs.conf or the AWS.EC2.Windows.CloudWatch.json of the SSM agent, prints the changes and their
+// diff, and writes the result. With -check nothing is written, and the exit code is 1 when the config
+// needs to be migrated.
+func migrateConfig(args []string) int {
+	flags := flag.NewFlagSet("migrate-config", flag.ContinueOnError)
+	configPath := flags.String("config", util.ConfigFilePath(), "The path of the config to migrate, a json config, an awslogs.conf or the AWS.EC2.Windows.CloudWatch.json of the SSM agent.")
+	outputPath := flags.String("configOutputPath", "", "The path the migrated json config is written to. Defaults to the json config migrated, or to the default config path for an awslogs.conf or an SSM agent config.")
+	check := flags.Bool("check", false, "If true, only report whether the config needs to be migrated, exiting with 1 when it does.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := migrateconfig.Migrate(*configPath)
+	if err != nil {
+		fmt.Printf("Unable to migrate the config %s: %v\n", *configPath, err)
+		return 1
+	}
+	if !result.Changed() {
+		fmt.Printf("The config %s is up to date.\n", *configPath)
+		return 0
+	}
+	target := *outputPath
+	if target == "" {
+		target = *configPath
+		if len(result.Original) == 0 {
+			target = util.ConfigFilePath()
+		}
+	}
+	for _, change := range result.Changes {
+		fmt.Printf("I! %s\n", change)
+	}
+	diff, err := result.Diff(*configPath, target)
+	if err != nil {
+		fmt.Printf("Unable to compute the diff of the migrated config: %v\n", err)
+		return 1
+	}
+	fmt.Print(diff)
+	if *check {
+		fmt.Printf("The config %s needs to be migrated.\n", *configPath)
+		return 1
+	}
+	_, err = util.SaveResultByteArrayToJsonFileE(result.Migrated, target)
+	return util.Report(os.Stdout, err)
+}
+
+func process(ctx *runtime.Context, config *data.Config, processors ...processors.Processor) error {
 	for _, processor := range processors {
-		processor.Process(ctx, config)
+		if err := processStep(processor, ctx, config); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func startProcessing() {
+func startProcessing() error {
 	ctx := new(runtime.Context)
 	config := new(data.Config)
 	ctx.ConfigOutputPath = *configOutputPath
+	ctx.EditConfigPath = *editConfigPath
+	ctx.SSMParameterName = *fromSSM
+	ctx.CheckPermissions = *checkPermissions
+	ctx.MergeConfig = *mergeConfig
 	var processor interface{}
 	processor = processors.StartProcessor
 	if *isNonInteractiveWindowsMigration {
@@ -130,12 +347,27 @@ func startProcessing() {
 				util.EnterToExit()
 			}
 			fmt.Println("Program exits now.")
-			break
+			return nil
 		}
 		MainProcessorGlobal.VerifyProcessor(processor) // For testing purposes
-		processor.(processors.Processor).Process(ctx, config)
+		if err := processStep(processor.(processors.Processor), ctx, config); err != nil {
+			return err
+		}
 		processor = processor.(processors.Processor).NextProcessor(ctx, config)
+		if err := answerError(); err != nil {
+			return err
+		}
+	}
+}
+
+// processStep runs processor, reporting a question it left unanswered in place of the error it returns
+// because of it.
+func processStep(processor processors.Processor, ctx *runtime.Context, config *data.Config) error {
+	err := processor.Process(ctx, config)
+	if answerErr := answerError(); answerErr != nil {
+		return answerErr
 	}
+	return err
 }
 
 func (p *MainProcessorStruct) VerifyProcessor(processor interface{}) {
@@ -4,12 +4,14 @@
 package main
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/agentconfig"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/basicInfo"
@@ -20,6 +22,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/statsd"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/template"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/tracesconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
@@ -55,7 +58,7 @@ func TestWindowsMigration(t *testing.T) {
 	processors.StartProcessor = basicInfo.Processor
 
 	*isNonInteractiveWindowsMigration = true
-	startProcessing()
+	assert.NoError(t, startProcessing())
 
 	// Assert expected behaviour
 	assert.True(t, processorMock.AssertNumberOfCalls(t, "VerifyProcessor", 7))
@@ -76,3 +79,86 @@ func TestWindowsMigration(t *testing.T) {
 		t.Errorf("The generated new config is incorrect, got:\n '%v'\n, want:\n '%v'.\n", actualConfig, expectedConfig)
 	}
 }
+
+func TestSetUpAnswers(t *testing.T) {
+	var flags answerFlags
+	assert.Error(t, flags.Set("no pair"))
+	assert.NoError(t, flags.Set("Log group name:=from-flag"))
+	assert.NoError(t, flags.Set("Log group name:=filter=a"))
+
+	answersFilePath := filepath.Join(t.TempDir(), "answers.yaml")
+	assert.NoError(t, os.WriteFile(answersFilePath, []byte("\"Log group name:\": from-file\n\"Log stream name:\": stream\n"), 0600))
+	assert.NoError(t, setUpAnswers(answersFilePath, flags))
+	answersFileOrig := answersFile
+	defer func() {
+		util.SetAnswerSource(nil)
+		answersFile = answersFileOrig
+	}()
+	answersFile = &answersFilePath
+
+	assert.Equal(t, "from-flag", util.AskWithDefault("Log group name:", "default"))
+	assert.Equal(t, "filter=a", util.AskWithDefault("Log group name:", "default"))
+	assert.Equal(t, "stream", util.AskWithDefault("Log stream name:", "default"))
+	assert.Equal(t, "default", util.AskWithDefault("Log class:", "default"))
+	assert.NoError(t, answerError())
+	util.AskWithDefault("Log stream name:", "default")
+	err := answerError()
+	assert.Equal(t, util.ErrCodeMissingAnswer, util.ErrorCode(err))
+	assert.Equal(t, answersFilePath, util.ErrorPath(err))
+	assert.ErrorContains(t, err, `"Log stream name:"`)
+
+	// a question left unanswered stops the wizard once its processor returns
+	assert.NoError(t, setUpAnswers(answersFilePath, nil))
+	err = process(new(runtime.Context), new(data.Config), unansweredProcessor{}, template.Processor)
+	assert.Equal(t, util.ErrCodeMissingAnswer, util.ErrorCode(err))
+	assert.ErrorContains(t, err, `"Which plan?"`)
+
+	assert.Error(t, setUpAnswers(filepath.Join(t.TempDir(), "missing.yaml"), nil))
+}
+
+type unansweredProcessor struct{}
+
+func (unansweredProcessor) Process(*runtime.Context, *data.Config) error {
+	util.Choice("Which plan?", 0, []string{"basic", "advanced"})
+	return nil
+}
+
+func (unansweredProcessor) NextProcessor(*runtime.Context, *data.Config) interface{} {
+	return nil
+}
+
+func TestValidateConfig(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	assert.NoError(t, os.WriteFile(valid, []byte(`{"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}}`), 0600))
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(invalid, []byte(`{"agent": {"metrics_collection_interval": "60"}}`), 0600))
+
+	assert.Equal(t, 0, validateConfig([]string{"-config", valid, "-os", util.OsTypeLinux, "-offline"}))
+	assert.Equal(t, 1, validateConfig([]string{"-config", invalid, "-os", util.OsTypeLinux, "-offline"}))
+	assert.Equal(t, 1, validateConfig([]string{"-config", filepath.Join(dir, "missing.json"), "-offline"}))
+	assert.Equal(t, 2, validateConfig([]string{"-unknownFlag"}))
+}
+
+func TestMigrateConfig(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "old.json")
+	oldContent := []byte(`{"agent": {"debug": "true"}, "csm": {"port": 31000}}`)
+	assert.NoError(t, os.WriteFile(old, oldContent, 0600))
+	migrated := filepath.Join(dir, "migrated.json")
+
+	assert.Equal(t, 1, migrateConfig([]string{"-config", old, "-configOutputPath", migrated, "-check"}))
+	assert.NoFileExists(t, migrated)
+	content, err := os.ReadFile(old)
+	assert.NoError(t, err)
+	assert.Equal(t, oldContent, content)
+
+	assert.Equal(t, 0, migrateConfig([]string{"-config", old, "-configOutputPath", migrated}))
+	content, err = os.ReadFile(migrated)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n\t\"agent\": {\n\t\t\"debug\": true\n\t}\n}", string(content))
+
+	assert.Equal(t, 0, migrateConfig([]string{"-config", migrated, "-check"}))
+	assert.Equal(t, 1, migrateConfig([]string{"-config", filepath.Join(dir, "missing.json")}))
+	assert.Equal(t, 2, migrateConfig([]string{"-unknownFlag"}))
+}
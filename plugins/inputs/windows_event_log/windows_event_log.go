@@ -31,6 +31,8 @@ var startOnlyOnce sync.Once
 type EventConfig struct {
 	Name          string   `toml:"event_name"`
 	Levels        []string `toml:"event_levels"`
+	EventIDs      []int    `toml:"event_ids"`
+	XPathQuery    string   `toml:"xpath_query"`
 	RenderFormat  string   `toml:"event_format"`
 	BatchReadSize int      `toml:"batch_read_size"`
 	LogGroupName  string   `toml:"log_group_name"`
@@ -60,6 +62,7 @@ func (s *Plugin) SampleConfig() string {
 	[[inputs.windows_event_log.event_config]]
 	event_name = "System"
 	event_levels = ["2", "3"]
+	event_ids = [7036, 7040]
 	batch_read_size = 1
 	log_group_name = "System"
 	log_stream_name = "STREAM_NAME"
@@ -112,6 +115,8 @@ func (s *Plugin) Start(acc telegraf.Accumulator) error {
 			eventConfig.BatchReadSize,
 			eventConfig.Retention,
 			eventConfig.LogGroupClass,
+			eventConfig.EventIDs,
+			eventConfig.XPathQuery,
 		)
 		err = eventLog.Init()
 		if err != nil {
@@ -8,6 +8,7 @@ package wineventlog
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"log"
@@ -24,6 +25,7 @@ const (
 	bookmarkTemplate         = `<BookmarkList><Bookmark Channel="%s" RecordId="%d" IsCurrent="True"/></BookmarkList>`
 	eventLogQueryTemplate    = `<QueryList><Query Id="0"><Select Path="%s">%s</Select></Query></QueryList>`
 	eventLogLevelFilter      = "Level='%s'"
+	eventLogEventIDFilter    = "EventID=%d"
 	eventIgnoreOldFilter     = "TimeCreated[timediff(@SystemTime) &lt;= %d]"
 	emptySpaceScanLength     = 100
 	UnknownBytesPerCharacter = 0
@@ -63,7 +65,26 @@ func CreateBookmark(channel string, recordID uint64) (h EvtHandle, err error) {
 	return h, nil
 }
 
-func CreateQuery(path string, levels []string) (*uint16, error) {
+// CreateQuery returns the query subscribing to the events of the channel at path. A non empty xpathQuery
+// selects the events as it is, else they are selected by level and event ID, ignoring the events older
+// than two weeks.
+func CreateQuery(path string, levels []string, eventIDs []int, xpathQuery string) (*uint16, error) {
+	query, err := buildQuery(path, levels, eventIDs, xpathQuery)
+	if err != nil {
+		return nil, err
+	}
+	return syscall.UTF16PtrFromString(query)
+}
+
+func buildQuery(path string, levels []string, eventIDs []int, xpathQuery string) (string, error) {
+	if xpathQuery != "" {
+		var escaped bytes.Buffer
+		if err := xml.EscapeText(&escaped, []byte(xpathQuery)); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(eventLogQueryTemplate, path, escaped.String()), nil
+	}
+
 	var filterLevels string
 	for _, level := range levels {
 		if filterLevels == "" {
@@ -72,18 +93,25 @@ func CreateQuery(path string, levels []string) (*uint16, error) {
 			filterLevels = filterLevels + " or " + fmt.Sprintf(eventLogLevelFilter, level)
 		}
 	}
+	var filterEventIDs string
+	for _, eventID := range eventIDs {
+		if filterEventIDs == "" {
+			filterEventIDs = fmt.Sprintf(eventLogEventIDFilter, eventID)
+		} else {
+			filterEventIDs = filterEventIDs + " or " + fmt.Sprintf(eventLogEventIDFilter, eventID)
+		}
+	}
 
 	//Ignore events older than 2 weeks
 	cutOffPeriod := (time.Hour * 24 * 14).Nanoseconds()
-	ignoreOlderThanTwoWeeksFilter := fmt.Sprintf(eventIgnoreOldFilter, cutOffPeriod/int64(time.Millisecond))
+	filter := fmt.Sprintf(eventIgnoreOldFilter, cutOffPeriod/int64(time.Millisecond))
+	if filterEventIDs != "" {
+		filter = "(" + filterEventIDs + ") and " + filter
+	}
 	if filterLevels != "" {
-		filterLevels = "*[System[(" + filterLevels + ") and " + ignoreOlderThanTwoWeeksFilter + "]]"
-	} else {
-		filterLevels = "*[System[" + ignoreOlderThanTwoWeeksFilter + "]]"
+		filter = "(" + filterLevels + ") and " + filter
 	}
-
-	xml := fmt.Sprintf(eventLogQueryTemplate, path, filterLevels)
-	return syscall.UTF16PtrFromString(xml)
+	return fmt.Sprintf(eventLogQueryTemplate, path, "*[System["+filter+"]]"), nil
 }
 
 func utf16ToUTF8Bytes(in []byte, length uint32) ([]byte, error) {
@@ -132,3 +132,18 @@ func TestInsertPlaceholderValues(t *testing.T) {
 func resetState() {
 	NumberOfBytesPerCharacter = 0
 }
+
+func TestBuildQuery(t *testing.T) {
+	ignoreOld := "TimeCreated[timediff(@SystemTime) &lt;= 1209600000]"
+	query, err := buildQuery("System", []string{"2", "3"}, nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `<QueryList><Query Id="0"><Select Path="System">*[System[(Level='2' or Level='3') and `+ignoreOld+`]]</Select></Query></QueryList>`, query)
+
+	query, err = buildQuery("Security", []string{"4"}, []int{4624, 4625}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `<QueryList><Query Id="0"><Select Path="Security">*[System[(Level='4') and (EventID=4624 or EventID=4625) and `+ignoreOld+`]]</Select></Query></QueryList>`, query)
+
+	query, err = buildQuery("Security", []string{"4"}, []int{4624}, "*[System[EventID=4624 and Level<=3]]")
+	assert.NoError(t, err)
+	assert.Equal(t, `<QueryList><Query Id="0"><Select Path="Security">*[System[EventID=4624 and Level&lt;=3]]</Select></Query></QueryList>`, query)
+}
@@ -34,7 +34,7 @@ var (
 // TestNewEventLog verifies constructor's default values.
 func TestNewEventLog(t *testing.T) {
 	elog := NewEventLog(NAME, LEVELS, GROUP_NAME, STREAM_NAME, RENDER_FMT, DEST,
-		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS)
+		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS, nil, "")
 	assert.Equal(t, NAME, elog.name)
 	assert.Equal(t, uint64(0), elog.eventOffset)
 	assert.Zero(t, elog.eventHandle)
@@ -45,26 +45,26 @@ func TestNewEventLog(t *testing.T) {
 func TestOpen(t *testing.T) {
 	// Happy path.
 	elog := NewEventLog(NAME, LEVELS, GROUP_NAME, STREAM_NAME, RENDER_FMT, DEST,
-		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS)
+		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS, nil, "")
 	assert.NoError(t, elog.Open())
 	assert.NotZero(t, elog.eventHandle)
 	assert.NoError(t, elog.Close())
 	// Bad event log source name does not cause Open() to fail.
 	// But eventHandle will be 0 and Close() will fail because of it.
 	elog = NewEventLog("FakeBadElogName", LEVELS, GROUP_NAME, STREAM_NAME,
-		RENDER_FMT, DEST, STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS)
+		RENDER_FMT, DEST, STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS, nil, "")
 	assert.NoError(t, elog.Open())
 	assert.Zero(t, elog.eventHandle)
 	assert.Error(t, elog.Close())
 	// bad LEVELS does not cause Open() to fail.
 	elog = NewEventLog(NAME, []string{"498"}, GROUP_NAME, STREAM_NAME,
-		RENDER_FMT, DEST, STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS)
+		RENDER_FMT, DEST, STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS, nil, "")
 	assert.NoError(t, elog.Open())
 	assert.NotZero(t, elog.eventHandle)
 	assert.NoError(t, elog.Close())
 	// bad wlog.eventOffset does not cause Open() to fail.
 	elog = NewEventLog(NAME, []string{"498"}, GROUP_NAME, STREAM_NAME,
-		RENDER_FMT, DEST, STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS)
+		RENDER_FMT, DEST, STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS, nil, "")
 	elog.eventOffset = 9987
 	assert.NoError(t, elog.Open())
 	assert.NotZero(t, elog.eventHandle)
@@ -75,7 +75,7 @@ func TestOpen(t *testing.T) {
 // event log source.
 func TestReadGoodSource(t *testing.T) {
 	elog := NewEventLog(NAME, LEVELS, GROUP_NAME, STREAM_NAME, RENDER_FMT, DEST,
-		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS)
+		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS, nil, "")
 	assert.NoError(t, elog.Open())
 	seekToEnd(t, elog)
 	writeEvents(t, 10, true, "CWA_UnitTest111", 777)
@@ -88,7 +88,7 @@ func TestReadGoodSource(t *testing.T) {
 // unregistered event log source.
 func TestReadBadSource(t *testing.T) {
 	elog := NewEventLog(NAME, LEVELS, GROUP_NAME, STREAM_NAME, RENDER_FMT, DEST,
-		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS)
+		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS, nil, "")
 	assert.NoError(t, elog.Open())
 	seekToEnd(t, elog)
 	writeEvents(t, 10, false, "CWA_UnitTest222", 888)
@@ -102,7 +102,7 @@ func TestReadBadSource(t *testing.T) {
 // unregistered source too.
 func TestReadWithBothSources(t *testing.T) {
 	elog := NewEventLog(NAME, LEVELS, GROUP_NAME, STREAM_NAME, RENDER_FMT, DEST,
-		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS)
+		STATE_FILE_PATH, BATCH_SIZE, RETENTION, LOG_GROUP_CLASS, nil, "")
 	assert.NoError(t, elog.Open())
 	seekToEnd(t, elog)
 	writeEvents(t, 10, true, "CWA_UnitTest111", 777)
@@ -5,6 +5,7 @@ package data
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
@@ -65,7 +66,56 @@ func (conf *Config) LogsConf() *config.Logs {
 
 func (conf *Config) SatisfiedWithCurrentConfig(context *runtime.Context) bool {
 	_, resultMap := conf.ToMap(context)
-	byteArray := util.SerializeResultMapToJsonByteArray(resultMap)
-	fmt.Printf("Current config as follows:\n%s\n", string(byteArray))
+	if byteArray, err := util.SerializeResultMapToJsonByteArrayE(resultMap); err == nil {
+		fmt.Printf("Current config as follows:\n%s\n", string(byteArray))
+	} else {
+		fmt.Printf("W! The config cannot be printed: %v\n", err)
+	}
+	if context.CheckPermissions {
+		printDeniedActions(context, resultMap)
+	}
+	if conf.LogsConfig != nil && context.OsParameter == util.OsTypeLinux {
+		if status, warn, err := util.SecurityModuleStatus(); err == nil && warn {
+			fmt.Printf("W! %s.\n", status)
+		}
+	}
+	_, warnings := util.EstimatePutMetricDataRate(resultMap)
+	_, perRequest := util.ValidateMetricsPerRequest(resultMap)
+	warnings = append(warnings, perRequest...)
+	warnings = append(warnings, util.DetectDuplicateStreams(resultMap)...)
+	warnings = append(warnings, util.ValidateRoleChain(resultMap)...)
+	if _, unset := util.DetectEnvReferences(resultMap); len(unset) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the config references the environment variables %s, which are not set here, they resolve to empty strings wherever the agent runs without them",
+			strings.Join(unset, ", ")))
+	}
+	if installed, err := util.InstalledAgentVersion(); err == nil {
+		warnings = append(warnings, util.ValidateMinVersion(resultMap, installed)...)
+	}
+	for _, warning := range warnings {
+		fmt.Printf("W! %s\n", warning)
+	}
+	if conf.LogsConfig != nil {
+		for _, warning := range util.WarnLogFileCount(resultMap, true) {
+			fmt.Printf("W! %s\n", warning)
+		}
+	}
 	return util.Yes("Are you satisfied with the above config? Note: it can be manually customized after the wizard completes to add additional items.")
 }
+
+func printDeniedActions(context *runtime.Context, resultMap map[string]interface{}) {
+	region := util.SDKRegion()
+	if region == "" && !context.IsOnPrem {
+		var err error
+		if region, err = util.DefaultEC2Region(); err != nil {
+			fmt.Printf("W! %v\n", err)
+		}
+	}
+	permitted, err := util.CheckPermissions(region, util.RequiredActions(resultMap))
+	if err != nil {
+		fmt.Printf("Unable to verify the permissions of the current credentials: %v\n", err)
+		return
+	}
+	for _, action := range util.DeniedActions(permitted) {
+		fmt.Printf("W! The current credentials are not allowed to call %s, which the above config requires.\n", action)
+	}
+}
@@ -8,11 +8,20 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
-const RUNASUSER = "run_as_user"
+const (
+	RUNASUSER    = "run_as_user"
+	USERAGENT    = "user_agent"
+	OMITHOSTNAME = "omit_hostname"
+	CREDENTIALS  = "credentials"
+	ROLEARN      = "role_arn"
+)
 
 type AgentConfig struct {
 	MetricsCollectInterval string `metrics_collection_interval`
 	Runasuser              string `run_as_user`
+	UserAgent              string `user_agent`
+	OmitHostname           bool   `omit_hostname`
+	RoleARN                string `role_arn`
 }
 
 func (config *AgentConfig) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
@@ -25,5 +34,25 @@ func (config *AgentConfig) ToMap(ctx *runtime.Context) (string, map[string]inter
 		resultMap[RUNASUSER] = config.Runasuser
 	}
 
+	if config.UserAgent != "" {
+		resultMap[USERAGENT] = config.UserAgent
+	}
+
+	if config.OmitHostname {
+		resultMap[OMITHOSTNAME] = true
+	}
+
+	if config.RoleARN != "" {
+		resultMap[CREDENTIALS] = map[string]interface{}{ROLEARN: config.RoleARN}
+	}
+
 	return "agent", resultMap
 }
+
+func (config *AgentConfig) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	ctx.MetricsCollectionInterval = util.ConfigInt(m, util.MapKeyMetricsCollectionInterval)
+	config.Runasuser = util.ConfigString(m, RUNASUSER)
+	config.UserAgent = util.ConfigString(m, USERAGENT)
+	config.OmitHostname = util.ConfigBool(m, OMITHOSTNAME)
+	config.RoleARN = util.ConfigString(util.ConfigMap(m, CREDENTIALS), ROLEARN)
+}
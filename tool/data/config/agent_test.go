@@ -28,4 +28,29 @@ func TestAgent_ToMap(t *testing.T) {
 	key, value = conf.ToMap(ctx)
 	assert.Equal(t, expectedKey, key)
 	assert.Equal(t, expectedValue, value)
+
+	userAgent := "my-org/1.0"
+	expectedValue = map[string]interface{}{util.MapKeyMetricsCollectionInterval: 10, RUNASUSER: runAsUser, USERAGENT: userAgent}
+	conf.UserAgent = userAgent
+	key, value = conf.ToMap(ctx)
+	assert.Equal(t, expectedKey, key)
+	assert.Equal(t, expectedValue, value)
+
+	expectedValue = map[string]interface{}{util.MapKeyMetricsCollectionInterval: 10, RUNASUSER: runAsUser, USERAGENT: userAgent, OMITHOSTNAME: true}
+	conf.OmitHostname = true
+	key, value = conf.ToMap(ctx)
+	assert.Equal(t, expectedKey, key)
+	assert.Equal(t, expectedValue, value)
+
+	roleARN := "arn:aws:iam::123456789012:role/CloudWatchAgent"
+	expectedValue = map[string]interface{}{util.MapKeyMetricsCollectionInterval: 10, RUNASUSER: runAsUser, USERAGENT: userAgent, OMITHOSTNAME: true,
+		CREDENTIALS: map[string]interface{}{ROLEARN: roleARN}}
+	conf.RoleARN = roleARN
+	key, value = conf.ToMap(ctx)
+	assert.Equal(t, expectedKey, key)
+	assert.Equal(t, expectedValue, value)
+
+	fromMap := new(AgentConfig)
+	fromMap.FromMap(ctx, value)
+	assert.Equal(t, conf, fromMap)
 }
@@ -6,12 +6,14 @@ package config
 import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/logs"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 type Logs struct {
 	ForceFlushInterval int `force_flush_interval`
 	LogStream          string
 	LogsCollect        *logs.Collection
+	MetricsCollect     *logs.MetricsCollection
 }
 
 func (config *Logs) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
@@ -22,6 +24,11 @@ func (config *Logs) ToMap(ctx *runtime.Context) (string, map[string]interface{})
 		resultMap[key] = value
 	}
 
+	if config.MetricsCollect != nil {
+		key, value := config.MetricsCollect.ToMap(ctx)
+		resultMap[key] = value
+	}
+
 	if config.ForceFlushInterval != 0 {
 		resultMap["force_flush_interval"] = config.ForceFlushInterval
 	}
@@ -33,17 +40,41 @@ func (config *Logs) ToMap(ctx *runtime.Context) (string, map[string]interface{})
 	return "logs", resultMap
 }
 
-func (config *Logs) AddLogFile(filePath, logGroupName string, logStream, timestampFormat, timezone, multiLineStartPattern, encoding string, retention int, logGroupClass string) {
+func (config *Logs) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.ForceFlushInterval = util.ConfigInt(m, "force_flush_interval")
+	config.LogStream = util.ConfigString(m, "log_stream_name")
+	config.LogsCollect = nil
+	if collected := util.ConfigMap(m, "logs_collected"); collected != nil {
+		config.LogsCollect = new(logs.Collection)
+		config.LogsCollect.FromMap(ctx, collected)
+	}
+}
+
+func (config *Logs) AddLogFile(filePath, logGroupName string, logStream, timestampFormat, timezone, multiLineStartPattern, encoding string, retention int, logGroupClass string, filters []map[string]string) {
 	if config.LogsCollect == nil {
 		config.LogsCollect = &logs.Collection{}
 	}
 
-	config.LogsCollect.AddLogFile(filePath, logGroupName, logStream, timestampFormat, timezone, multiLineStartPattern, encoding, retention, logGroupClass)
+	config.LogsCollect.AddLogFile(filePath, logGroupName, logStream, timestampFormat, timezone, multiLineStartPattern, encoding, retention, logGroupClass, filters)
 }
 
-func (config *Logs) AddWindowsEvent(eventName, logGroupName, logStream, eventFormat string, eventLevels []string, retention int, logGroupClass string) {
+func (config *Logs) AddWindowsEvent(eventName, logGroupName, logStream, eventFormat string, eventLevels []string, eventIDs []int, xpathQuery string, retention int, logGroupClass string) {
 	if config.LogsCollect == nil {
 		config.LogsCollect = &logs.Collection{}
 	}
-	config.LogsCollect.AddWindowsEvent(eventName, logGroupName, logStream, eventFormat, eventLevels, retention, logGroupClass)
+	config.LogsCollect.AddWindowsEvent(eventName, logGroupName, logStream, eventFormat, eventLevels, eventIDs, xpathQuery, retention, logGroupClass)
+}
+
+func (config *Logs) AddPrometheus(logGroupName, configPath string, emfProcessor map[string]interface{}) {
+	if config.MetricsCollect == nil {
+		config.MetricsCollect = &logs.MetricsCollection{}
+	}
+	config.MetricsCollect.AddPrometheus(logGroupName, configPath, emfProcessor)
+}
+
+func (config *Logs) AddContainerInsights(env, clusterName string, enhanced bool, interval int) {
+	if config.MetricsCollect == nil {
+		config.MetricsCollect = &logs.MetricsCollection{}
+	}
+	config.MetricsCollect.AddContainerInsights(env, clusterName, enhanced, interval)
 }
@@ -30,16 +30,29 @@ func (config *Collection) ToMap(ctx *runtime.Context) (string, map[string]interf
 	return "logs_collected", resultMap
 }
 
-func (config *Collection) AddWindowsEvent(eventName, logGroupName, logStreamName, eventFormat string, eventLevels []string, retention int, logGroupClass string) {
+func (config *Collection) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Files = nil
+	if files := util.ConfigMap(m, "files"); files != nil {
+		config.Files = new(Files)
+		config.Files.FromMap(ctx, files)
+	}
+	config.WinEvents = nil
+	if events := util.ConfigMap(m, "windows_events"); events != nil {
+		config.WinEvents = new(Events)
+		config.WinEvents.FromMap(ctx, events)
+	}
+}
+
+func (config *Collection) AddWindowsEvent(eventName, logGroupName, logStreamName, eventFormat string, eventLevels []string, eventIDs []int, xpathQuery string, retention int, logGroupClass string) {
 	if config.WinEvents == nil {
 		config.WinEvents = &Events{}
 	}
-	config.WinEvents.AddWindowsEvent(eventName, logGroupName, logStreamName, eventFormat, eventLevels, retention, logGroupClass)
+	config.WinEvents.AddWindowsEvent(eventName, logGroupName, logStreamName, eventFormat, eventLevels, eventIDs, xpathQuery, retention, logGroupClass)
 }
 
-func (config *Collection) AddLogFile(filePath, logGroupName, logStreamName string, timestampFormat, timezone, multiLineStartPattern, encoding string, retention int, logGroupClass string) {
+func (config *Collection) AddLogFile(filePath, logGroupName, logStreamName string, timestampFormat, timezone, multiLineStartPattern, encoding string, retention int, logGroupClass string, filters []map[string]string) {
 	if config.Files == nil {
 		config.Files = &Files{}
 	}
-	config.Files.AddLogFile(filePath, logGroupName, logStreamName, timestampFormat, timezone, multiLineStartPattern, encoding, retention, logGroupClass)
+	config.Files.AddLogFile(filePath, logGroupName, logStreamName, timestampFormat, timezone, multiLineStartPattern, encoding, retention, logGroupClass, filters)
 }
@@ -3,18 +3,22 @@
 
 package logs
 
-import "github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+import (
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
 
 type Config struct {
-	FilePath              string `file_path`
-	LogGroup              string `log_group_name`
-	LogStream             string `log_stream_name`
-	LogGroupClass         string `log_group_class`
-	TimestampFormat       string `timestamp_format`
-	Timezone              string `timezone`
-	MultiLineStartPattern string `multi_line_start_pattern`
-	Encoding              string `encoding`
-	Retention             int    `retention_in_days`
+	FilePath              string              `file_path`
+	LogGroup              string              `log_group_name`
+	LogStream             string              `log_stream_name`
+	LogGroupClass         string              `log_group_class`
+	TimestampFormat       string              `timestamp_format`
+	Timezone              string              `timezone`
+	MultiLineStartPattern string              `multi_line_start_pattern`
+	Encoding              string              `encoding`
+	Retention             int                 `retention_in_days`
+	Filters               []map[string]string `filters`
 }
 
 func (config *Config) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
@@ -39,8 +43,31 @@ func (config *Config) ToMap(ctx *runtime.Context) (string, map[string]interface{
 	if config.Retention != 0 {
 		resultMap["retention_in_days"] = config.Retention
 	}
+	if len(config.Filters) > 0 {
+		resultMap["filters"] = config.Filters
+	}
 	if config.LogGroupClass != "" {
 		resultMap["log_group_class"] = config.LogGroupClass
 	}
 	return "", resultMap
 }
+
+func (config *Config) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.FilePath = util.ConfigString(m, "file_path")
+	config.LogGroup = util.ConfigString(m, "log_group_name")
+	config.LogStream = util.ConfigString(m, "log_stream_name")
+	config.LogGroupClass = util.ConfigString(m, "log_group_class")
+	config.TimestampFormat = util.ConfigString(m, "timestamp_format")
+	config.Timezone = util.ConfigString(m, "timezone")
+	config.MultiLineStartPattern = util.ConfigString(m, "multi_line_start_pattern")
+	config.Encoding = util.ConfigString(m, "encoding")
+	config.Retention = util.ConfigInt(m, "retention_in_days")
+	config.Filters = nil
+	for _, filter := range util.ConfigMapList(m, "filters") {
+		singleFilter := make(map[string]string, len(filter))
+		for key := range filter {
+			singleFilter[key] = util.ConfigString(filter, key)
+		}
+		config.Filters = append(config.Filters, singleFilter)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+// ContainerInsights collects the Container Insights metrics of the ECS or Kubernetes cluster the agent
+// runs in, Env being util.ContainerEnvECS or util.ContainerEnvEKS. The ECS section takes the cluster
+// from the task metadata, so ClusterName and EnhancedContainerInsights are only written for Kubernetes.
+type ContainerInsights struct {
+	Env                       string
+	ClusterName               string
+	EnhancedContainerInsights bool
+	MetricsCollectionInterval int
+}
+
+func (config *ContainerInsights) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
+	resultMap := make(map[string]interface{})
+	if config.MetricsCollectionInterval != 0 {
+		resultMap[util.MapKeyMetricsCollectionInterval] = config.MetricsCollectionInterval
+	}
+	if config.Env == util.ContainerEnvECS {
+		return "ecs", resultMap
+	}
+	resultMap["cluster_name"] = config.ClusterName
+	if config.EnhancedContainerInsights {
+		resultMap["enhanced_container_insights"] = true
+	}
+	return "kubernetes", resultMap
+}
@@ -3,11 +3,16 @@
 
 package logs
 
-import "github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+import (
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
 
 type EventConfig struct {
 	EventName     string   `event_name`
 	EventLevels   []string `event_levels`
+	EventIDs      []int    `event_ids`
+	XPathQuery    string   `xpath_query`
 	EventFormat   string   `event_format`
 	LogGroup      string   `log_group_name`
 	LogStream     string   `log_stream_name`
@@ -21,6 +26,12 @@ func (config *EventConfig) ToMap(ctx *runtime.Context) (string, map[string]inter
 	if config.EventLevels != nil && len(config.EventLevels) > 0 {
 		resultMap["event_levels"] = config.EventLevels
 	}
+	if len(config.EventIDs) > 0 {
+		resultMap["event_ids"] = config.EventIDs
+	}
+	if config.XPathQuery != "" {
+		resultMap["xpath_query"] = config.XPathQuery
+	}
 	if config.EventFormat != "" {
 		resultMap["event_format"] = config.EventFormat
 	}
@@ -34,3 +45,15 @@ func (config *EventConfig) ToMap(ctx *runtime.Context) (string, map[string]inter
 	}
 	return "", resultMap
 }
+
+func (config *EventConfig) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.EventName = util.ConfigString(m, "event_name")
+	config.EventLevels = util.ConfigStringList(m, "event_levels")
+	config.EventIDs = util.ConfigIntList(m, "event_ids")
+	config.XPathQuery = util.ConfigString(m, "xpath_query")
+	config.EventFormat = util.ConfigString(m, "event_format")
+	config.LogGroup = util.ConfigString(m, "log_group_name")
+	config.LogStream = util.ConfigString(m, "log_stream_name")
+	config.LogGroupClass = util.ConfigString(m, "log_group_class")
+	config.Retention = util.ConfigInt(m, "retention_in_days")
+}
@@ -3,7 +3,10 @@
 
 package logs
 
-import "github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+import (
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
 
 type Events struct {
 	EventConfigs []*EventConfig
@@ -21,7 +24,16 @@ func (config *Events) ToMap(ctx *runtime.Context) (string, map[string]interface{
 	return "windows_events", resultMap
 }
 
-func (config *Events) AddWindowsEvent(eventName, logGroupName, logStreamName, eventFormat string, eventLevels []string, retention int, logGroupClass string) {
+func (config *Events) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.EventConfigs = []*EventConfig{}
+	for _, entry := range util.ConfigMapList(m, "collect_list") {
+		singleEvent := new(EventConfig)
+		singleEvent.FromMap(ctx, entry)
+		config.EventConfigs = append(config.EventConfigs, singleEvent)
+	}
+}
+
+func (config *Events) AddWindowsEvent(eventName, logGroupName, logStreamName, eventFormat string, eventLevels []string, eventIDs []int, xpathQuery string, retention int, logGroupClass string) {
 	if config.EventConfigs == nil {
 		config.EventConfigs = []*EventConfig{}
 	}
@@ -32,6 +44,8 @@ func (config *Events) AddWindowsEvent(eventName, logGroupName, logStreamName, ev
 		LogGroupClass: logGroupClass,
 		EventFormat:   eventFormat,
 		EventLevels:   eventLevels,
+		EventIDs:      eventIDs,
+		XPathQuery:    xpathQuery,
 		Retention:     retention,
 	}
 	config.EventConfigs = append(config.EventConfigs, singleEvent)
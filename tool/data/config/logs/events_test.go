@@ -14,8 +14,9 @@ import (
 
 func TestEvents_ToMap(t *testing.T) {
 	conf := new(Events)
-	conf.AddWindowsEvent("EN1", "LG1", "LS1", "", []string{"ERROR", "SUCCESS"}, 1, util.InfrequentAccessLogGroupClass)
-	conf.AddWindowsEvent("EN2", "LG2", "LS2", "xml", []string{"ERROR"}, 1, util.InfrequentAccessLogGroupClass)
+	conf.AddWindowsEvent("EN1", "LG1", "LS1", "", []string{"ERROR", "SUCCESS"}, nil, "", 1, util.InfrequentAccessLogGroupClass)
+	conf.AddWindowsEvent("EN2", "LG2", "LS2", "xml", []string{"ERROR"}, []int{4624, 4625}, "", 1, util.InfrequentAccessLogGroupClass)
+	conf.AddWindowsEvent("EN3", "LG3", "LS3", "", nil, nil, "*[System[EventID=7036]]", 0, "")
 
 	ctx := &runtime.Context{}
 	actualkey, actualValue := conf.ToMap(ctx)
@@ -24,7 +25,8 @@ func TestEvents_ToMap(t *testing.T) {
 	expectedVal := map[string]interface{}{
 		"collect_list": []map[string]interface{}{
 			{"event_name": "EN1", "event_levels": []string{"ERROR", "SUCCESS"}, "log_group_name": "LG1", "log_stream_name": "LS1", "retention_in_days": 1, "log_group_class": util.InfrequentAccessLogGroupClass},
-			{"event_name": "EN2", "event_levels": []string{"ERROR"}, "log_group_name": "LG2", "log_stream_name": "LS2", "event_format": "xml", "retention_in_days": 1, "log_group_class": util.InfrequentAccessLogGroupClass},
+			{"event_name": "EN2", "event_levels": []string{"ERROR"}, "event_ids": []int{4624, 4625}, "log_group_name": "LG2", "log_stream_name": "LS2", "event_format": "xml", "retention_in_days": 1, "log_group_class": util.InfrequentAccessLogGroupClass},
+			{"event_name": "EN3", "xpath_query": "*[System[EventID=7036]]", "log_group_name": "LG3", "log_stream_name": "LS3"},
 		},
 	}
 	assert.Equal(t, expectedKey, actualkey)
@@ -5,6 +5,7 @@ package logs
 
 import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 type Files struct {
@@ -24,7 +25,16 @@ func (config *Files) ToMap(ctx *runtime.Context) (string, map[string]interface{}
 	return "files", resultMap
 }
 
-func (config *Files) AddLogFile(filePath, logGroupName, logStreamName string, timestampFormat, timezone, multiLineStartPattern, encoding string, retention int, logGroupClass string) {
+func (config *Files) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.FileConfigs = []*Config{}
+	for _, entry := range util.ConfigMapList(m, "collect_list") {
+		singleFile := new(Config)
+		singleFile.FromMap(ctx, entry)
+		config.FileConfigs = append(config.FileConfigs, singleFile)
+	}
+}
+
+func (config *Files) AddLogFile(filePath, logGroupName, logStreamName string, timestampFormat, timezone, multiLineStartPattern, encoding string, retention int, logGroupClass string, filters []map[string]string) {
 	if config.FileConfigs == nil {
 		config.FileConfigs = []*Config{}
 	}
@@ -51,5 +61,8 @@ func (config *Files) AddLogFile(filePath, logGroupName, logStreamName string, ti
 	if retention != 0 {
 		singleFile.Retention = retention
 	}
+	if len(filters) > 0 {
+		singleFile.Filters = filters
+	}
 	config.FileConfigs = append(config.FileConfigs, singleFile)
 }
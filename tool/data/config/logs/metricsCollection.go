@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+// MetricsCollection holds the metrics the agent publishes as embedded metric format logs.
+type MetricsCollection struct {
+	Prometheus        *Prometheus
+	ContainerInsights *ContainerInsights
+}
+
+func (config *MetricsCollection) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
+	resultMap := make(map[string]interface{})
+
+	if config.Prometheus != nil {
+		util.AddToMap(ctx, resultMap, config.Prometheus)
+	}
+
+	if config.ContainerInsights != nil {
+		util.AddToMap(ctx, resultMap, config.ContainerInsights)
+	}
+
+	return "metrics_collected", resultMap
+}
+
+func (config *MetricsCollection) AddPrometheus(logGroupName, configPath string, emfProcessor map[string]interface{}) {
+	config.Prometheus = &Prometheus{
+		LogGroupName: logGroupName,
+		ConfigPath:   configPath,
+		EMFProcessor: emfProcessor,
+	}
+}
+
+func (config *MetricsCollection) AddContainerInsights(env, clusterName string, enhanced bool, interval int) {
+	config.ContainerInsights = &ContainerInsights{
+		Env:                       env,
+		ClusterName:               clusterName,
+		EnhancedContainerInsights: enhanced,
+		MetricsCollectionInterval: interval,
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func TestMetricsCollection_ToMap(t *testing.T) {
+	conf := new(MetricsCollection)
+	ctx := &runtime.Context{}
+	actualKey, actualValue := conf.ToMap(ctx)
+	assert.Equal(t, "metrics_collected", actualKey)
+	assert.Equal(t, map[string]interface{}{}, actualValue)
+
+	conf.AddPrometheus("prometheus", "/opt/aws/amazon-cloudwatch-agent/etc/prometheus.yaml", map[string]interface{}{"metric_namespace": "CWAgent/Prometheus"})
+	_, actualValue = conf.ToMap(ctx)
+	assert.Equal(t, map[string]interface{}{
+		"prometheus": map[string]interface{}{
+			"log_group_name":         "prometheus",
+			"prometheus_config_path": "/opt/aws/amazon-cloudwatch-agent/etc/prometheus.yaml",
+			"emf_processor":          map[string]interface{}{"metric_namespace": "CWAgent/Prometheus"},
+		},
+	}, actualValue)
+
+	conf = new(MetricsCollection)
+	conf.AddContainerInsights(util.ContainerEnvEKS, "eks-prod", true, 30)
+	_, actualValue = conf.ToMap(ctx)
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes": map[string]interface{}{
+			"cluster_name":                "eks-prod",
+			"enhanced_container_insights": true,
+			"metrics_collection_interval": 30,
+		},
+	}, actualValue)
+
+	conf.AddContainerInsights(util.ContainerEnvECS, "ecs-prod", false, 60)
+	_, actualValue = conf.ToMap(ctx)
+	assert.Equal(t, map[string]interface{}{
+		"ecs": map[string]interface{}{"metrics_collection_interval": 60},
+	}, actualValue)
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+)
+
+// Prometheus scrapes the jobs of the Prometheus scrape config at ConfigPath, and publishes the metrics
+// to LogGroupName, turned into CloudWatch metrics by the emf_processor.
+type Prometheus struct {
+	LogGroupName string
+	ConfigPath   string
+	EMFProcessor map[string]interface{}
+}
+
+func (config *Prometheus) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
+	resultMap := make(map[string]interface{})
+	resultMap["log_group_name"] = config.LogGroupName
+	resultMap["prometheus_config_path"] = config.ConfigPath
+	if config.EMFProcessor != nil {
+		resultMap["emf_processor"] = config.EMFProcessor
+	}
+	return "prometheus", resultMap
+}
@@ -10,10 +10,27 @@ type AggregationDimensions struct {
 }
 
 func (config *AggregationDimensions) ToMap(ctx *runtime.Context) (string, [][]string) {
-	config.SetDefaultDimensions()
+	if config.Dimensions == nil {
+		config.SetDefaultDimensions()
+	}
 	return "aggregation_dimensions", config.Dimensions
 }
 
+func (config *AggregationDimensions) FromMap(ctx *runtime.Context, dimensions []interface{}) {
+	config.Dimensions = [][]string{}
+	for _, item := range dimensions {
+		set := []string{}
+		if names, ok := item.([]interface{}); ok {
+			for _, name := range names {
+				if s, ok := name.(string); ok {
+					set = append(set, s)
+				}
+			}
+		}
+		config.Dimensions = append(config.Dimensions, set)
+	}
+}
+
 func (config *AggregationDimensions) SetDefaultDimensions() {
 	config.Dimensions = [][]string{{"InstanceId"}}
 }
@@ -10,10 +10,19 @@ type AppendDimensions struct {
 }
 
 func (config *AppendDimensions) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
-	config.SetDefaultDimensions()
+	if config.Dimensions == nil {
+		config.SetDefaultDimensions()
+	}
 	return "append_dimensions", config.Dimensions
 }
 
+func (config *AppendDimensions) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Dimensions = make(map[string]interface{}, len(m))
+	for key, value := range m {
+		config.Dimensions[key] = value
+	}
+}
+
 func (config *AppendDimensions) SetDefaultDimensions() {
 	config.Dimensions = map[string]interface{}{
 		"ImageId":              "${aws:ImageId}",
@@ -5,18 +5,38 @@ package collectd
 
 import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 type CollectD struct {
-	MetricsAggregationInterval int `metrics_aggregation_interval`
+	MetricsAggregationInterval int      `metrics_aggregation_interval`
+	TypesDB                    []string `collectd_typesdb`
+	SecurityLevel              string   `collectd_security_level`
+	AuthFile                   string   `collectd_auth_file`
 }
 
 func (config *CollectD) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
 	resultMap := make(map[string]interface{})
 	resultMap["metrics_aggregation_interval"] = config.MetricsAggregationInterval
+	if len(config.TypesDB) > 0 {
+		resultMap["collectd_typesdb"] = config.TypesDB
+	}
+	if config.SecurityLevel != "" {
+		resultMap["collectd_security_level"] = config.SecurityLevel
+	}
+	if config.AuthFile != "" {
+		resultMap["collectd_auth_file"] = config.AuthFile
+	}
 	return "collectd", resultMap
 }
 
+func (config *CollectD) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.MetricsAggregationInterval = util.ConfigInt(m, "metrics_aggregation_interval")
+	config.TypesDB = util.ConfigStringList(m, "collectd_typesdb")
+	config.SecurityLevel = util.ConfigString(m, "collectd_security_level")
+	config.AuthFile = util.ConfigString(m, "collectd_auth_file")
+}
+
 func (config *CollectD) Enable() {
 	config.MetricsAggregationInterval = 60
 }
@@ -22,4 +22,18 @@ func TestCollectD_ToMap(t *testing.T) {
 	key, value := conf.ToMap(ctx)
 	assert.Equal(t, expectedKey, key)
 	assert.Equal(t, expectedValue, value)
+
+	conf.TypesDB = []string{"/usr/share/collectd/types.db"}
+	expectedValue["collectd_typesdb"] = []string{"/usr/share/collectd/types.db"}
+	key, value = conf.ToMap(ctx)
+	assert.Equal(t, expectedKey, key)
+	assert.Equal(t, expectedValue, value)
+
+	conf.SecurityLevel = "sign"
+	conf.AuthFile = "/etc/collectd/auth_file"
+	expectedValue["collectd_security_level"] = "sign"
+	expectedValue["collectd_auth_file"] = "/etc/collectd/auth_file"
+	key, value = conf.ToMap(ctx)
+	assert.Equal(t, expectedKey, key)
+	assert.Equal(t, expectedValue, value)
 }
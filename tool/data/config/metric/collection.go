@@ -4,10 +4,13 @@
 package metric
 
 import (
+	"reflect"
+
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/metric/collectd"
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/metric/linux"
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/metric/statsd"
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/metric/windows"
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/interfaze"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
@@ -138,3 +141,92 @@ func (config *Collection) EnableAll(ctx *runtime.Context) {
 	config.StatsD = new(statsd.StatsD)
 	config.StatsD.Enable()
 }
+
+// plugin is a metric plugin of the collection, such as linux.CPU.
+type plugin interface {
+	interfaze.ConvertibleToMap
+	FromMap(ctx *runtime.Context, m map[string]interface{})
+	Enable()
+}
+
+// plugins returns pointers to the fields holding the plugins the OS of ctx collects, in the order ToMap
+// adds them.
+func (config *Collection) plugins(ctx *runtime.Context) []interface{} {
+	if ctx.OsParameter == util.OsTypeWindows {
+		return []interface{}{&config.WinLogicalDisk, &config.WinPhysicalDisk, &config.WinMemory, &config.WinNetworkInterface,
+			&config.WinProcessor, &config.WinTCPv4, &config.WinTCPv6, &config.WinPagingFile, &config.StatsD}
+	}
+	return []interface{}{&config.CPU, &config.Disk, &config.DiskIO, &config.Memory, &config.Net, &config.NetStat,
+		&config.Swap, &config.CollectD, &config.StatsD}
+}
+
+// pluginKey returns the key in metrics_collected of the plugin a field of plugins holds.
+func pluginKey(ctx *runtime.Context, field interface{}) string {
+	key, _ := newPlugin(field).ToMap(ctx)
+	return key
+}
+
+func newPlugin(field interface{}) plugin {
+	return reflect.New(reflect.TypeOf(field).Elem().Elem()).Interface().(plugin)
+}
+
+// FromMap sets the plugins of the collection from the metrics_collected section of a config. Sections
+// of plugins the wizard does not know are ignored.
+func (config *Collection) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	for _, field := range config.plugins(ctx) {
+		v := reflect.ValueOf(field).Elem()
+		v.Set(reflect.Zero(v.Type()))
+		if entry := util.ConfigMap(m, pluginKey(ctx, field)); entry != nil {
+			p := newPlugin(field)
+			p.FromMap(ctx, entry)
+			v.Set(reflect.ValueOf(p))
+		}
+	}
+}
+
+// PluginKeys returns the keys in metrics_collected of the plugins the OS of ctx collects, and whether
+// each of them is enabled.
+func (config *Collection) PluginKeys(ctx *runtime.Context) ([]string, []bool) {
+	fields := config.plugins(ctx)
+	keys := make([]string, len(fields))
+	enabled := make([]bool, len(fields))
+	for i, field := range fields {
+		keys[i] = pluginKey(ctx, field)
+		enabled[i] = !reflect.ValueOf(field).Elem().IsNil()
+	}
+	return keys, enabled
+}
+
+// Plugin returns the enabled plugin with the key in metrics_collected, or nil when it is disabled.
+func (config *Collection) Plugin(ctx *runtime.Context, key string) interface{} {
+	for _, field := range config.plugins(ctx) {
+		if v := reflect.ValueOf(field).Elem(); pluginKey(ctx, field) == key && !v.IsNil() {
+			return v.Interface()
+		}
+	}
+	return nil
+}
+
+// EnablePlugin enables the plugin with the key in metrics_collected with all its measurements, and
+// returns false when the OS of ctx has no such plugin.
+func (config *Collection) EnablePlugin(ctx *runtime.Context, key string) bool {
+	for _, field := range config.plugins(ctx) {
+		if pluginKey(ctx, field) == key {
+			p := newPlugin(field)
+			p.Enable()
+			reflect.ValueOf(field).Elem().Set(reflect.ValueOf(p))
+			return true
+		}
+	}
+	return false
+}
+
+// DisablePlugin disables the plugin with the key in metrics_collected.
+func (config *Collection) DisablePlugin(ctx *runtime.Context, key string) {
+	for _, field := range config.plugins(ctx) {
+		if pluginKey(ctx, field) == key {
+			v := reflect.ValueOf(field).Elem()
+			v.Set(reflect.Zero(v.Type()))
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/metric/windows"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
@@ -67,3 +68,41 @@ func TestCollection_ToMap(t *testing.T) {
 	assert.Equal(t, expectedKey, key)
 	assert.Equal(t, expectedValue, value)
 }
+
+func TestCollection_FromMap(t *testing.T) {
+	ctx := &runtime.Context{OsParameter: util.OsTypeLinux, WantPerInstanceMetrics: true}
+	conf := new(Collection)
+	conf.EnableAll(ctx)
+	_, value := conf.ToMap(ctx)
+
+	imported := new(Collection)
+	imported.FromMap(ctx, value)
+	_, importedValue := imported.ToMap(ctx)
+	assert.Equal(t, value, importedValue)
+
+	ctx = &runtime.Context{OsParameter: util.OsTypeWindows}
+	imported = new(Collection)
+	imported.FromMap(ctx, map[string]interface{}{
+		"Processor": map[string]interface{}{"resources": []interface{}{"_Total"}, "measurement": []interface{}{"% Idle Time"}},
+		"cpu":       map[string]interface{}{"measurement": []interface{}{"cpu_usage_idle"}},
+	})
+	assert.Equal(t, &Collection{WinProcessor: &windows.Processor{Instances: []string{"_Total"}, PercentIdleTime: true}}, imported)
+}
+
+func TestCollection_Plugins(t *testing.T) {
+	ctx := &runtime.Context{OsParameter: util.OsTypeLinux}
+	conf := new(Collection)
+	assert.True(t, conf.EnablePlugin(ctx, "cpu"))
+	assert.True(t, conf.EnablePlugin(ctx, "statsd"))
+	assert.False(t, conf.EnablePlugin(ctx, "Processor"))
+	keys, enabled := conf.PluginKeys(ctx)
+	assert.Equal(t, []string{"cpu", "disk", "diskio", "mem", "net", "netstat", "swap", "collectd", "statsd"}, keys)
+	assert.Equal(t, []bool{true, false, false, false, false, false, false, false, true}, enabled)
+	assert.Equal(t, conf.CPU, conf.Plugin(ctx, "cpu"))
+	assert.Nil(t, conf.Plugin(ctx, "disk"))
+
+	conf.DisablePlugin(ctx, "cpu")
+	assert.Nil(t, conf.CPU)
+	assert.Nil(t, conf.Plugin(ctx, "cpu"))
+	assert.NotNil(t, conf.StatsD)
+}
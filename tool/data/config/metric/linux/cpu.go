@@ -57,6 +57,13 @@ func (config *CPU) ToMap(ctx *runtime.Context) (string, map[string]interface{})
 	return "cpu", resultMap
 }
 
+func (config *CPU) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.PerCore = len(util.ConfigStringList(m, util.MapKeyInstances)) > 0
+	ctx.WantPerInstanceMetrics = config.PerCore
+	config.TotalCPU = util.ConfigBool(m, "totalcpu")
+	util.SetMeasurementFields(config, "cpu", m[util.MapKeyMeasurement])
+}
+
 func (config *CPU) Enable() {
 	config.PerCore = true
 	config.TotalCPU = true
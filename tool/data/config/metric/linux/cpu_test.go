@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 func TestCPU_ToMap(t *testing.T) {
@@ -23,3 +24,29 @@ func TestCPU_ToMap(t *testing.T) {
 	assert.Equal(t, expectedKey, key)
 	assert.Equal(t, expectedValue, value)
 }
+
+func TestCPU_FromMap(t *testing.T) {
+	ctx := new(runtime.Context)
+	conf := &CPU{UsageSteal: true}
+	conf.FromMap(ctx, map[string]interface{}{
+		"resources": []interface{}{"*"},
+		"totalcpu":  "true",
+		"measurement": []interface{}{
+			"usage_idle",
+			map[string]interface{}{"name": "cpu_usage_user", "rename": "CPU_USAGE_USER"},
+			"time_active",
+		},
+	})
+	assert.True(t, ctx.WantPerInstanceMetrics)
+	assert.Equal(t, &CPU{PerCore: true, TotalCPU: true, UsageIdle: true, UsageUser: true}, conf)
+	assert.Equal(t, []string{"cpu_usage_idle", "cpu_usage_iowait", "cpu_usage_steal", "cpu_usage_guest", "cpu_usage_user", "cpu_usage_system"}, util.MeasurementFields(conf))
+	assert.Equal(t, "cpu_usage_iowait", util.MatchMeasurementField(conf, "cpu", "usage_iowait"))
+	assert.Equal(t, "", util.MatchMeasurementField(conf, "cpu", "time_active"))
+
+	conf.FromMap(ctx, map[string]interface{}{"measurement": []string{"cpu_usage_system"}})
+	assert.False(t, ctx.WantPerInstanceMetrics)
+	assert.Equal(t, &CPU{UsageSystem: true}, conf)
+	assert.True(t, util.SetMeasurementField(conf, "cpu_usage_guest", true))
+	assert.False(t, util.SetMeasurementField(conf, "usage_guest", true))
+	assert.True(t, util.MeasurementField(conf, "cpu_usage_guest"))
+}
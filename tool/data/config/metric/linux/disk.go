@@ -36,6 +36,11 @@ func (config *Disk) ToMap(ctx *runtime.Context) (string, map[string]interface{})
 	return "disk", resultMap
 }
 
+func (config *Disk) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Instances = util.ConfigStringList(m, util.MapKeyInstances)
+	util.SetMeasurementFields(config, "disk", m[util.MapKeyMeasurement])
+}
+
 func (config *Disk) Enable() {
 	config.UsedPercent = true
 	config.InodesFree = true
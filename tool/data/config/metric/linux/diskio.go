@@ -48,6 +48,11 @@ func (config *DiskIO) ToMap(ctx *runtime.Context) (string, map[string]interface{
 	return "diskio", resultMap
 }
 
+func (config *DiskIO) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Instances = util.ConfigStringList(m, util.MapKeyInstances)
+	util.SetMeasurementFields(config, "diskio", m[util.MapKeyMeasurement])
+}
+
 func (config *DiskIO) Enable() {
 	config.IOTime = true
 	config.WriteBytes = true
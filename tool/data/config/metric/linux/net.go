@@ -44,6 +44,11 @@ func (config *Net) ToMap(ctx *runtime.Context) (string, map[string]interface{})
 	return "net", resultMap
 }
 
+func (config *Net) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Instances = util.ConfigStringList(m, util.MapKeyInstances)
+	util.SetMeasurementFields(config, "net", m[util.MapKeyMeasurement])
+}
+
 func (config *Net) Enable() {
 	config.BytesSent = true
 	config.BytesReceived = true
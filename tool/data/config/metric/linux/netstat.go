@@ -29,6 +29,10 @@ func (config *NetStat) ToMap(ctx *runtime.Context) (string, map[string]interface
 	return "netstat", resultMap
 }
 
+func (config *NetStat) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	util.SetMeasurementFields(config, "netstat", m[util.MapKeyMeasurement])
+}
+
 func (config *NetStat) Enable() {
 	config.TCPEstablished = true
 	config.TCPTimeWait = true
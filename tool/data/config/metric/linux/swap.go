@@ -25,6 +25,10 @@ func (config *Swap) ToMap(ctx *runtime.Context) (string, map[string]interface{})
 	return "swap", resultMap
 }
 
+func (config *Swap) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	util.SetMeasurementFields(config, "swap", m[util.MapKeyMeasurement])
+}
+
 func (config *Swap) Enable() {
 	config.UsedPercent = true
 }
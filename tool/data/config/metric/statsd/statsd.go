@@ -5,6 +5,7 @@ package statsd
 
 import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 type StatsD struct {
@@ -26,6 +27,12 @@ func (config *StatsD) ToMap(ctx *runtime.Context) (string, map[string]interface{
 	return "statsd", resultMap
 }
 
+func (config *StatsD) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.ServiceAddress = util.ConfigString(m, "service_address")
+	config.MetricsCollectionInterval = util.ConfigInt(m, "metrics_collection_interval")
+	config.MetricsAggregationInterval = util.ConfigInt(m, "metrics_aggregation_interval")
+}
+
 func (config *StatsD) Enable() {
 	config.ServiceAddress = ":8125"
 	config.MetricsCollectionInterval = 10
@@ -40,6 +40,11 @@ func (config *LogicalDisk) ToMap(ctx *runtime.Context) (string, map[string]inter
 	return "LogicalDisk", resultMap
 }
 
+func (config *LogicalDisk) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Instances = util.ConfigStringList(m, util.MapKeyInstances)
+	util.SetMeasurementFields(config, "LogicalDisk", m[util.MapKeyMeasurement])
+}
+
 func (config *LogicalDisk) Enable() {
 	config.PercentFreeSpace = true
 }
@@ -25,6 +25,10 @@ func (config *Memory) ToMap(ctx *runtime.Context) (string, map[string]interface{
 	return "Memory", resultMap
 }
 
+func (config *Memory) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	util.SetMeasurementFields(config, "Memory", m[util.MapKeyMeasurement])
+}
+
 func (config *Memory) Enable() {
 	config.PercentCommittedBytesInUse = true
 }
@@ -44,6 +44,11 @@ func (config *NetworkInterface) ToMap(ctx *runtime.Context) (string, map[string]
 	return "Network Interface", resultMap
 }
 
+func (config *NetworkInterface) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Instances = util.ConfigStringList(m, util.MapKeyInstances)
+	util.SetMeasurementFields(config, "Network Interface", m[util.MapKeyMeasurement])
+}
+
 func (config *NetworkInterface) Enable() {
 	config.BytesSentPerSec = true
 	config.BytesReceivedPerSec = true
@@ -36,6 +36,11 @@ func (config *PagingFile) ToMap(ctx *runtime.Context) (string, map[string]interf
 	return "Paging File", resultMap
 }
 
+func (config *PagingFile) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Instances = util.ConfigStringList(m, util.MapKeyInstances)
+	util.SetMeasurementFields(config, "Paging File", m[util.MapKeyMeasurement])
+}
+
 func (config *PagingFile) Enable() {
 	config.PercentUsage = true
 }
@@ -52,6 +52,11 @@ func (config *PhysicalDisk) ToMap(ctx *runtime.Context) (string, map[string]inte
 	return "PhysicalDisk", resultMap
 }
 
+func (config *PhysicalDisk) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Instances = util.ConfigStringList(m, util.MapKeyInstances)
+	util.SetMeasurementFields(config, "PhysicalDisk", m[util.MapKeyMeasurement])
+}
+
 func (config *PhysicalDisk) Enable() {
 	config.PercentDiskTime = true
 	config.DiskWriteBytesPerSec = true
@@ -50,6 +50,11 @@ func (config *Processor) ToMap(ctx *runtime.Context) (string, map[string]interfa
 	return "Processor", resultMap
 }
 
+func (config *Processor) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.Instances = util.ConfigStringList(m, util.MapKeyInstances)
+	util.SetMeasurementFields(config, "Processor", m[util.MapKeyMeasurement])
+}
+
 func (config *Processor) Enable() {
 	config.PercentProcessorTime = true
 	config.PercentUserTime = true
@@ -25,6 +25,10 @@ func (config *TCPv4) ToMap(ctx *runtime.Context) (string, map[string]interface{}
 	return "TCPv4", resultMap
 }
 
+func (config *TCPv4) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	util.SetMeasurementFields(config, "TCPv4", m[util.MapKeyMeasurement])
+}
+
 func (config *TCPv4) Enable() {
 	config.ConnectionsEstablished = true
 }
@@ -25,6 +25,10 @@ func (config *TCPv6) ToMap(ctx *runtime.Context) (string, map[string]interface{}
 	return "TCPv6", resultMap
 }
 
+func (config *TCPv6) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	util.SetMeasurementFields(config, "TCPv6", m[util.MapKeyMeasurement])
+}
+
 func (config *TCPv6) Enable() {
 	config.ConnectionsEstablished = true
 }
@@ -6,6 +6,7 @@ package config
 import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/metric"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 type Metrics struct {
@@ -50,6 +51,28 @@ func (config *Metrics) ToMap(ctx *runtime.Context) (string, map[string]interface
 	return "metrics", resultMap
 }
 
+func (config *Metrics) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	config.AppendDimensions = nil
+	if dimensions := util.ConfigMap(m, "append_dimensions"); dimensions != nil {
+		config.AppendDimensions = new(metric.AppendDimensions)
+		config.AppendDimensions.FromMap(ctx, dimensions)
+	}
+	ctx.WantEC2TagDimensions = config.AppendDimensions != nil
+
+	config.AggregationDimensions = nil
+	if dimensions, ok := m["aggregation_dimensions"].([]interface{}); ok {
+		config.AggregationDimensions = new(metric.AggregationDimensions)
+		config.AggregationDimensions.FromMap(ctx, dimensions)
+	}
+	ctx.WantAggregateDimensions = config.AggregationDimensions != nil
+
+	config.MetricsCollect = nil
+	if collected := util.ConfigMap(m, "metrics_collected"); collected != nil {
+		config.MetricsCollect = new(metric.Collection)
+		config.MetricsCollect.FromMap(ctx, collected)
+	}
+}
+
 func (config *Metrics) CollectAllMetrics(ctx *runtime.Context) {
 	config.MetricsCollect = new(metric.Collection)
 	config.MetricsCollect.EnableAll(ctx)
@@ -46,3 +46,32 @@ func TestConfig_ToMap(t *testing.T) {
 	assert.Equal(t, expectedKey, key)
 	assert.Equal(t, expectedValue, value)
 }
+
+func TestConfig_ToMapEnvReferences(t *testing.T) {
+	conf := new(Config)
+	conf.LogsConf().AddLogFile("/var/log/${CWAGENT_TEST_APP}/*.log", "${CWAGENT_TEST_LOG_GROUP}", "{instance_id}", "", "", "", "", -1, util.StandardLogGroupClass, nil)
+	_, resultMap := conf.ToMap(&runtime.Context{OsParameter: util.OsTypeLinux})
+
+	t.Setenv("CWAGENT_TEST_LOG_GROUP", "app")
+	references, unset := util.DetectEnvReferences(resultMap)
+	assert.Equal(t, []string{"CWAGENT_TEST_APP", "CWAGENT_TEST_LOG_GROUP"}, references)
+	assert.Equal(t, []string{"CWAGENT_TEST_APP"}, unset)
+}
+
+func TestConfig_ToMapPolicyViolations(t *testing.T) {
+	above := float64(365)
+	policy := util.Policy{Rules: []util.PolicyRule{{
+		Name:     "long-retention",
+		Severity: util.SeverityWarning,
+		Message:  "log groups should not be kept for more than a year",
+		Match:    []util.PolicyPattern{{Path: "logs.logs_collected.files.collect_list[*].retention_in_days", Above: &above}},
+	}}}
+	conf := new(Config)
+	conf.LogsConf().AddLogFile("/var/log/messages", "messages", "{instance_id}", "", "", "", "", 30, util.StandardLogGroupClass, nil)
+	conf.LogsConf().AddLogFile("/var/log/audit.log", "audit", "{instance_id}", "", "", "", "", 731, util.StandardLogGroupClass, nil)
+	_, resultMap := conf.ToMap(&runtime.Context{OsParameter: util.OsTypeLinux})
+
+	assert.Equal(t, []string{
+		"warning: long-retention: log groups should not be kept for more than a year (logs.logs_collected.files.collect_list[1].retention_in_days)",
+	}, util.CheckPolicyViolations(resultMap, policy))
+}
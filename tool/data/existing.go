@@ -0,0 +1,189 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package data
+
+import (
+	"encoding/json"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/metric"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+// FromMap sets the config and ctx from an existing config, such as a config.json read back, the way the
+// wizard would have set them to generate it. Only what the wizard asks about is read: the traces
+// section, the metrics_collected section of logs, the plugins the wizard does not know and the keys it
+// never sets are left out, and are put back by MergeExistingConfig.
+func (conf *Config) FromMap(ctx *runtime.Context, m map[string]interface{}) {
+	conf.AgentConfig = nil
+	if agent := util.ConfigMap(m, "agent"); agent != nil {
+		conf.AgentConf().FromMap(ctx, agent)
+	}
+	conf.MetricsConfig = nil
+	if metrics := util.ConfigMap(m, "metrics"); metrics != nil {
+		conf.MetricsConf().FromMap(ctx, metrics)
+	}
+	conf.LogsConfig = nil
+	if logs := util.ConfigMap(m, "logs"); logs != nil {
+		conf.LogsConf().FromMap(ctx, logs)
+	}
+}
+
+// MergeExistingConfig merges generated, the config an existing config became once read with FromMap
+// and edited, back into a copy of the existing config. generated decides which metric plugins,
+// dimensions, log files and windows events the config has, but the entries kept keep everything the
+// wizard does not model, e.g. the rename of a measurement or the auto_removal of a log file, and the
+// sections the wizard does not build are kept as they are.
+func MergeExistingConfig(ctx *runtime.Context, existing, generated map[string]interface{}) map[string]interface{} {
+	result := copyConfig(existing)
+	generated = copyConfig(generated)
+	if agent := util.ConfigMap(generated, "agent"); agent != nil {
+		if resultAgent := util.ConfigMap(result, "agent"); resultAgent != nil {
+			for key, value := range agent {
+				resultAgent[key] = value
+			}
+		} else {
+			result["agent"] = agent
+		}
+	}
+	mergeExistingMetrics(ctx, result, util.ConfigMap(generated, "metrics"))
+	mergeExistingLogs(result, util.ConfigMap(generated, "logs"))
+	return result
+}
+
+func mergeExistingMetrics(ctx *runtime.Context, result, generated map[string]interface{}) {
+	if generated == nil {
+		return
+	}
+	metrics := util.ConfigMap(result, "metrics")
+	if metrics == nil {
+		result["metrics"] = generated
+		return
+	}
+	for _, key := range []string{"append_dimensions", "aggregation_dimensions"} {
+		if value, ok := generated[key]; ok {
+			metrics[key] = value
+		} else {
+			delete(metrics, key)
+		}
+	}
+	generatedCollected := util.ConfigMap(generated, "metrics_collected")
+	collected := util.ConfigMap(metrics, "metrics_collected")
+	if collected == nil {
+		if generatedCollected != nil {
+			metrics["metrics_collected"] = generatedCollected
+		}
+		return
+	}
+	keys, _ := new(metric.Collection).PluginKeys(ctx)
+	for _, key := range keys {
+		entry := util.ConfigMap(generatedCollected, key)
+		existingEntry := util.ConfigMap(collected, key)
+		switch {
+		case entry == nil:
+			delete(collected, key)
+		case existingEntry == nil:
+			collected[key] = entry
+		case entry[util.MapKeyMeasurement] != nil:
+			mergeExistingMeasurement(ctx, key, existingEntry, entry)
+		}
+	}
+}
+
+// mergeExistingMeasurement sets the measurement list of the existing entry of a plugin to the
+// measurements selected in the generated one. The measurements still selected keep their entry, which
+// may be an object with a rename or a unit, and the ones the wizard does not model are kept.
+func mergeExistingMeasurement(ctx *runtime.Context, key string, existing, generated map[string]interface{}) {
+	collection := new(metric.Collection)
+	collection.FromMap(ctx, map[string]interface{}{key: generated})
+	plugin := collection.Plugin(ctx, key)
+	seen := make(map[string]bool)
+	list := []interface{}{}
+	existingList, _ := existing[util.MapKeyMeasurement].([]interface{})
+	for _, item := range existingList {
+		field := util.MatchMeasurementField(plugin, key, util.MeasurementName(item))
+		if field == "" {
+			list = append(list, item)
+		} else if util.MeasurementField(plugin, field) && !seen[field] {
+			seen[field] = true
+			list = append(list, item)
+		}
+	}
+	for _, field := range util.MeasurementFields(plugin) {
+		if util.MeasurementField(plugin, field) && !seen[field] {
+			list = append(list, field)
+		}
+	}
+	existing[util.MapKeyMeasurement] = list
+}
+
+func mergeExistingLogs(result, generated map[string]interface{}) {
+	if generated == nil {
+		return
+	}
+	logs := util.ConfigMap(result, "logs")
+	if logs == nil {
+		result["logs"] = generated
+		return
+	}
+	for _, key := range []string{"force_flush_interval", "log_stream_name"} {
+		if value, ok := generated[key]; ok {
+			logs[key] = value
+		}
+	}
+	generatedCollected := util.ConfigMap(generated, "logs_collected")
+	collected := util.ConfigMap(logs, "logs_collected")
+	if collected == nil {
+		if generatedCollected != nil {
+			logs["logs_collected"] = generatedCollected
+		}
+		return
+	}
+	mergeExistingCollectList(collected, generatedCollected, "files", "file_path")
+	mergeExistingCollectList(collected, generatedCollected, "windows_events", "event_name")
+}
+
+// mergeExistingCollectList sets the collect_list of a section of logs_collected to the entries of the
+// generated one, keeping the existing entry of every file or event still collected.
+func mergeExistingCollectList(collected, generated map[string]interface{}, section, key string) {
+	generatedSection := util.ConfigMap(generated, section)
+	existingSection := util.ConfigMap(collected, section)
+	switch {
+	case generatedSection == nil:
+		delete(collected, section)
+		return
+	case existingSection == nil:
+		collected[section] = generatedSection
+		return
+	}
+	existingList := util.ConfigMapList(existingSection, "collect_list")
+	used := make([]bool, len(existingList))
+	list := []interface{}{}
+	for _, entry := range util.ConfigMapList(generatedSection, "collect_list") {
+		var kept interface{} = entry
+		for i, existingEntry := range existingList {
+			if !used[i] && util.ConfigString(existingEntry, key) == util.ConfigString(entry, key) {
+				used[i] = true
+				kept = existingEntry
+				break
+			}
+		}
+		list = append(list, kept)
+	}
+	existingSection["collect_list"] = list
+}
+
+// copyConfig returns a deep copy of a config with the lists the wizard builds turned into plain json
+// lists.
+func copyConfig(m map[string]interface{}) map[string]interface{} {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return m
+	}
+	var result map[string]interface{}
+	if err = json.Unmarshal(content, &result); err != nil {
+		return m
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package data
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+const existingConfig = `{
+	"agent": {"metrics_collection_interval": 60, "run_as_user": "cwagent", "debug": true},
+	"metrics": {
+		"namespace": "App",
+		"append_dimensions": {"InstanceId": "${aws:InstanceId}"},
+		"aggregation_dimensions": [["InstanceId"], []],
+		"metrics_collected": {
+			"cpu": {"resources": ["*"], "totalcpu": false, "measurement": ["usage_idle", {"name": "cpu_usage_user", "rename": "User"}, "time_active"]},
+			"mem": {"metrics_collection_interval": 10, "measurement": ["mem_used_percent"]},
+			"procstat": [{"exe": "nginx", "measurement": ["cpu_usage"]}]
+		}
+	},
+	"logs": {
+		"logs_collected": {"files": {"collect_list": [
+			{"file_path": "/var/log/messages", "log_group_name": "messages", "auto_removal": true},
+			{"file_path": "/var/log/secure", "log_group_name": "secure"}
+		]}}
+	},
+	"traces": {"traces_collected": {"xray": {}}}
+}`
+
+func readExistingConfig(t *testing.T, content string) map[string]interface{} {
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(content), &m))
+	return m
+}
+
+func TestConfig_FromMap(t *testing.T) {
+	ctx := &runtime.Context{OsParameter: util.OsTypeLinux}
+	conf := new(Config)
+	conf.FromMap(ctx, readExistingConfig(t, existingConfig))
+
+	assert.Equal(t, 60, ctx.MetricsCollectionInterval)
+	assert.True(t, ctx.WantPerInstanceMetrics)
+	assert.True(t, ctx.WantEC2TagDimensions)
+	assert.True(t, ctx.WantAggregateDimensions)
+	assert.Equal(t, "cwagent", conf.AgentConfig.Runasuser)
+	collection := conf.MetricsConfig.MetricsCollect
+	assert.True(t, collection.CPU.UsageIdle)
+	assert.True(t, collection.CPU.UsageUser)
+	assert.False(t, collection.CPU.UsageSystem)
+	assert.True(t, collection.Memory.MemUsedPercent)
+	assert.Nil(t, collection.Disk)
+	assert.Equal(t, [][]string{{"InstanceId"}, {}}, conf.MetricsConfig.AggregationDimensions.Dimensions)
+	files := conf.LogsConfig.LogsCollect.Files.FileConfigs
+	require.Len(t, files, 2)
+	assert.Equal(t, "/var/log/secure", files[1].FilePath)
+	assert.Equal(t, "secure", files[1].LogGroup)
+}
+
+func TestMergeExistingConfig(t *testing.T) {
+	existing := readExistingConfig(t, existingConfig)
+	ctx := &runtime.Context{OsParameter: util.OsTypeLinux}
+	conf := new(Config)
+	conf.FromMap(ctx, existing)
+	_, generated := conf.ToMap(ctx)
+	assert.Equal(t, readExistingConfig(t, existingConfig), MergeExistingConfig(ctx, existing, generated), "a config left as it is must not change")
+
+	collection := conf.MetricsConfig.MetricsCollect
+	collection.CPU.UsageIdle = false
+	collection.CPU.UsageSystem = true
+	collection.DisablePlugin(ctx, "mem")
+	collection.EnablePlugin(ctx, "swap")
+	conf.MetricsConfig.AppendDimensions = nil
+	ctx.WantEC2TagDimensions = false
+	conf.LogsConfig.LogsCollect.Files.FileConfigs = conf.LogsConfig.LogsCollect.Files.FileConfigs[:1]
+	conf.LogsConfig.AddLogFile("/var/log/app.log", "app", "", "", "", "", "", 0, "", nil)
+	_, generated = conf.ToMap(ctx)
+
+	assert.Equal(t, readExistingConfig(t, `{
+		"agent": {"metrics_collection_interval": 60, "run_as_user": "cwagent", "debug": true},
+		"metrics": {
+			"namespace": "App",
+			"aggregation_dimensions": [["InstanceId"], []],
+			"metrics_collected": {
+				"cpu": {"resources": ["*"], "totalcpu": false, "measurement": [{"name": "cpu_usage_user", "rename": "User"}, "time_active", "cpu_usage_system"]},
+				"swap": {"metrics_collection_interval": 60, "measurement": ["swap_used_percent"]},
+				"procstat": [{"exe": "nginx", "measurement": ["cpu_usage"]}]
+			}
+		},
+		"logs": {
+			"logs_collected": {"files": {"collect_list": [
+				{"file_path": "/var/log/messages", "log_group_name": "messages", "auto_removal": true},
+				{"file_path": "/var/log/app.log", "log_group_name": "app"}
+			]}}
+		},
+		"traces": {"traces_collected": {"xray": {}}}
+	}`), MergeExistingConfig(ctx, existing, generated))
+	assert.Equal(t, readExistingConfig(t, existingConfig), existing, "the existing config must not change")
+}
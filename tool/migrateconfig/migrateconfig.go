@@ -0,0 +1,139 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package migrateconfig upgrades the config of an older agent, a json config, the awslogs.conf of the
+// CloudWatch Logs agent or the AWS.EC2.Windows.CloudWatch.json of the SSM agent, to a json config of the
+// current agent.
+package migrateconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/migration/linux"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/migration/windows"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+const (
+	// awslogsExtension is the extension of the configs of the CloudWatch Logs agent.
+	awslogsExtension = ".conf"
+	// ssmEngineConfigurationKey is the top level key of the CloudWatch configs of the SSM agent,
+	// AWS.EC2.Windows.CloudWatch.json.
+	ssmEngineConfigurationKey = "EngineConfiguration"
+)
+
+// Result is the outcome of a migration.
+type Result struct {
+	// Original is the config migrated, indented the way the wizard writes it, or nothing for an
+	// awslogs.conf or a config of the SSM agent, which have no json config of the agent to compare with.
+	Original []byte
+	// Migrated is the json config of the current agent.
+	Migrated []byte
+	// Changes describes every change made, in order.
+	Changes []string
+}
+
+// Changed reports whether the migration changed the config, i.e. whether it needs to be written.
+func (r *Result) Changed() bool {
+	return !bytes.Equal(r.Original, r.Migrated)
+}
+
+// Diff returns the unified diff from the original config at fromName to the migrated one at toName.
+func (r *Result) Diff(fromName, toName string) (string, error) {
+	return util.UnifiedDiff(fromName, toName, r.Original, r.Migrated)
+}
+
+// Migrate upgrades the config at filePath with util.UpgradeConfig. A file with the .conf extension, or
+// whose content is not a json object, is read as an awslogs.conf and its log files are converted first,
+// and a json config with an EngineConfiguration is converted from the config of the SSM agent first. The
+// errors are *util.WizardError located at filePath.
+func Migrate(filePath string) (*Result, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, &util.WizardError{Code: util.ErrCodeConfigRead, Path: filePath, Err: fmt.Errorf("unable to read the config: %w", err)}
+	}
+	result := &Result{}
+	var m map[string]interface{}
+	if isAwslogsConfig(filePath, content) {
+		if m, err = readAwslogsConfig(filePath); err != nil {
+			return nil, &util.WizardError{Code: util.ErrCodeConfigParse, Path: filePath, Err: err}
+		}
+		result.Changes = append(result.Changes, fmt.Sprintf("converted the awslogs config %s to the logs section", filePath))
+	} else {
+		if err = json.Unmarshal(content, &m); err != nil {
+			return nil, &util.WizardError{Code: util.ErrCodeConfigParse, Path: filePath, Err: fmt.Errorf("the config is not valid json: %w", err)}
+		}
+		if _, ok := m[ssmEngineConfigurationKey]; ok {
+			if m, err = convertSSMConfig(content); err != nil {
+				return nil, &util.WizardError{Code: util.ErrCodeConfigParse, Path: filePath, Err: err}
+			}
+			result.Changes = append(result.Changes, fmt.Sprintf("converted the SSM agent config %s to the agent config", filePath))
+		} else if result.Original, err = util.SerializeResultMapToJsonByteArrayE(m); err != nil {
+			return nil, err
+		}
+	}
+
+	upgraded, changes, err := util.UpgradeConfig(m)
+	if err != nil {
+		return nil, &util.WizardError{Code: util.ErrCodeConfigInvalid, Path: filePath, Err: err}
+	}
+	result.Changes = append(result.Changes, changes...)
+	if result.Migrated, err = util.SerializeResultMapToJsonByteArrayE(upgraded); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func isAwslogsConfig(filePath string, content []byte) bool {
+	return filepath.Ext(filePath) == awslogsExtension || !bytes.HasPrefix(bytes.TrimSpace(content), []byte("{"))
+}
+
+// readAwslogsConfig converts the awslogs.conf at filePath the way the migration of the wizard does. The
+// migration panics on the settings it cannot convert, e.g. an unsupported encoding, which is returned.
+func readAwslogsConfig(filePath string) (map[string]interface{}, error) {
+	logsConfig := new(config.Logs)
+	if err := readAwslogsSections(filePath, logsConfig); err != nil {
+		return nil, err
+	}
+	key, value := logsConfig.ToMap(&runtime.Context{OsParameter: util.OsTypeLinux})
+	return map[string]interface{}{key: value}, nil
+}
+
+// readAwslogsSections reads the awslogs.conf at filePath into logsConfig and recovers the panics of the
+// migration.
+func readAwslogsSections(filePath string, logsConfig *config.Logs) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return linux.ReadAwslogsConfig(filePath, logsConfig)
+}
+
+// convertSSMConfig converts the CloudWatch config of the SSM agent in content the way the Windows migration
+// of the wizard does.
+func convertSSMConfig(content []byte) (map[string]interface{}, error) {
+	var oldConfig windows.OldSsmCwConfig
+	if err := json.Unmarshal(content, &oldConfig); err != nil {
+		return nil, fmt.Errorf("the SSM agent config cannot be read: %w", err)
+	}
+	converted, err := windows.MapOldWindowsConfigToNewConfig(oldConfig)
+	if err != nil {
+		return nil, err
+	}
+	newConfig, err := json.Marshal(converted)
+	if err != nil {
+		return nil, fmt.Errorf("the SSM agent config cannot be converted: %w", err)
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(newConfig, &m); err != nil {
+		return nil, fmt.Errorf("the SSM agent config cannot be converted: %w", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package migrateconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	filePath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+	return filePath
+}
+
+func TestMigrateJSON(t *testing.T) {
+	filePath := writeConfig(t, "config.json", `{"agent": {"run_as_user": "cwagent", "debug": "false"}, "csm": {"port": 31000}}`)
+
+	result, err := Migrate(filePath)
+	require.NoError(t, err)
+	assert.True(t, result.Changed())
+	assert.Equal(t, []string{
+		"converted 1 boolean strings to json booleans",
+		"removed the csm section, which the agent no longer supports",
+	}, result.Changes)
+	assert.Equal(t, "{\n\t\"agent\": {\n\t\t\"debug\": false,\n\t\t\"run_as_user\": \"cwagent\"\n\t}\n}", string(result.Migrated))
+
+	diff, err := result.Diff("config.json", "config.json (migrated)")
+	require.NoError(t, err)
+	assert.Equal(t, "--- config.json\n+++ config.json (migrated)\n@@ -1,9 +1,6 @@\n {\n \t\"agent\": {\n"+
+		"-\t\t\"debug\": \"false\",\n+\t\t\"debug\": false,\n \t\t\"run_as_user\": \"cwagent\"\n-\t},\n"+
+		"-\t\"csm\": {\n-\t\t\"port\": 31000\n \t}\n }\n", diff)
+}
+
+func TestMigrateUpToDate(t *testing.T) {
+	testCases := map[string]string{
+		"WithAgent":    `{"agent": {"metrics_collection_interval": 60}, "metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}}`,
+		"WithoutAgent": `{"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}}`,
+	}
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := Migrate(writeConfig(t, "config.json", content))
+			require.NoError(t, err)
+			assert.False(t, result.Changed())
+			assert.Empty(t, result.Changes)
+			diff, err := result.Diff("config.json", "config.json (migrated)")
+			require.NoError(t, err)
+			assert.Empty(t, diff)
+		})
+	}
+}
+
+func TestMigrateAwslogsConfig(t *testing.T) {
+	filePath := writeConfig(t, "awslogs.conf", "[general]\nstate_file = /var/lib/awslogs/agent-state\n\n"+
+		"[/var/log/messages]\nfile = /var/log/messages\nlog_group_name = /var/log/messages\nlog_stream_name = {instance_id}\n")
+
+	result, err := Migrate(filePath)
+	require.NoError(t, err)
+	assert.True(t, result.Changed())
+	assert.Empty(t, result.Original)
+	assert.Equal(t, []string{"converted the awslogs config " + filePath + " to the logs section"}, result.Changes)
+	assert.Contains(t, string(result.Migrated), "\"file_path\": \"/var/log/messages\"")
+	assert.Contains(t, string(result.Migrated), "\"log_stream_name\": \"{instance_id}\"")
+}
+
+func TestMigrateSSMConfig(t *testing.T) {
+	filePath := filepath.Join("testdata", "AWS.EC2.Windows.CloudWatch.json")
+
+	result, err := Migrate(filePath)
+	require.NoError(t, err)
+	assert.True(t, result.Changed())
+	assert.Empty(t, result.Original)
+	assert.Equal(t, []string{"converted the SSM agent config " + filePath + " to the agent config"}, result.Changes)
+	assert.NotContains(t, string(result.Migrated), "EngineConfiguration")
+	assert.Contains(t, string(result.Migrated), "\"region\": \"us-west-2\"")
+	assert.Contains(t, string(result.Migrated), "\"event_name\": \"Application\"")
+	assert.Contains(t, string(result.Migrated), "\"log_group_name\": \"Application\"")
+
+	result, err = Migrate(writeConfig(t, "config.json", string(result.Migrated)))
+	require.NoError(t, err)
+	assert.False(t, result.Changed(), "the converted config is up to date")
+}
+
+func TestMigrateErrors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "config.json")
+	_, err := Migrate(missing)
+	assert.Equal(t, util.ErrCodeConfigRead, util.ErrorCode(err))
+	assert.Equal(t, missing, util.ErrorPath(err))
+
+	_, err = Migrate(writeConfig(t, "config.json", `{"agent": `))
+	assert.Equal(t, util.ErrCodeConfigParse, util.ErrorCode(err))
+	assert.ErrorContains(t, err, "the config is not valid json")
+}
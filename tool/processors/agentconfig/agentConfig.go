@@ -4,6 +4,8 @@
 package agentconfig
 
 import (
+	"fmt"
+
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/statsd"
@@ -21,8 +23,11 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
 	whichRunAsUser(ctx, config)
+	omitHostname(config)
+	whichProfile(ctx, config)
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
@@ -43,3 +48,29 @@ func whichRunAsUser(ctx *runtime.Context, config *data.Config) {
 	}
 	config.AgentConf().Runasuser = answer
 }
+
+func omitHostname(config *data.Config) {
+	omit, err := util.AskOmitHostname()
+	if err != nil {
+		return
+	}
+	config.AgentConf().OmitHostname = omit
+}
+
+// whichProfile asks on premises for the shared profile the agent sends with and the credentials file it
+// reads the profile from. The serialization sets them in common-config.toml once the config is saved, so
+// the keys of the profile are not copied into the json config.
+func whichProfile(ctx *runtime.Context, config *data.Config) {
+	if !ctx.IsOnPrem {
+		return
+	}
+	profile, roleARN, err := util.AskAgentProfile()
+	if err != nil || profile == "" {
+		return
+	}
+	credentialsFile, _ := util.SharedConfigFiles()
+	util.PrintHint("The agent reads the credentials file as the user it runs as, which may not have access to the credentials file of the user running the wizard.")
+	ctx.AgentProfile = profile
+	ctx.AgentCredentialsFile = util.AskWithDefault(fmt.Sprintf("Which credentials file should the agent read the profile %s from?", profile), credentialsFile)
+	config.AgentConf().RoleARN = roleARN
+}
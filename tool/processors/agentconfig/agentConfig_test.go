@@ -19,19 +19,24 @@ func TestProcessor_Process(t *testing.T) {
 	ctx := new(runtime.Context)
 	conf := new(data.Config)
 
-	testutil.Type(inputChan, "")
+	testutil.Type(inputChan, "", "")
 	Processor.Process(ctx, conf)
 	assert.Equal(t, RUNASUSER_CWAGENT, conf.AgentConfig.Runasuser)
 
-	testutil.Type(inputChan, "1")
+	testutil.Type(inputChan, "1", "")
 	Processor.Process(ctx, conf)
 	assert.Equal(t, RUNASUSER_CWAGENT, conf.AgentConfig.Runasuser)
 
-	testutil.Type(inputChan, "2")
+	testutil.Type(inputChan, "2", "")
 	Processor.Process(ctx, conf)
 	assert.Equal(t, RUNASUSER_ROOT, conf.AgentConfig.Runasuser)
 
-	testutil.Type(inputChan, "3", "testuser")
+	testutil.Type(inputChan, "3", "testuser", "")
 	Processor.Process(ctx, conf)
 	assert.Equal(t, "testuser", conf.AgentConfig.Runasuser)
+	assert.False(t, conf.AgentConfig.OmitHostname)
+
+	testutil.Type(inputChan, "", "1")
+	Processor.Process(ctx, conf)
+	assert.True(t, conf.AgentConfig.OmitHostname)
 }
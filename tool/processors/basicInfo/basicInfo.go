@@ -9,37 +9,49 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/agentconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/containerinsights"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/edit"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
+const containersOption = "Containers (ECS or Kubernetes)"
+
+// detectContainerEnv is replaced in tests.
+var detectContainerEnv = util.DetectContainerEnv
+
 var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
-	ensurePermission()
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
+	if err := util.PermissionCheckE(); err != nil {
+		return err
+	}
 	welcome()
 	whichOS(ctx)
 	isEC2(ctx, config)
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
+	if ctx != nil && (ctx.EditConfigPath != "" || ctx.SSMParameterName != "") {
+		return edit.Processor
+	}
+	if ctx != nil && ctx.ContainerEnv != "" {
+		return containerinsights.Processor
+	}
 	return agentconfig.Processor
 }
 
-func ensurePermission() {
-	util.PermissionCheck()
-}
-
 func welcome() {
-	fmt.Println("================================================================")
-	fmt.Println("= Welcome to the Amazon CloudWatch Agent Configuration Manager =")
-	fmt.Println("=                                                              =")
-	fmt.Println("= CloudWatch Agent allows you to collect metrics and logs from =")
-	fmt.Println("= your host and send them to CloudWatch. Additional CloudWatch =")
-	fmt.Println("= charges may apply.                                           =")
-	fmt.Println("================================================================")
+	util.PrintHint("================================================================")
+	util.PrintHint("= Welcome to the Amazon CloudWatch Agent Configuration Manager =")
+	util.PrintHint("=                                                              =")
+	util.PrintHint("= CloudWatch Agent allows you to collect metrics and logs from =")
+	util.PrintHint("= your host and send them to CloudWatch. Additional CloudWatch =")
+	util.PrintHint("= charges may apply.                                           =")
+	util.PrintHint("================================================================")
 }
 
 func whichOS(ctx *runtime.Context) {
@@ -54,18 +66,31 @@ func whichOS(ctx *runtime.Context) {
 	ctx.OsParameter = answer
 }
 
+// isEC2 asks where the agent runs: on EC2 instances, on premises, or in the containers of an ECS or
+// Kubernetes cluster, which is the default when the wizard runs in one.
 func isEC2(ctx *runtime.Context, conf *data.Config) {
 	defaultOption := 1
-	defaultRegion := util.DefaultEC2Region()
-	if defaultRegion == "" {
+	containerEnv := detectContainerEnv()
+	if containerEnv != "" {
+		defaultOption = 3
+	} else if _, err := util.DefaultEC2Region(); err != nil {
+		fmt.Printf("W! %v\n", err)
 		defaultOption = 2
 	}
-	answer := util.Choice("Are you using EC2 or On-Premises hosts?",
+	answer := util.Choice("Where will the agent run: on EC2 instances, on-premises hosts, or containers?",
 		defaultOption,
-		[]string{"EC2", "On-Premises"})
+		[]string{"EC2", "On-Premises", containersOption})
 	ctx.IsOnPrem = answer == "On-Premises"
+	ctx.ContainerEnv = ""
+	if answer == containersOption {
+		// the orchestrator is asked next, defaulting to the detected one
+		ctx.ContainerEnv = containerEnv
+		if ctx.ContainerEnv == "" {
+			ctx.ContainerEnv = util.ContainerEnvECS
+		}
+	}
 	if ctx.IsOnPrem {
-		fmt.Println("Please make sure the credentials and region set correctly on your hosts.\n" +
+		util.PrintHint("Please make sure the credentials and region set correctly on your hosts.\n" +
 			"Refer to http://docs.aws.amazon.com/cli/latest/userguide/cli-chap-getting-started.html")
 	}
 }
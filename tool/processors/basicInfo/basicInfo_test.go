@@ -10,6 +10,8 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/agentconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/containerinsights"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/edit"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
@@ -40,6 +42,9 @@ func TestProcessor_Process(t *testing.T) {
 
 func TestProcessor_NextProcessor(t *testing.T) {
 	assert.Equal(t, agentconfig.Processor, Processor.NextProcessor(nil, nil))
+	assert.Equal(t, edit.Processor, Processor.NextProcessor(&runtime.Context{EditConfigPath: "config.json"}, nil))
+	assert.Equal(t, edit.Processor, Processor.NextProcessor(&runtime.Context{SSMParameterName: "AmazonCloudWatch-linux"}, nil))
+	assert.Equal(t, containerinsights.Processor, Processor.NextProcessor(&runtime.Context{ContainerEnv: util.ContainerEnvEKS}, nil))
 }
 
 func TestWhichOS(t *testing.T) {
@@ -76,4 +81,21 @@ func TestIsEC2(t *testing.T) {
 	isEC2(ctx, conf)
 	assert.Equal(t, true, ctx.IsOnPrem)
 
+	//containers
+	testutil.Type(inputChan, "3")
+	isEC2(ctx, conf)
+	assert.Equal(t, false, ctx.IsOnPrem)
+	assert.Equal(t, util.ContainerEnvECS, ctx.ContainerEnv)
+
+	original := detectContainerEnv
+	defer func() { detectContainerEnv = original }()
+	detectContainerEnv = func() string { return util.ContainerEnvEKS }
+	testutil.Type(inputChan, "")
+	isEC2(ctx, conf)
+	assert.Equal(t, util.ContainerEnvEKS, ctx.ContainerEnv)
+
+	testutil.Type(inputChan, "1")
+	isEC2(ctx, conf)
+	assert.Empty(t, ctx.ContainerEnv)
+
 }
@@ -17,9 +17,9 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
 	if ctx.OsParameter == util.OsTypeWindows {
-		return
+		return nil
 	}
 	yes := util.Yes("Do you want to monitor metrics from CollectD? WARNING: CollectD must be installed or the Agent will fail to start")
 	if yes {
@@ -30,7 +30,15 @@ func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
 		} else {
 			collection.CollectD.MetricsAggregationInterval = 60
 		}
+		if typesDB, err := util.AskCollectdTypesDB(util.DefaultCollectdTypesDB(ctx.OsParameter)); err == nil {
+			collection.CollectD.TypesDB = []string{typesDB}
+		}
+		if security, err := util.AskCollectdSecurity(); err == nil {
+			collection.CollectD.SecurityLevel, _ = security["collectd_security_level"].(string)
+			collection.CollectD.AuthFile, _ = security["collectd_auth_file"].(string)
+		}
 	}
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
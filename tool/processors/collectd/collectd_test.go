@@ -4,9 +4,12 @@
 package collectd
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/defaultConfig"
@@ -26,11 +29,24 @@ func TestProcessor_Process(t *testing.T) {
 	Processor.Process(ctx, conf)
 	assert.Nil(t, conf.MetricsConfig)
 
-	testutil.Type(inputChan, "", "", "", "")
+	typesDB := filepath.Join(t.TempDir(), "types.db")
+	require.NoError(t, os.WriteFile(typesDB, []byte("load\t\tvalue:GAUGE:0:100\n"), 0644))
+	testutil.Type(inputChan, "", typesDB, "")
 	Processor.Process(ctx, conf)
 	collectdConf := conf.MetricsConf().Collection().CollectD
 	assert.NotNil(t, collectdConf)
 	assert.Equal(t, 60, collectdConf.MetricsAggregationInterval)
+	assert.Equal(t, []string{typesDB}, collectdConf.TypesDB)
+	assert.Equal(t, util.CollectdSecurityNone, collectdConf.SecurityLevel)
+	assert.Empty(t, collectdConf.AuthFile)
+
+	authFile := filepath.Join(t.TempDir(), "auth_file")
+	require.NoError(t, os.WriteFile(authFile, []byte("user: secret\n"), 0600))
+	testutil.Type(inputChan, "", typesDB, "2", authFile)
+	Processor.Process(ctx, conf)
+	collectdConf = conf.MetricsConf().Collection().CollectD
+	assert.Equal(t, util.CollectdSecuritySign, collectdConf.SecurityLevel)
+	assert.Equal(t, authFile, collectdConf.AuthFile)
 }
 
 func TestProcessor_NextProcessor(t *testing.T) {
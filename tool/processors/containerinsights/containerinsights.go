@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package containerinsights
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/logs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+var Processor processors.Processor = &processor{}
+
+type processor struct{}
+
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
+	whichOrchestrator(ctx)
+	monitorContainers(ctx, config)
+	return nil
+}
+
+func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
+	return logs.Processor
+}
+
+func whichOrchestrator(ctx *runtime.Context) {
+	defaultOption := 1
+	if ctx.ContainerEnv == util.ContainerEnvEKS {
+		defaultOption = 2
+	}
+	ctx.ContainerEnv = util.Choice("Which container orchestrator runs the agent?", defaultOption, []string{util.ContainerEnvECS, util.ContainerEnvEKS})
+}
+
+// monitorContainers asks about the cluster the agent runs in and adds the ecs or kubernetes section of
+// logs.metrics_collected, telling the log groups Container Insights writes to.
+func monitorContainers(ctx *runtime.Context, config *data.Config) {
+	clusterName, err := util.AskContainerClusterName(ctx.ContainerEnv)
+	if err != nil {
+		return
+	}
+	ctx.ContainerClusterName = clusterName
+	enhanced := false
+	if ctx.ContainerEnv == util.ContainerEnvEKS {
+		util.PrintHint("Enhanced observability adds metrics down to the container level, at additional charges.")
+		enhanced = util.Yes("Do you want to turn on enhanced observability for Container Insights?")
+	} else {
+		util.PrintHint("The enhanced observability of ECS is turned on with the containerInsights setting of the cluster.")
+	}
+	answer := util.Choice("How often do you want to collect the container metrics?", 1, util.ContainerMetricsIntervals)
+	interval, err := util.ParseDuration(answer)
+	if err != nil {
+		return
+	}
+	config.LogsConf().AddContainerInsights(ctx.ContainerEnv, clusterName, enhanced, interval)
+	fmt.Printf("Container Insights publishes the metrics of the cluster %s to the log group %s.\n",
+		clusterName, util.ContainerInsightsLogGroup(clusterName, "performance"))
+	util.PrintHint("The logs of the containers go to %s by default.", util.ContainerInsightsLogGroup(clusterName, "application"))
+}
@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package containerinsights
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/logs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+	"github.com/aws/amazon-cloudwatch-agent/tool/validateconfig"
+)
+
+func TestProcessor_Process(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	ctx := &runtime.Context{OsParameter: util.OsTypeLinux, ContainerEnv: util.ContainerEnvEKS}
+	conf := new(data.Config)
+	testutil.Type(inputChan, "", "eks-prod", "", "2")
+	Processor.Process(ctx, conf)
+	assert.Equal(t, "eks-prod", ctx.ContainerClusterName)
+	_, confMap := conf.ToMap(ctx)
+	assert.Equal(t,
+		map[string]interface{}{
+			"logs": map[string]interface{}{
+				"metrics_collected": map[string]interface{}{
+					"kubernetes": map[string]interface{}{
+						"cluster_name":                "eks-prod",
+						"enhanced_container_insights": true,
+						"metrics_collection_interval": 30,
+					},
+				},
+			},
+		},
+		confMap)
+
+	// the agent translates the generated section
+	content, err := json.Marshal(confMap)
+	require.NoError(t, err)
+	report := validateconfig.ValidateContent(content, validateconfig.Options{OS: util.OsTypeLinux, Mode: "ec2", Offline: true})
+	assert.Empty(t, report.Errors())
+
+	ctx = &runtime.Context{OsParameter: util.OsTypeLinux, ContainerEnv: util.ContainerEnvECS}
+	conf = new(data.Config)
+	testutil.Type(inputChan, "", "ecs-prod", "")
+	Processor.Process(ctx, conf)
+	_, confMap = conf.ToMap(ctx)
+	assert.Equal(t,
+		map[string]interface{}{
+			"logs": map[string]interface{}{
+				"metrics_collected": map[string]interface{}{
+					"ecs": map[string]interface{}{"metrics_collection_interval": 60},
+				},
+			},
+		},
+		confMap)
+}
+
+func TestProcessor_NextProcessor(t *testing.T) {
+	assert.Equal(t, logs.Processor, Processor.NextProcessor(new(runtime.Context), new(data.Config)))
+}
@@ -17,7 +17,7 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, conf *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, conf *data.Config) error {
 	metricsConfig := conf.MetricsConf()
 	metricsCollection := metricsConfig.Collection()
 	if ctx.IsOnPrem {
@@ -25,6 +25,7 @@ func (p *processor) Process(ctx *runtime.Context, conf *data.Config) {
 	} else {
 		ConfigureEC2Metrics(metricsCollection, ctx)
 	}
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"strconv"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
@@ -25,16 +24,19 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
+	ctx.WantHostMetrics = wantMonitorAnyHostMetrics()
+	if !ctx.WantHostMetrics {
+		return nil
+	}
+	wantPerInstanceMetrics(ctx)
+	wantEC2TagDimensions(ctx)
+	wantEC2AggregateDimensions(ctx)
+	return metricsCollectInterval(ctx)
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
-	if wantMonitorAnyHostMetrics() {
-		wantPerInstanceMetrics(ctx)
-		wantEC2TagDimensions(ctx)
-		wantEC2AggregateDimensions(ctx)
-		metricsCollectInterval(ctx)
-	} else {
+	if !ctx.WantHostMetrics {
 		if ctx.OsParameter == util.OsTypeWindows {
 			return logs.Processor
 		} else {
@@ -62,6 +64,9 @@ func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) int
 			advancedPlan.Processor.Process(ctx, config)
 		case "None":
 			return question.Processor
+		case "":
+			// the answers have none for the question, which stops the wizard
+			return nil
 		default:
 			log.Panicf("Unknown default config: %s", whichDefaultConfig)
 		}
@@ -114,11 +119,12 @@ func wantEC2AggregateDimensions(ctx *runtime.Context) {
 	ctx.WantAggregateDimensions = util.Yes("Do you want to aggregate ec2 dimensions (InstanceId)?")
 }
 
-func metricsCollectInterval(ctx *runtime.Context) {
-	answer := util.Choice("Would you like to collect your metrics at high resolution (sub-minute resolution)? This enables sub-minute resolution for all metrics, but you can customize for specific metrics in the output json file.", 4, []string{"1s", "10s", "30s", "60s"})
-	if val, err := strconv.Atoi(answer[:len(answer)-1]); err == nil {
-		ctx.MetricsCollectionInterval = val
-	} else {
-		log.Panicf("Failed to parse the collect time interval. Error details: %v", err)
+func metricsCollectInterval(ctx *runtime.Context) error {
+	interval, err := util.AskMetricsCollectionInterval()
+	if err != nil {
+		return err
 	}
+	ctx.MetricsCollectionInterval = interval
+	util.SetMetricsCollectionInterval(interval)
+	return nil
 }
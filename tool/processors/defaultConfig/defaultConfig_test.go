@@ -16,13 +16,28 @@ import (
 )
 
 func TestProcessor_Process(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
 
 	ctx := new(runtime.Context)
 	conf := new(data.Config)
 
-	Processor.Process(ctx, conf)
+	// wantMonitorAnyHostMetrics?
+	testutil.Type(inputChan, "2")
+	assert.NoError(t, Processor.Process(ctx, conf))
 	assert.Equal(t, new(runtime.Context), ctx)
 	assert.Equal(t, new(data.Config), conf)
+
+	// wantMonitorAnyHostMetrics?
+	// wantPerInstanceMetrics?
+	// wantEC2TagDimensions?
+	// wantEC2AggregateDimensions?
+	// metricsCollectInterval?
+	testutil.Type(inputChan, "", "2", "", "", "45", "1m30s", "2m")
+	assert.NoError(t, Processor.Process(ctx, conf))
+	assert.True(t, ctx.WantHostMetrics)
+	assert.False(t, ctx.WantPerInstanceMetrics)
+	assert.Equal(t, 120, ctx.MetricsCollectionInterval)
+	assert.Equal(t, new(data.Config), conf)
 }
 
 // basic metrics config
@@ -67,9 +82,8 @@ func TestProcessor_NextProcessor(t *testing.T) {
 
 	// wantMonitorAnyHostMetrics?
 	testutil.Type(inputChan, "2")
-	nextProcessor := Processor.NextProcessor(ctx, conf)
+	nextProcessor := processAndNext(t, ctx, conf)
 	assert.Equal(t, linux.Processor, nextProcessor)
-	assert.Equal(t, new(runtime.Context), ctx)
 	assert.Equal(t, new(data.Config), conf)
 
 	// wantMonitorAnyHostMetrics?
@@ -79,7 +93,7 @@ func TestProcessor_NextProcessor(t *testing.T) {
 	// metricsCollectInterval?
 	// whichDefaultConfig?
 	testutil.Type(inputChan, "", "1", "", "", "", "4")
-	nextProcessor = Processor.NextProcessor(ctx, conf)
+	nextProcessor = processAndNext(t, ctx, conf)
 	assert.Equal(t, question.Processor, nextProcessor)
 	assert.Equal(t, true, ctx.WantPerInstanceMetrics)
 	assert.Equal(t, true, ctx.WantEC2TagDimensions)
@@ -90,7 +104,7 @@ func TestProcessor_NextProcessor(t *testing.T) {
 	ctx = new(runtime.Context)
 	conf = new(data.Config)
 	testutil.Type(inputChan, "", "1", "", "", "", "", "")
-	nextProcessor = Processor.NextProcessor(ctx, conf)
+	nextProcessor = processAndNext(t, ctx, conf)
 	assert.Equal(t, linux.Processor, nextProcessor)
 
 	_, confMap := conf.ToMap(ctx)
@@ -100,7 +114,7 @@ func TestProcessor_NextProcessor(t *testing.T) {
 	ctx = new(runtime.Context)
 	conf = new(data.Config)
 	testutil.Type(inputChan, "", "1", "", "", "", "2", "")
-	nextProcessor = Processor.NextProcessor(ctx, conf)
+	nextProcessor = processAndNext(t, ctx, conf)
 	assert.Equal(t, linux.Processor, nextProcessor)
 
 	_, confMap = conf.ToMap(ctx)
@@ -110,7 +124,7 @@ func TestProcessor_NextProcessor(t *testing.T) {
 	ctx = new(runtime.Context)
 	conf = new(data.Config)
 	testutil.Type(inputChan, "", "1", "", "", "", "3", "")
-	nextProcessor = Processor.NextProcessor(ctx, conf)
+	nextProcessor = processAndNext(t, ctx, conf)
 	assert.Equal(t, linux.Processor, nextProcessor)
 
 	_, confMap = conf.ToMap(ctx)
@@ -120,9 +134,14 @@ func TestProcessor_NextProcessor(t *testing.T) {
 	ctx = new(runtime.Context)
 	conf = new(data.Config)
 	testutil.Type(inputChan, "", "1", "", "", "", "3", "2", "", "")
-	nextProcessor = Processor.NextProcessor(ctx, conf)
+	nextProcessor = processAndNext(t, ctx, conf)
 	assert.Equal(t, linux.Processor, nextProcessor)
 
 	_, confMap = conf.ToMap(ctx)
 	assert.Equal(t, basicMetricsConf, confMap)
 }
+
+func processAndNext(t *testing.T, ctx *runtime.Context, conf *data.Config) interface{} {
+	assert.NoError(t, Processor.Process(ctx, conf))
+	return Processor.NextProcessor(ctx, conf)
+}
@@ -0,0 +1,288 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package edit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/metric"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/logs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/serialization"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/ssm"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+const (
+	editQuestion = "What do you want to change in the config?"
+
+	addLogFile         = "Add a log file"
+	removeLogFile      = "Remove a log file"
+	addMetricPlugin    = "Add a metric plugin"
+	removeMetricPlugin = "Remove a metric plugin"
+	changeMeasurements = "Change the measurements of a metric plugin"
+	addDimension       = "Add a dimension"
+	removeDimension    = "Remove a dimension"
+	done               = "Done, save the config"
+
+	none = "None"
+)
+
+var editOptions = []string{addLogFile, removeLogFile, addMetricPlugin, removeMetricPlugin, changeMeasurements, addDimension, removeDimension, done}
+
+// ec2Dimensions are the dimensions the agent can append to the metrics, with the value it resolves.
+var ec2Dimensions = map[string]string{
+	"AutoScalingGroupName": "${aws:AutoScalingGroupName}",
+	"ImageId":              "${aws:ImageId}",
+	"InstanceId":           "${aws:InstanceId}",
+	"InstanceType":         "${aws:InstanceType}",
+}
+
+var Processor processors.Processor = &processor{}
+
+type processor struct{}
+
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
+	existing, err := readExistingConfig(ctx)
+	if err != nil {
+		return &util.WizardError{Code: util.ErrCodeConfigRead, Path: ctx.EditConfigPath, Err: fmt.Errorf("unable to read the config to edit: %w", err)}
+	}
+	util.NormalizeBooleans(existing)
+	ctx.ExistingConfig = existing
+	if ctx.ConfigOutputPath == "" && ctx.EditConfigPath != "" {
+		ctx.ConfigOutputPath = ctx.EditConfigPath
+	}
+	config.FromMap(ctx, existing)
+	editConfig(ctx, config)
+	return nil
+}
+
+func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
+	return serialization.Processor
+}
+
+// readExistingConfig reads the config at ctx.EditConfigPath, or fetches the one in the parameter store
+// when ctx.SSMParameterName is set.
+func readExistingConfig(ctx *runtime.Context) (map[string]interface{}, error) {
+	if ctx.SSMParameterName != "" {
+		return ssm.FetchConfig(ctx)
+	}
+	return util.ReadConfigMap(ctx.EditConfigPath)
+}
+
+func editConfig(ctx *runtime.Context, config *data.Config) {
+	for {
+		printSummary(ctx, config)
+		switch util.Choice(editQuestion, len(editOptions), editOptions) {
+		case addLogFile:
+			logs.AddLogFile(ctx, config)
+		case removeLogFile:
+			removeLogFiles(config)
+		case addMetricPlugin:
+			addMetricPlugins(ctx, config)
+		case removeMetricPlugin:
+			removeMetricPlugins(ctx, config)
+		case changeMeasurements:
+			changePluginMeasurements(ctx, config)
+		case addDimension:
+			addDimensions(ctx, config)
+		case removeDimension:
+			removeDimensions(ctx, config)
+		default:
+			return
+		}
+	}
+}
+
+func printSummary(ctx *runtime.Context, config *data.Config) {
+	enabled := metricPlugins(ctx, config, true)
+	if len(enabled) == 0 {
+		enabled = []string{none}
+	}
+	files := logFilePaths(config)
+	if len(files) == 0 {
+		files = []string{none}
+	}
+	dimensions := appendDimensionNames(config)
+	if len(dimensions) == 0 {
+		dimensions = []string{none}
+	}
+	name := ctx.EditConfigPath
+	if ctx.SSMParameterName != "" {
+		name = "in parameter store " + ctx.SSMParameterName
+	}
+	fmt.Printf("The config %s collects:\n"+
+		"Metric plugins: %s\n"+
+		"Log files: %s\n"+
+		"Dimensions appended to the metrics: %s\n",
+		name, strings.Join(enabled, ", "), strings.Join(files, ", "), strings.Join(dimensions, ", "))
+}
+
+func removeLogFiles(config *data.Config) {
+	files := logFilePaths(config)
+	if len(files) == 0 {
+		fmt.Println("The config monitors no log file.")
+		return
+	}
+	i := util.ChoiceIndex("Which log file do you want to stop monitoring?", len(files)+1, append(files, none))
+	if i < 0 || i == len(files) {
+		return
+	}
+	fileConfigs := config.LogsConfig.LogsCollect.Files.FileConfigs
+	fileConfigs = append(fileConfigs[:i], fileConfigs[i+1:]...)
+	config.LogsConfig.LogsCollect.Files.FileConfigs = fileConfigs
+	if len(fileConfigs) == 0 {
+		config.LogsConfig.LogsCollect.Files = nil
+	}
+}
+
+func addMetricPlugins(ctx *runtime.Context, config *data.Config) {
+	disabled := metricPlugins(ctx, config, false)
+	if len(disabled) == 0 {
+		fmt.Println("The config already collects all the metric plugins the wizard knows.")
+		return
+	}
+	answer := util.Choice("Which metric plugin do you want to collect?", len(disabled)+1, append(disabled, none))
+	if answer != none && answer != "" {
+		config.MetricsConf().Collection().EnablePlugin(ctx, answer)
+	}
+}
+
+func removeMetricPlugins(ctx *runtime.Context, config *data.Config) {
+	enabled := metricPlugins(ctx, config, true)
+	if len(enabled) == 0 {
+		fmt.Println("The config collects no metric plugin the wizard knows.")
+		return
+	}
+	answer := util.Choice("Which metric plugin do you want to stop collecting?", len(enabled)+1, append(enabled, none))
+	if answer != none && answer != "" {
+		config.MetricsConf().Collection().DisablePlugin(ctx, answer)
+	}
+}
+
+func changePluginMeasurements(ctx *runtime.Context, config *data.Config) {
+	var keys []string
+	for _, key := range metricPlugins(ctx, config, true) {
+		if len(util.MeasurementFields(config.MetricsConf().Collection().Plugin(ctx, key))) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		fmt.Println("The config collects no metric plugin with measurements the wizard knows.")
+		return
+	}
+	key := util.Choice("Which metric plugin do you want to change the measurements of?", len(keys)+1, append(keys, none))
+	if key == none || key == "" {
+		return
+	}
+	plugin := config.MetricsConf().Collection().Plugin(ctx, key)
+	selected := 0
+	for _, field := range util.MeasurementFields(plugin) {
+		question := fmt.Sprintf("Do you want to collect %s?", field)
+		var answer bool
+		if util.MeasurementField(plugin, field) {
+			answer = util.Yes(question)
+		} else {
+			answer = util.No(question)
+		}
+		util.SetMeasurementField(plugin, field, answer)
+		if answer {
+			selected++
+		}
+	}
+	if selected == 0 {
+		fmt.Printf("No measurement of %s is selected, so %s is not collected anymore.\n", key, key)
+		config.MetricsConf().Collection().DisablePlugin(ctx, key)
+	}
+}
+
+func addDimensions(ctx *runtime.Context, config *data.Config) {
+	appended := make(map[string]bool)
+	for _, name := range appendDimensionNames(config) {
+		appended[name] = true
+	}
+	var names []string
+	for name := range ec2Dimensions {
+		if !appended[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		fmt.Println("The config already appends all the dimensions the agent resolves.")
+		return
+	}
+	answer := util.Choice("Which dimension do you want to append to the metrics?", len(names)+1, append(names, none))
+	if answer == none || answer == "" {
+		return
+	}
+	metricsConf := config.MetricsConf()
+	if metricsConf.AppendDimensions == nil {
+		metricsConf.AppendDimensions = &metric.AppendDimensions{Dimensions: map[string]interface{}{}}
+	}
+	metricsConf.AppendDimensions.Dimensions[answer] = ec2Dimensions[answer]
+	ctx.WantEC2TagDimensions = true
+}
+
+func removeDimensions(ctx *runtime.Context, config *data.Config) {
+	names := appendDimensionNames(config)
+	if len(names) == 0 {
+		fmt.Println("The config appends no dimension to the metrics.")
+		return
+	}
+	answer := util.Choice("Which dimension do you want to stop appending to the metrics?", len(names)+1, append(names, none))
+	if answer == none || answer == "" {
+		return
+	}
+	metricsConf := config.MetricsConf()
+	delete(metricsConf.AppendDimensions.Dimensions, answer)
+	if len(metricsConf.AppendDimensions.Dimensions) == 0 {
+		metricsConf.AppendDimensions = nil
+		ctx.WantEC2TagDimensions = false
+	}
+}
+
+// metricPlugins returns the keys of the metric plugins of the OS of ctx the config collects, or the ones
+// it does not collect.
+func metricPlugins(ctx *runtime.Context, config *data.Config, collected bool) []string {
+	collection := new(metric.Collection)
+	if config.MetricsConfig != nil && config.MetricsConfig.MetricsCollect != nil {
+		collection = config.MetricsConfig.MetricsCollect
+	}
+	keys, enabled := collection.PluginKeys(ctx)
+	var result []string
+	for i, key := range keys {
+		if enabled[i] == collected {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+func logFilePaths(config *data.Config) []string {
+	if config.LogsConfig == nil || config.LogsConfig.LogsCollect == nil || config.LogsConfig.LogsCollect.Files == nil {
+		return nil
+	}
+	var paths []string
+	for _, fileConfig := range config.LogsConfig.LogsCollect.Files.FileConfigs {
+		paths = append(paths, fileConfig.FilePath)
+	}
+	return paths
+}
+
+func appendDimensionNames(config *data.Config) []string {
+	if config.MetricsConfig == nil || config.MetricsConfig.AppendDimensions == nil {
+		return nil
+	}
+	var names []string
+	for name := range config.MetricsConfig.AppendDimensions.Dimensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
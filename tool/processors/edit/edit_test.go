@@ -0,0 +1,67 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package edit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/serialization"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func TestProcessor_Process(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+		"agent": {"run_as_user": "root"},
+		"metrics": {"metrics_collected": {
+			"cpu": {"resources": ["*"], "measurement": ["usage_idle", "usage_user"]},
+			"mem": {"measurement": ["mem_used_percent"]}
+		}},
+		"logs": {"logs_collected": {"files": {"collect_list": [
+			{"file_path": "/var/log/messages", "log_group_name": "messages"},
+			{"file_path": "/var/log/secure", "log_group_name": "secure", "auto_removal": true}
+		]}}}
+	}`), 0600))
+	ctx := &runtime.Context{OsParameter: util.OsTypeLinux, EditConfigPath: configPath}
+	conf := new(data.Config)
+
+	testutil.Type(inputChan,
+		"2", "1",
+		"4", "mem",
+		"3", "swap",
+		"5", "cpu", "2", "", "", "", "", "1",
+		"6", "InstanceType",
+		"8")
+	Processor.Process(ctx, conf)
+	assert.Equal(t, configPath, ctx.ConfigOutputPath)
+
+	_, generated := conf.ToMap(ctx)
+	assert.Equal(t, map[string]interface{}{
+		"agent": map[string]interface{}{"run_as_user": "root"},
+		"metrics": map[string]interface{}{
+			"append_dimensions": map[string]interface{}{"InstanceType": "${aws:InstanceType}"},
+			"metrics_collected": map[string]interface{}{
+				"cpu":  map[string]interface{}{"resources": []interface{}{"*"}, "measurement": []interface{}{"usage_user", "cpu_usage_system"}},
+				"swap": map[string]interface{}{"measurement": []interface{}{"swap_used_percent"}},
+			},
+		},
+		"logs": map[string]interface{}{"logs_collected": map[string]interface{}{"files": map[string]interface{}{"collect_list": []interface{}{
+			map[string]interface{}{"file_path": "/var/log/secure", "log_group_name": "secure", "auto_removal": true},
+		}}}},
+	}, data.MergeExistingConfig(ctx, ctx.ExistingConfig, generated))
+}
+
+func TestProcessor_NextProcessor(t *testing.T) {
+	assert.Equal(t, serialization.Processor, Processor.NextProcessor(nil, nil))
+}
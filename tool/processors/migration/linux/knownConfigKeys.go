@@ -12,6 +12,7 @@ import (
 	"github.com/bigkevmcd/go-configparser"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 var knownConfigKeys = []string{
@@ -57,10 +58,21 @@ func addLogConfig(logsConfig *config.Logs, filePath, section string, p *configpa
 	logStreamName, _ := p.Get(section, "log_stream_name")
 	logGroupClass, _ := p.Get(section, "log_group_class")
 	timestampFormat, _ := p.Get(section, "datetime_format")
+	if timestampFormat != "" {
+		if err := util.ValidateTimestampFormat(timestampFormat); err != nil {
+			fmt.Printf("Warning: datetime_format %s for section %s in file %s is dropped, its log events get the time they are read at: %v\n", timestampFormat, section, filePath, err)
+			timestampFormat = ""
+		}
+	}
 	timezone, _ := p.Get(section, "time_zone")
 	multiLineStartPattern, _ := p.Get(section, "multi_line_start_pattern")
 	if multiLineStartPattern == "{datetime_format}" {
-		multiLineStartPattern = "{timestamp_format}"
+		// without a timestamp format there is nothing for the pattern to refer to
+		if timestampFormat == "" {
+			multiLineStartPattern = ""
+		} else {
+			multiLineStartPattern = "{timestamp_format}"
+		}
 	}
 	encoding, _ := p.Get(section, "encoding")
 	if encoding != "" {
@@ -94,5 +106,5 @@ func addLogConfig(logsConfig *config.Logs, filePath, section string, p *configpa
 		}
 
 	}
-	logsConfig.AddLogFile(logFilePath, logGroupName, logStreamName, timestampFormat, timezone, multiLineStartPattern, encoding, retention, logGroupClass)
+	logsConfig.AddLogFile(logFilePath, logGroupName, logStreamName, timestampFormat, timezone, multiLineStartPattern, encoding, retention, logGroupClass, nil)
 }
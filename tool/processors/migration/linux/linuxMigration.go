@@ -4,7 +4,7 @@
 package linux
 
 import (
-	"log"
+	"fmt"
 
 	"github.com/bigkevmcd/go-configparser"
 
@@ -27,32 +27,37 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
 	if ctx.HasExistingLinuxConfig || util.No(anyExistingLinuxConfigQuestion) {
 		filePath := ctx.ConfigFilePath
 		if filePath == "" {
 			filePath = util.AskWithDefault(filePathLinuxConfigQuestion, DefaultFilePathLinuxConfiguration)
 		}
-		processConfigFromPythonConfigParserFile(filePath, config.LogsConf())
+		if err := ReadAwslogsConfig(filePath, config.LogsConf()); err != nil {
+			return &util.WizardError{Code: util.ErrCodeConfigRead, Path: filePath, Err: err}
+		}
 	}
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
 	return logs.Processor
 }
 
-func processConfigFromPythonConfigParserFile(filePath string, logsConfig *config.Logs) {
+// ReadAwslogsConfig adds a log file to logsConfig for every section of the CloudWatch Logs agent config
+// at filePath, an awslogs.conf.
+func ReadAwslogsConfig(filePath string, logsConfig *config.Logs) error {
 	p, err := configparser.NewConfigParserFromFile(filePath)
 	if err != nil {
-		log.Panicf("E! Error in reading old python config from file %s: %v", filePath, err)
+		return fmt.Errorf("error in reading old python config from file %s: %w", filePath, err)
 	}
 	if p.HasSection(genericSectionName) {
-		err := p.RemoveSection(genericSectionName)
-		if err != nil {
-			log.Panicf("E! Error in removing generic section from the config file %s: %v", filePath, err)
+		if err = p.RemoveSection(genericSectionName); err != nil {
+			return fmt.Errorf("error in removing generic section from the config file %s: %w", filePath, err)
 		}
 	}
 	for _, section := range p.Sections() {
 		addLogConfig(logsConfig, filePath, section, p)
 	}
+	return nil
 }
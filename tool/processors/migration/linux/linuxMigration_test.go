@@ -145,7 +145,42 @@ func TestProcessConfigFromPythonConfigParserFile(t *testing.T) {
 	ctx := new(runtime.Context)
 	ctx.OsParameter = util.OsTypeLinux
 	logsConfig := new(config.Logs)
-	processConfigFromPythonConfigParserFile(tmpFile.Name(), logsConfig)
+	assert.NoError(t, ReadAwslogsConfig(tmpFile.Name(), logsConfig))
 	_, resultMap := logsConfig.ToMap(ctx)
 	assert.Equal(t, expectedMap, resultMap)
+
+	assert.ErrorContains(t, ReadAwslogsConfig(tmpFile.Name()+".missing", new(config.Logs)), "error in reading old python config")
+}
+
+func TestProcessConfigFromPythonConfigParserFileUnsupportedDatetimeFormat(t *testing.T) {
+	tomlString := `
+	[/var/log/messages]
+		datetime_format = %Y %j %H:%M:%S
+		file = /var/log/messages
+		log_stream_name = {hostname}
+		log_group_name = /var/log/messages
+		multi_line_start_pattern = {datetime_format}
+	`
+	expectedCollectList := []map[string]interface{}{
+		{
+			"file_path":         "/var/log/messages",
+			"log_group_name":    "/var/log/messages",
+			"log_stream_name":   "{hostname}",
+			"retention_in_days": -1,
+		},
+	}
+
+	tmpFile, _ := os.CreateTemp("", "")
+	defer os.Remove(tmpFile.Name())
+
+	err := os.WriteFile(tmpFile.Name(), []byte(tomlString), os.ModePerm)
+	assert.NoError(t, err)
+
+	ctx := new(runtime.Context)
+	ctx.OsParameter = util.OsTypeLinux
+	logsConfig := new(config.Logs)
+	assert.NoError(t, ReadAwslogsConfig(tmpFile.Name(), logsConfig))
+	_, resultMap := logsConfig.ToMap(ctx)
+	collectList := resultMap["logs_collected"].(map[string]interface{})["files"].(map[string]interface{})["collect_list"]
+	assert.Equal(t, expectedCollectList, collectList)
 }
@@ -16,7 +16,9 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {}
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
+	return nil
+}
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
 	switch ctx.OsParameter {
@@ -26,39 +26,47 @@ const (
 	DefaultFilePathWindowsConfiguration = "C:\\Program Files\\Amazon\\SSM\\Plugins\\awsCloudWatch\\AWS.EC2.Windows.CloudWatch.json"
 )
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
+	if !util.No(anyExistingLinuxConfigQuestion) {
+		return nil
+	}
+	if err := migrateOldAgentConfig(); err != nil {
+		return err
+	}
+	ctx.WindowsConfigMigrated = true
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
-	if util.No(anyExistingLinuxConfigQuestion) {
-		migrateOldAgentConfig()
+	if ctx.WindowsConfigMigrated {
 		return ssm.Processor
 	}
 	return defaultConfig.Processor
 }
 
-func migrateOldAgentConfig() {
+func migrateOldAgentConfig() error {
 	// 1 - parse the old config
 	var oldConfig OldSsmCwConfig
 	absPath := util.AskWithDefault(filePathWindowsConfigQuestion, DefaultFilePathWindowsConfiguration)
-	if file, err := os.ReadFile(absPath); err == nil {
-		if err := json.Unmarshal(file, &oldConfig); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to parse the provided configuration file. Error details: %v", err)
-			os.Exit(1)
-		}
-	} else {
-		fmt.Fprintf(os.Stderr, "Failed to read the provided configuration file. Error details: %v", err)
-		os.Exit(1)
+	file, err := os.ReadFile(absPath)
+	if err != nil {
+		return &util.WizardError{Code: util.ErrCodeConfigRead, Path: absPath, Err: fmt.Errorf("failed to read the provided configuration file: %w", err)}
+	}
+	if err = json.Unmarshal(file, &oldConfig); err != nil {
+		return &util.WizardError{Code: util.ErrCodeConfigParse, Path: absPath, Err: fmt.Errorf("failed to parse the provided configuration file: %w", err)}
 	}
 
 	// 2 - map to the new config
-	newConfig := MapOldWindowsConfigToNewConfig(oldConfig)
+	newConfig, err := MapOldWindowsConfigToNewConfig(oldConfig)
+	if err != nil {
+		return err
+	}
 
 	// 3 - marshall the new config object to string
-	if newConfigJson, err := json.Marshal(newConfig); err == nil {
-		util.SaveResultByteArrayToJsonFile(newConfigJson, util.ConfigFilePath())
-	} else {
-		fmt.Fprintf(os.Stderr, "Failed to produce the new configuration file. Error details: %v", err)
-		os.Exit(1)
+	newConfigJson, err := json.Marshal(newConfig)
+	if err != nil {
+		return &util.WizardError{Code: util.ErrCodeConfigSerialize, Err: fmt.Errorf("failed to produce the new configuration file: %w", err)}
 	}
+	_, err = util.SaveResultByteArrayToJsonFileE(newConfigJson, util.ConfigFilePath())
+	return err
 }
@@ -4,12 +4,13 @@
 package windows
 
 import (
-	"fmt"
+	"errors"
 	"log"
-	"os"
 	"strings"
 
 	"github.com/Jeffail/gabs"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 const (
@@ -18,7 +19,10 @@ const (
 	INFORMATION = "INFORMATION"
 )
 
-func MapOldWindowsConfigToNewConfig(oldConfig OldSsmCwConfig) (newConfig NewCwConfig) {
+// MapOldWindowsConfigToNewConfig converts the CloudWatch config of the SSM agent. The error is a
+// *util.WizardError with ErrCodeConfigInvalid when the config uses several regions or keys, which the
+// agent does not support.
+func MapOldWindowsConfigToNewConfig(oldConfig OldSsmCwConfig) (newConfig NewCwConfig, err error) {
 	// Add static part
 	newConfig.Logs = nil
 	newConfig.Metrics = nil
@@ -46,12 +50,11 @@ func MapOldWindowsConfigToNewConfig(oldConfig OldSsmCwConfig) (newConfig NewCwCo
 			}
 		}
 
-		// This will panic if different regions/credentials exist because the new agent does not support multiple values
+		// This fails if different regions/credentials exist because the new agent does not support multiple values
 		// TODO: Capture different regions when the new agent is capable of https://github.com/aws/amazon-cloudwatch-agent/issues/230
 		if component.Parameters.Region != "" {
 			if val, ok := newConfig.Agent["region"]; ok && val != component.Parameters.Region {
-				fmt.Fprint(os.Stderr, "Detected multiple different regions in the input config file. This feature is unsupported by the new agent. Thus, will not be able to migrate the old config. Terminating.")
-				os.Exit(1)
+				return newConfig, &util.WizardError{Code: util.ErrCodeConfigInvalid, Err: errors.New("detected multiple different regions in the input config file, which the new agent does not support, so the old config cannot be migrated")}
 			}
 			jsonObjAgent.Set(component.Parameters.Region, "region")
 		}
@@ -59,8 +62,7 @@ func MapOldWindowsConfigToNewConfig(oldConfig OldSsmCwConfig) (newConfig NewCwCo
 			if creds, ok := newConfig.Agent["credentials"]; ok {
 				if credsMap, valid := creds.(map[string]interface{}); valid {
 					if val, ok := credsMap["access_key"]; ok && val != component.Parameters.AccessKey {
-						fmt.Fprint(os.Stderr, "Detected multiple different access keys in the input config file. This feature is unsupported by the new agent. Thus, will not be able to migrate the old config. Terminating.")
-						os.Exit(1)
+						return newConfig, &util.WizardError{Code: util.ErrCodeConfigInvalid, Err: errors.New("detected multiple different access keys in the input config file, which the new agent does not support, so the old config cannot be migrated")}
 					}
 				}
 			}
@@ -70,8 +72,7 @@ func MapOldWindowsConfigToNewConfig(oldConfig OldSsmCwConfig) (newConfig NewCwCo
 			if creds, ok := newConfig.Agent["credentials"]; ok {
 				if credsMap, valid := creds.(map[string]interface{}); valid {
 					if val, ok := credsMap["secret_key"]; ok && val != component.Parameters.SecretKey {
-						fmt.Fprint(os.Stderr, "Detected multiple different secret keys in the input config file. This feature is unsupported by the new agent. Thus, will not be able to migrate the old config. Terminating.")
-						os.Exit(1)
+						return newConfig, &util.WizardError{Code: util.ErrCodeConfigInvalid, Err: errors.New("detected multiple different secret keys in the input config file, which the new agent does not support, so the old config cannot be migrated")}
 					}
 				}
 			}
@@ -6,13 +6,13 @@ package windows
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 func TestMapOldWindowsConfigToNewConfig(t *testing.T) {
@@ -37,7 +37,8 @@ func TestMapOldWindowsConfigToNewConfig(t *testing.T) {
 			}
 
 			// Get actual output
-			newConfig := MapOldWindowsConfigToNewConfig(oldConfig)
+			newConfig, err := MapOldWindowsConfigToNewConfig(oldConfig)
+			assert.NoError(t, err)
 			confBytes, err := json.Marshal(newConfig)
 			if err != nil {
 				t.Error(err)
@@ -76,19 +77,9 @@ func TestInvalidOldWindowsConfig(t *testing.T) {
 				return
 			}
 
-			// Run the function - expect it to exit(1) as the input is invalid
-			// Ref: https://stackoverflow.com/questions/26225513/how-to-test-os-exit-scenarios-in-go
-			if os.Getenv("BE_CRASHER") == "1" {
-				MapOldWindowsConfigToNewConfig(oldConfig)
-				return
-			}
-			cmd := exec.Command(os.Args[0], "-test.run=TestInvalidOldWindowsConfig")
-			cmd.Env = append(os.Environ(), "BE_CRASHER=1")
-			err = cmd.Run()
-			if e, ok := err.(*exec.ExitError); ok && !e.Success() {
-				return
-			}
-			t.Fatalf("process ran with err %v, want exit status 1", err)
+			// Run the function - expect it to fail as the input is invalid
+			_, err = MapOldWindowsConfigToNewConfig(oldConfig)
+			assert.Equal(t, util.ErrCodeConfigInvalid, util.ErrorCode(err))
 		})
 	}
 }
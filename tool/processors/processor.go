@@ -10,7 +10,9 @@ import (
 
 var StartProcessor Processor
 
+// Processor is a step of the wizard. Process asks the questions of the step and updates config; the error
+// it returns stops the wizard. NextProcessor returns the step that follows, nil when the wizard is done.
 type Processor interface {
-	Process(ctx *runtime.Context, config *data.Config)
+	Process(ctx *runtime.Context, config *data.Config) error
 	NextProcessor(ctx *runtime.Context, config *data.Config) interface{}
 }
@@ -29,14 +29,18 @@ const (
 
 	EventFormatXML       = "xml"
 	EventFormatPlainText = "text"
+
+	FilterByLevel      = "By event level and event ID"
+	FilterByXPathQuery = "By XPath query"
 )
 
 var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
 	monitorEvents(ctx, config)
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
@@ -49,21 +53,42 @@ func monitorEvents(ctx *runtime.Context, config *data.Config) {
 		return
 	}
 
+	// the channels can only be listed on the host the wizard runs on
+	var channels []string
+	if util.CurOS() == util.OsTypeWindows {
+		channels, _ = util.WindowsEventChannels()
+	}
+
 	eventFormatDefaultOption := 1
 	for {
 		logsConf := config.LogsConf()
-		eventName := util.AskWithDefault(fmt.Sprintf("%s name:", WindowsEventLog), "System")
+		eventName, err := util.AskWindowsEventChannel("System", channels)
+		if err != nil {
+			return
+		}
 
-		availableEventLevels := []string{VERBOSE, INFORMATION, WARNING, ERROR, CRITICAL}
 		eventLevels := []string{}
-		for _, eventLevel := range availableEventLevels {
-			yes = util.Yes(
-				fmt.Sprintf("Do you want to monitor %s level events for %s %s ?",
-					eventLevel,
-					WindowsEventLog,
-					eventName))
-			if yes {
-				eventLevels = append(eventLevels, eventLevel)
+		var eventIDs []int
+		var xpathQuery string
+		filter := util.Choice(fmt.Sprintf("How do you want to select the events of %s to monitor?", eventName), 1, []string{FilterByLevel, FilterByXPathQuery})
+		if filter == FilterByXPathQuery {
+			if xpathQuery, err = util.AskWindowsEventXPathQuery(eventName); err != nil {
+				return
+			}
+		} else {
+			availableEventLevels := []string{VERBOSE, INFORMATION, WARNING, ERROR, CRITICAL}
+			for _, eventLevel := range availableEventLevels {
+				yes = util.Yes(
+					fmt.Sprintf("Do you want to monitor %s level events for %s %s ?",
+						eventLevel,
+						WindowsEventLog,
+						eventName))
+				if yes {
+					eventLevels = append(eventLevels, eventLevel)
+				}
+			}
+			if eventIDs, err = util.AskWindowsEventIDs(eventName); err != nil {
+				return
 			}
 		}
 
@@ -74,16 +99,14 @@ func monitorEvents(ctx *runtime.Context, config *data.Config) {
 			logStreamNameHint = "{hostname}"
 		}
 
-		logStreamName := util.AskWithDefault("Log stream name:", logStreamNameHint)
+		logStreamName, err := util.AskLogStreamName(logStreamNameHint)
+		if err != nil {
+			return
+		}
 
-		logGroupDefaultOption := 1
-		logGroupClass := util.Choice("Which log group class would you like to have for this log group?", logGroupDefaultOption, []string{util.StandardLogGroupClass, util.InfrequentAccessLogGroupClass})
-		if logGroupClass == util.StandardLogGroupClass {
-			logGroupClass = util.StandardLogGroupClass
-			logGroupDefaultOption = 1
-		} else {
-			logGroupClass = util.InfrequentAccessLogGroupClass
-			logGroupDefaultOption = 2
+		logGroupClass, err := util.AskLogClass()
+		if err != nil {
+			return
 		}
 
 		eventFormat := util.Choice("In which format do you want to store windows event to CloudWatch Logs?", eventFormatDefaultOption, []string{EventFormatXMLDescription, EventFormatPlainTextDescription})
@@ -102,7 +125,7 @@ func monitorEvents(ctx *runtime.Context, config *data.Config) {
 		if err == nil {
 			retention = i
 		}
-		logsConf.AddWindowsEvent(eventName, logGroupName, logStreamName, eventFormat, eventLevels, retention, logGroupClass)
+		logsConf.AddWindowsEvent(eventName, logGroupName, logStreamName, eventFormat, eventLevels, eventIDs, xpathQuery, retention, logGroupClass)
 
 		yes = util.Yes(fmt.Sprintf("Do you want to specify any additional %s to monitor?", WindowsEventLog))
 		if !yes {
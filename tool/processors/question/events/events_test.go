@@ -22,7 +22,7 @@ func TestProcessor_Process(t *testing.T) {
 	ctx.OsParameter = util.OsTypeWindows
 	conf := new(data.Config)
 
-	testutil.Type(inputChan, "", "", "", "", "", "", "", "", "", "", "", "", "2")
+	testutil.Type(inputChan, "", "", "", "", "", "", "", "", "", "", "", "", "", "", "2")
 	Processor.Process(ctx, conf)
 	_, confMap := conf.ToMap(ctx)
 	assert.Equal(t, map[string]interface{}{
@@ -34,6 +34,27 @@ func TestProcessor_Process(t *testing.T) {
 		confMap)
 }
 
+func TestProcessor_ProcessEventFilters(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	ctx := new(runtime.Context)
+	ctx.OsParameter = util.OsTypeWindows
+	conf := new(data.Config)
+
+	testutil.Type(inputChan, "", "Security", "", "", "2", "2", "", "", "4624,4625", "", "", "", "", "", "",
+		"System", "2", "*[System[EventID=7036]]", "", "", "", "", "", "2")
+	Processor.Process(ctx, conf)
+	_, confMap := conf.ToMap(ctx)
+	assert.Equal(t, map[string]interface{}{
+		"logs": map[string]interface{}{
+			"logs_collected": map[string]interface{}{
+				"windows_events": map[string]interface{}{
+					"collect_list": []map[string]interface{}{
+						{"event_name": "Security", "event_format": "xml", "event_levels": []string{VERBOSE, ERROR, CRITICAL}, "event_ids": []int{4624, 4625}, "log_group_name": "Security", "log_group_class": util.StandardLogGroupClass, "log_stream_name": "{instance_id}", "retention_in_days": -1},
+						{"event_name": "System", "event_format": "xml", "xpath_query": "*[System[EventID=7036]]", "log_group_name": "System", "log_group_class": util.StandardLogGroupClass, "log_stream_name": "{instance_id}", "retention_in_days": -1}}}}}},
+		confMap)
+}
+
 func TestProcessor_NextProcessor(t *testing.T) {
 	nextProcessor := Processor.NextProcessor(nil, nil)
 	assert.Equal(t, tracesconfig.Processor, nextProcessor)
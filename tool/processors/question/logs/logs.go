@@ -10,8 +10,7 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
-	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/events"
-	"github.com/aws/amazon-cloudwatch-agent/tool/processors/tracesconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/prometheus"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
@@ -21,15 +20,13 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
 	monitorLogs(ctx, config)
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
-	if ctx.OsParameter == util.OsTypeWindows {
-		return events.Processor
-	}
-	return tracesconfig.Processor
+	return prometheus.Processor
 }
 
 func monitorLogs(ctx *runtime.Context, config *data.Config) {
@@ -45,29 +42,64 @@ func monitorLogs(ctx *runtime.Context, config *data.Config) {
 		return
 	}
 	for {
-		logsConf := config.LogsConf()
-		logFilePath := util.Ask("Log file path:")
-		logGroupNameHint := strings.Replace(filepath.Base(logFilePath), " ", "_", -1)
-		logGroupName := util.AskWithDefault("Log group name:", logGroupNameHint)
-		logGroupClass := util.Choice("Log group class:", 1, []string{util.StandardLogGroupClass, util.InfrequentAccessLogGroupClass})
-		logStreamNameHint := "{instance_id}"
-		if ctx.IsOnPrem {
-			logStreamNameHint = "{hostname}"
-		}
-		logStreamName := util.AskWithDefault("Log stream name:", logStreamNameHint)
-
-		keys := translator.ValidRetentionInDays
-		retentionInDays := util.Choice("Log Group Retention in days", 1, keys)
-		retention := -1
-
-		i, err := strconv.Atoi(retentionInDays)
-		if err == nil {
-			retention = i
+		if !AddLogFile(ctx, config) {
+			return
 		}
-		logsConf.AddLogFile(logFilePath, logGroupName, logStreamName, "", "", "", "", retention, logGroupClass)
 		yes = util.Yes("Do you want to specify any additional log files to monitor?")
 		if !yes {
 			return
 		}
 	}
 }
+
+// AddLogFile asks about a log file to monitor and adds it to the config, or returns false when an
+// answer could not be read.
+func AddLogFile(ctx *runtime.Context, config *data.Config) bool {
+	logsConf := config.LogsConf()
+	logFilePath, err := util.AskPath("Log file path:", ctx.OsParameter)
+	if err != nil {
+		return false
+	}
+	encoding := ""
+	if detected, err := util.DetectLogEncoding(logFilePath); err == nil && detected != util.EncodingUTF8 {
+		if encoding, err = util.AskLogEncoding(detected); err != nil {
+			return false
+		}
+	}
+	logGroupNameHint := strings.Replace(filepath.Base(logFilePath), " ", "_", -1)
+	if ctx.ContainerClusterName != "" {
+		logGroupNameHint = util.ContainerInsightsLogGroup(ctx.ContainerClusterName, "application")
+	}
+	logGroupName := util.AskWithDefault("Log group name:", logGroupNameHint)
+	logGroupClass, err := util.AskLogClass()
+	if err != nil {
+		return false
+	}
+	timestampFormat, err := util.AskTimestampFormat()
+	if err != nil {
+		return false
+	}
+	logStreamNameHint := "{instance_id}"
+	if ctx.IsOnPrem {
+		logStreamNameHint = "{hostname}"
+	}
+	logStreamName, err := util.AskLogStreamName(logStreamNameHint)
+	if err != nil {
+		return false
+	}
+
+	keys := translator.ValidRetentionInDays
+	retentionInDays := util.Choice("Log Group Retention in days", 1, keys)
+	retention := -1
+
+	i, err := strconv.Atoi(retentionInDays)
+	if err == nil {
+		retention = i
+	}
+	filters, err := util.AskLogFilters()
+	if err != nil {
+		return false
+	}
+	logsConf.AddLogFile(logFilePath, logGroupName, logStreamName, timestampFormat, "", "", encoding, retention, logGroupClass, filters)
+	return true
+}
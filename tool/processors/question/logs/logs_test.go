@@ -9,8 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
-	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/events"
-	"github.com/aws/amazon-cloudwatch-agent/tool/processors/tracesconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/prometheus"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
@@ -25,7 +24,7 @@ func TestProcessor_Process(t *testing.T) {
 
 	conf := new(data.Config)
 
-	testutil.Type(inputChan, "", "/var/log/messages", "", "2", "", "", "2")
+	testutil.Type(inputChan, "", "/var/log/messages", "", "2", "", "", "", "", "2")
 	Processor.Process(ctx, conf)
 	_, confMap := conf.ToMap(ctx)
 	assert.Equal(t,
@@ -47,15 +46,28 @@ func TestProcessor_Process(t *testing.T) {
 			},
 		},
 		confMap)
+
+	// in containers the log group defaults to the application log group of Container Insights
+	ctx.ContainerClusterName = "eks-prod"
+	conf = new(data.Config)
+	testutil.Type(inputChan, "", "/var/log/messages", "", "2", "", "", "", "", "2")
+	Processor.Process(ctx, conf)
+	_, confMap = conf.ToMap(ctx)
+	collectList := confMap["logs"].(map[string]interface{})["logs_collected"].(map[string]interface{})["files"].(map[string]interface{})["collect_list"].([]map[string]interface{})
+	assert.Equal(t, "/aws/containerinsights/eks-prod/application", collectList[0]["log_group_name"])
+
+	// the timestamp format is checked against a sample line before it is kept
+	conf = new(data.Config)
+	testutil.Type(inputChan, "", "/var/log/messages", "", "2", "%Q", "%b %d %H:%M:%S", "1", "Oct 15 12:00:01 host sshd[1]: accepted", "", "", "", "", "2")
+	Processor.Process(ctx, conf)
+	_, confMap = conf.ToMap(ctx)
+	collectList = confMap["logs"].(map[string]interface{})["logs_collected"].(map[string]interface{})["files"].(map[string]interface{})["collect_list"].([]map[string]interface{})
+	assert.Equal(t, "%b %d %H:%M:%S", collectList[0]["timestamp_format"])
 }
 
 func TestProcessor_NextProcessor(t *testing.T) {
 	ctx := new(runtime.Context)
 	conf := new(data.Config)
 	nextProcessor := Processor.NextProcessor(ctx, conf)
-	assert.Equal(t, tracesconfig.Processor, nextProcessor)
-
-	ctx.OsParameter = util.OsTypeWindows
-	nextProcessor = Processor.NextProcessor(ctx, conf)
-	assert.Equal(t, events.Processor, nextProcessor)
+	assert.Equal(t, prometheus.Processor, nextProcessor)
 }
@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/events"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/tracesconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+var Processor processors.Processor = &processor{}
+
+type processor struct{}
+
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
+	monitorPrometheus(ctx, config)
+	return nil
+}
+
+func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
+	if ctx.OsParameter == util.OsTypeWindows {
+		return events.Processor
+	}
+	return tracesconfig.Processor
+}
+
+// monitorPrometheus asks about the Prometheus endpoints to scrape, saves the scrape config of the jobs
+// and adds logs.metrics_collected.prometheus pointing at it.
+func monitorPrometheus(ctx *runtime.Context, config *data.Config) {
+	if !util.No("Do you want to scrape metrics from Prometheus endpoints?") {
+		return
+	}
+	scrapeConfigs, err := util.AskPrometheusScrapeConfigs()
+	if err != nil {
+		return
+	}
+	scrapeInterval := util.Choice("How often do you want to scrape the Prometheus targets?", len(util.PrometheusScrapeIntervals), util.PrometheusScrapeIntervals)
+	logGroupName, err := util.AskPrometheusLogGroup()
+	if err != nil {
+		return
+	}
+	emfProcessor, err := util.AskEMFProcessor()
+	if err != nil {
+		return
+	}
+	configPath := util.NormalizeConfigPath(util.AskWithDefault("Where do you want to save the Prometheus scrape config?", util.DefaultPrometheusConfigPath(ctx.OsParameter)), ctx.OsParameter)
+
+	content, err := util.PrometheusScrapeConfigYAML(scrapeInterval, scrapeConfigs)
+	if err == nil {
+		err = util.SavePrometheusScrapeConfig(content, configPath)
+	}
+	if err != nil {
+		fmt.Printf("Unable to save the Prometheus scrape config, the endpoints are not scraped: %v\n", err)
+		return
+	}
+	fmt.Printf("Saved the Prometheus scrape config to %s.\n", configPath)
+	config.LogsConf().AddPrometheus(logGroupName, configPath, emfProcessor)
+}
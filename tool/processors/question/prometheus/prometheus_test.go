@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/events"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/tracesconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+	"github.com/aws/amazon-cloudwatch-agent/tool/validateconfig"
+)
+
+func TestProcessor_Process(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	ctx := new(runtime.Context)
+	ctx.OsParameter = util.OsTypeLinux
+	conf := new(data.Config)
+
+	testutil.Type(inputChan, "")
+	Processor.Process(ctx, conf)
+	assert.Nil(t, conf.LogsConfig)
+
+	configPath := filepath.Join(t.TempDir(), "prometheus.yaml")
+	testutil.Type(inputChan, "1", "", "localhost:9100", "", "", "", "", "", "", "job,instance", "", "", configPath)
+	Processor.Process(ctx, conf)
+	_, confMap := conf.ToMap(ctx)
+	assert.Equal(t,
+		map[string]interface{}{
+			"logs": map[string]interface{}{
+				"metrics_collected": map[string]interface{}{
+					"prometheus": map[string]interface{}{
+						"log_group_name":         "prometheus",
+						"prometheus_config_path": configPath,
+						"emf_processor": map[string]interface{}{
+							"metric_namespace": "CWAgent/Prometheus",
+							"metric_declaration": []interface{}{
+								map[string]interface{}{"dimensions": [][]string{{"job", "instance"}}, "metric_selectors": []string{".*"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		confMap)
+	content, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "job_name: prometheus")
+
+	// the agent translates the generated section
+	content, err = json.Marshal(confMap)
+	require.NoError(t, err)
+	report := validateconfig.ValidateContent(content, validateconfig.Options{OS: util.OsTypeLinux, Mode: "ec2", Offline: true})
+	assert.Empty(t, report.Issues)
+}
+
+func TestProcessor_NextProcessor(t *testing.T) {
+	ctx := new(runtime.Context)
+	conf := new(data.Config)
+	assert.Equal(t, tracesconfig.Processor, Processor.NextProcessor(ctx, conf))
+
+	ctx.OsParameter = util.OsTypeWindows
+	assert.Equal(t, events.Processor, Processor.NextProcessor(ctx, conf))
+}
@@ -5,6 +5,7 @@ package serialization
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
@@ -17,15 +18,37 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
 	_, resultMap := config.ToMap(ctx)
-	byteArray := util.SerializeResultMapToJsonByteArray(resultMap)
-	var filepath string
-	filepath = ctx.ConfigOutputPath
-	if filepath == "" {
-		filepath = util.ConfigFilePath()
+	if ctx.ExistingConfig != nil {
+		resultMap = data.MergeExistingConfig(ctx, ctx.ExistingConfig, resultMap)
+	}
+	var err error
+	if ctx.MergeConfig {
+		// merged before the config is finalized and checked, so the config saved is the one checked
+		outputPath := ctx.ConfigOutputPath
+		if outputPath == "" {
+			outputPath = util.ConfigFilePath()
+		}
+		if resultMap, err = util.MergeWithConfigFile(resultMap, outputPath); err != nil {
+			return err
+		}
+	}
+	resultMap, err = finalize(resultMap)
+	if err != nil {
+		return err
+	}
+	if err = checkPolicy(resultMap); err != nil {
+		return err
+	}
+	byteArray, err := util.SerializeResultMapToJsonByteArrayE(resultMap)
+	if err != nil {
+		return err
+	}
+	filepath, err := util.SaveResultByteArrayToJsonFileE(byteArray, ctx.ConfigOutputPath)
+	if err != nil {
+		return err
 	}
-	util.SaveResultByteArrayToJsonFile(byteArray, filepath)
 	fmt.Printf("Current config as follows:\n"+
 		"%s\n"+
 		"Please check the above content of the config.\n"+
@@ -33,6 +56,77 @@ func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
 		"Edit it manually if needed.\n",
 		string(byteArray),
 		filepath)
+	if command, err := util.ApplyCommand(filepath); err == nil {
+		fmt.Printf("Run the following command to apply the config and restart the agent:\n%s\n", command)
+	}
+	setAgentProfile(ctx, filepath)
+	return nil
+}
+
+var commonConfigPath = util.CommonConfigPath
+
+// setAgentProfile sets the profile chosen for the agent in the common-config.toml of this host once the
+// config saved at savedPath is the one of this host. A config for other hosts, written to another path or
+// to the parameter store, only gets the settings printed, to be set on those hosts.
+func setAgentProfile(ctx *runtime.Context, savedPath string) {
+	if ctx.AgentProfile == "" {
+		return
+	}
+	filePath := commonConfigPath()
+	if savedPath != util.ConfigFilePath() || ctx.SSMParameterName != "" {
+		fmt.Printf("Set the [credentials] section of %s on the hosts the config is for:\n"+
+			"   shared_credential_profile = %q\n   shared_credential_file = %q\n", filePath, ctx.AgentProfile, ctx.AgentCredentialsFile)
+		return
+	}
+	existing, err := util.CommonConfigCredentials(filePath)
+	if err != nil {
+		fmt.Printf("W! The profile of the agent was not set: %v\n", err)
+		return
+	}
+	if len(existing) > 0 && !util.No(fmt.Sprintf("%s already sets the credentials of the agent %v. Do you want to replace them with the profile %s?", filePath, existing, ctx.AgentProfile)) {
+		fmt.Printf("The credentials of the agent in %s were kept.\n", filePath)
+		return
+	}
+	if err = util.SetCommonConfigCredentials(filePath, ctx.AgentProfile, ctx.AgentCredentialsFile); err != nil {
+		fmt.Printf("W! The profile of the agent was not set: %v\n", err)
+		return
+	}
+	fmt.Printf("Set the agent to read the profile %s from %s in %s.\n", ctx.AgentProfile, ctx.AgentCredentialsFile, filePath)
+}
+
+// finalize returns the config cleaned up by util.FinalizeConfig and prints what was adjusted. The error
+// stops the wizard without saving the config.
+func finalize(resultMap map[string]interface{}) (map[string]interface{}, error) {
+	finalized, changes, err := util.FinalizeConfig(resultMap)
+	if err != nil {
+		return nil, &util.WizardError{Code: util.ErrCodeConfigInvalid, Err: fmt.Errorf("unable to finalize the config: %w", err)}
+	}
+	for _, change := range changes {
+		fmt.Printf("Adjusted the config: %s\n", change)
+	}
+	return finalized, nil
+}
+
+// checkPolicy prints the violations of the policy named by util.PolicyFileEnv, if any. The error, when
+// the policy cannot be loaded or one of the violations is an error, stops the wizard without saving the
+// config.
+func checkPolicy(resultMap map[string]interface{}) error {
+	policyFile := os.Getenv(util.PolicyFileEnv)
+	if policyFile == "" {
+		return nil
+	}
+	policy, err := util.LoadPolicy(policyFile)
+	if err != nil {
+		return &util.WizardError{Code: util.ErrCodeConfigRead, Path: policyFile, Err: fmt.Errorf("unable to load the config policy: %w", err)}
+	}
+	violations := util.CheckPolicyViolations(resultMap, policy)
+	for _, violation := range violations {
+		fmt.Printf("Policy %s\n", violation)
+	}
+	if util.HasPolicyErrors(violations) {
+		return &util.WizardError{Code: util.ErrCodeConfigInvalid, Path: policyFile, Err: fmt.Errorf("the config was not saved because it violates the policy %s", policyFile)}
+	}
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
@@ -4,13 +4,18 @@
 package serialization
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/ssm"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 func TestProcessor_Process(t *testing.T) {
@@ -22,7 +27,57 @@ func TestProcessor_Process(t *testing.T) {
 	assert.Equal(t, new(data.Config), conf)
 }
 
+func TestProcessMergeConfig(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(outputPath, []byte(`{"logs":{"force_flush_interval":15}}`), 0600))
+	conf := new(data.Config)
+	conf.AgentConf().Runasuser = "cwagent"
+
+	ctx := &runtime.Context{ConfigOutputPath: outputPath, MergeConfig: true}
+	require.NoError(t, Processor.Process(ctx, conf))
+	m, err := util.ReadConfigMap(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"run_as_user": "cwagent"}, m["agent"])
+	assert.Equal(t, map[string]interface{}{"force_flush_interval": float64(15)}, m["logs"])
+
+	ctx.MergeConfig = false
+	require.NoError(t, Processor.Process(ctx, conf))
+	m, err = util.ReadConfigMap(outputPath)
+	require.NoError(t, err)
+	assert.NotContains(t, m, "logs", "without merge-config the config is overwritten")
+}
+
 func TestProcessor_NextProcessor(t *testing.T) {
 	nextProcessor := Processor.NextProcessor(nil, nil)
 	assert.Equal(t, ssm.Processor, nextProcessor)
 }
+
+func TestSetAgentProfile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "common-config.toml")
+	originalPath := commonConfigPath
+	commonConfigPath = func() string { return filePath }
+	t.Cleanup(func() { commonConfigPath = originalPath })
+	inputChan := testutil.SetUpTestInputStream()
+	ctx := &runtime.Context{AgentProfile: "base", AgentCredentialsFile: "/home/agent/.aws/credentials"}
+
+	setAgentProfile(ctx, filepath.Join(t.TempDir(), "config.json"))
+	assert.NoFileExists(t, filePath, "a config for other hosts does not change the common-config.toml of this host")
+
+	setAgentProfile(ctx, util.ConfigFilePath())
+	existing, err := util.CommonConfigCredentials(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"shared_credential_profile": "base", "shared_credential_file": "/home/agent/.aws/credentials"}, existing)
+
+	ctx.AgentProfile = "admin"
+	testutil.Type(inputChan, "")
+	setAgentProfile(ctx, util.ConfigFilePath())
+	existing, err = util.CommonConfigCredentials(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "base", existing["shared_credential_profile"], "the existing credentials are kept unless replacing them is confirmed")
+
+	testutil.Type(inputChan, "1")
+	setAgentProfile(ctx, util.ConfigFilePath())
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `shared_credential_profile = "admin"`)
+}
@@ -0,0 +1,173 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package ssm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+// newSSMClient returns the SSM client of region. It is replaced in tests.
+var newSSMClient = func(region string, creds *credentials.Credentials) (ssmiface.SSMAPI, error) {
+	awsConfig := aws.NewConfig().WithRegion(region)
+	if creds != nil {
+		awsConfig = awsConfig.WithCredentials(creds)
+	}
+	ses, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return ssm.New(ses), nil
+}
+
+// FetchConfig downloads the config stored in the parameter ctx.SSMParameterName for the wizard to edit.
+// A name ending with * lists the parameters starting with the rest of it to choose from, e.g.
+// AmazonCloudWatch-*. The region, credentials, version, tier and KMS key of the parameter are kept in ctx
+// to write the edited config back to it.
+func FetchConfig(ctx *runtime.Context) (map[string]interface{}, error) {
+	ctx.SSMRegion = askRegion(ctx, "Which region is the config stored in the parameter store?")
+	ctx.SSMCredentials = askCredentials(ctx, "Which AWS credential should be used to read and write the json config in parameter store?")
+	client, err := newSSMClient(ctx.SSMRegion, ctx.SSMCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("error in creating session: %w", err)
+	}
+	name := ctx.SSMParameterName
+	if prefix, ok := strings.CutSuffix(name, "*"); ok {
+		if name, err = chooseParameter(client, prefix); err != nil {
+			return nil, err
+		}
+	}
+	metadata, err := describeParameter(client, name)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.GetParameter(&ssm.GetParameterInput{Name: aws.String(name), WithDecryption: aws.Bool(true)})
+	if err != nil {
+		return nil, fmt.Errorf("error in getting config from parameter store %s: %w", name, err)
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal([]byte(aws.StringValue(output.Parameter.Value)), &m); err != nil {
+		return nil, fmt.Errorf("the parameter %s does not hold a json config: %w", name, err)
+	}
+	ctx.SSMParameterName = name
+	ctx.SSMParameterVersion = aws.Int64Value(output.Parameter.Version)
+	ctx.SSMTier = aws.StringValue(metadata.Tier)
+	ctx.SSMKMSKeyID = aws.StringValue(metadata.KeyId)
+	fmt.Printf("Fetched version %d of the config in parameter store %s.\n", ctx.SSMParameterVersion, name)
+	return m, nil
+}
+
+// chooseParameter lists the parameters whose name starts with prefix and returns the one chosen.
+func chooseParameter(client ssmiface.SSMAPI, prefix string) (string, error) {
+	input := &ssm.DescribeParametersInput{}
+	if prefix != "" {
+		input.ParameterFilters = []*ssm.ParameterStringFilter{{
+			Key:    aws.String("Name"),
+			Option: aws.String("BeginsWith"),
+			Values: []*string{aws.String(prefix)},
+		}}
+	}
+	var names []string
+	err := client.DescribeParametersPages(input, func(page *ssm.DescribeParametersOutput, lastPage bool) bool {
+		for _, parameter := range page.Parameters {
+			names = append(names, aws.StringValue(parameter.Name))
+		}
+		return true
+	})
+	if err != nil {
+		return "", fmt.Errorf("error in listing the parameters starting with %s: %w", prefix, err)
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("there is no parameter starting with %s", prefix)
+	}
+	sort.Strings(names)
+	return util.Choice("Which parameter do you want to edit the config of?", 1, names), nil
+}
+
+func describeParameter(client ssmiface.SSMAPI, name string) (*ssm.ParameterMetadata, error) {
+	output, err := client.DescribeParameters(&ssm.DescribeParametersInput{
+		ParameterFilters: []*ssm.ParameterStringFilter{{
+			Key:    aws.String("Name"),
+			Option: aws.String("Equals"),
+			Values: []*string{aws.String(name)},
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error in describing parameter %s: %w", name, err)
+	}
+	if len(output.Parameters) == 0 {
+		return nil, fmt.Errorf("the parameter %s does not exist", name)
+	}
+	return output.Parameters[0], nil
+}
+
+// writeBack puts the edited config back to the parameter FetchConfig fetched it from, as a new version.
+// The parameter keeps its tier unless the config outgrew it, and is encrypted with the KMS key chosen.
+// When the parameter changed since it was fetched, it is only written over once confirmed.
+func writeBack(ctx *runtime.Context) {
+	name := ctx.SSMParameterName
+	if !util.Yes(fmt.Sprintf("Do you want to write the config back to parameter store %s?", name)) {
+		return
+	}
+	serializedConfig, err := readSavedConfig(ctx)
+	if err != nil {
+		fmt.Printf("Error in putting config to parameter store %s: %v\n", name, err)
+		return
+	}
+	client, err := newSSMClient(ctx.SSMRegion, ctx.SSMCredentials)
+	if err != nil {
+		fmt.Printf("Error in creating session:\n %v\n", err)
+		return
+	}
+	if current, err := describeParameter(client, name); err == nil && aws.Int64Value(current.Version) != ctx.SSMParameterVersion {
+		fmt.Printf("The parameter %s was changed to version %d since version %d was fetched.\n", name, aws.Int64Value(current.Version), ctx.SSMParameterVersion)
+		if !util.No("Do you want to overwrite the changes?") {
+			fmt.Printf("The config was not written back to parameter store %s.\n", name)
+			return
+		}
+	}
+	keyID, err := util.AskSSMKMSKey(ctx.SSMKMSKeyID)
+	if err != nil {
+		fmt.Printf("Error in putting config to parameter store %s: %v\n", name, err)
+		return
+	}
+	tier := ctx.SSMTier
+	if tier == "" || !util.SSMTierFits(tier, len(serializedConfig)) {
+		if tier, err = util.AskSSMTier(len(serializedConfig)); err != nil {
+			fmt.Printf("Error in putting config to parameter store %s: %v\n", name, err)
+			return
+		}
+	}
+	version, err := putConfig(client, serializedConfig, name, tier, keyID)
+	if err != nil {
+		fmt.Printf("Error in putting config to parameter store %s: %v\n", name, err)
+		return
+	}
+	ctx.SSMParameterVersion = version
+	fmt.Printf("Successfully put version %d of the config to parameter store %s.\n", version, name)
+}
+
+// readSavedConfig returns the config serialization saved.
+func readSavedConfig(ctx *runtime.Context) (string, error) {
+	if ctx.ConfigOutputPath == "" {
+		return util.ReadConfigFromJsonFileE()
+	}
+	content, err := os.ReadFile(ctx.ConfigOutputPath)
+	if err != nil {
+		return "", fmt.Errorf("error in reading config from file %s: %w", ctx.ConfigOutputPath, err)
+	}
+	return string(content), nil
+}
@@ -0,0 +1,149 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package ssm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+type mockSSMClient struct {
+	ssmiface.SSMAPI
+	values   map[string]string
+	metadata map[string]*ssm.ParameterMetadata
+	puts     []*ssm.PutParameterInput
+}
+
+func newMockSSMClient() *mockSSMClient {
+	return &mockSSMClient{
+		values: map[string]string{
+			"AmazonCloudWatch-linux":   `{"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}}`,
+			"AmazonCloudWatch-windows": `{}`,
+			"other":                    `not json`,
+		},
+		metadata: map[string]*ssm.ParameterMetadata{
+			"AmazonCloudWatch-linux":   {Name: aws.String("AmazonCloudWatch-linux"), Version: aws.Int64(3), Tier: aws.String(ssm.ParameterTierStandard), KeyId: aws.String("alias/aws/ssm")},
+			"AmazonCloudWatch-windows": {Name: aws.String("AmazonCloudWatch-windows"), Version: aws.Int64(1), Tier: aws.String(ssm.ParameterTierStandard)},
+			"other":                    {Name: aws.String("other"), Version: aws.Int64(1), Tier: aws.String(ssm.ParameterTierStandard)},
+		},
+	}
+}
+
+func (c *mockSSMClient) DescribeParameters(input *ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error) {
+	output := &ssm.DescribeParametersOutput{}
+	if metadata, ok := c.metadata[aws.StringValue(input.ParameterFilters[0].Values[0])]; ok {
+		output.Parameters = append(output.Parameters, metadata)
+	}
+	return output, nil
+}
+
+func (c *mockSSMClient) DescribeParametersPages(input *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool) error {
+	page := &ssm.DescribeParametersOutput{}
+	for name, metadata := range c.metadata {
+		if strings.HasPrefix(name, aws.StringValue(input.ParameterFilters[0].Values[0])) {
+			page.Parameters = append(page.Parameters, metadata)
+		}
+	}
+	fn(page, true)
+	return nil
+}
+
+func (c *mockSSMClient) GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+	name := aws.StringValue(input.Name)
+	return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{
+		Name:    input.Name,
+		Value:   aws.String(c.values[name]),
+		Version: c.metadata[name].Version,
+	}}, nil
+}
+
+func (c *mockSSMClient) PutParameter(input *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+	c.puts = append(c.puts, input)
+	metadata := c.metadata[aws.StringValue(input.Name)]
+	metadata.Version = aws.Int64(aws.Int64Value(metadata.Version) + 1)
+	return &ssm.PutParameterOutput{Version: metadata.Version}, nil
+}
+
+func setUpMockSSMClient(t *testing.T) *mockSSMClient {
+	// the credentials of the SDK are the first choice
+	t.Setenv("AWS_ACCESS_KEY_ID", "AK1")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SK1")
+	client := newMockSSMClient()
+	newSSMClientOrig := newSSMClient
+	t.Cleanup(func() { newSSMClient = newSSMClientOrig })
+	newSSMClient = func(region string, creds *credentials.Credentials) (ssmiface.SSMAPI, error) {
+		assert.Equal(t, "us-west-2", region)
+		return client, nil
+	}
+	return client
+}
+
+func TestFetchConfigAndWriteBack(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	client := setUpMockSSMClient(t)
+
+	ctx := &runtime.Context{IsOnPrem: true, SSMParameterName: "AmazonCloudWatch-*"}
+	testutil.Type(inputChan, "us-west-2", "", "1")
+	m, err := FetchConfig(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{"mem": map[string]interface{}{"measurement": []interface{}{"mem_used_percent"}}}}}, m)
+	assert.Equal(t, "AmazonCloudWatch-linux", ctx.SSMParameterName)
+	assert.Equal(t, int64(3), ctx.SSMParameterVersion)
+	assert.Equal(t, "alias/aws/ssm", ctx.SSMKMSKeyID)
+	assert.Equal(t, ssm.ParameterTierStandard, ctx.SSMTier)
+
+	ctx.ConfigOutputPath = filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(ctx.ConfigOutputPath, []byte(`{"agent": {"debug": true}}`), 0600))
+	testutil.Type(inputChan, "", "")
+	Processor.Process(ctx, nil)
+	require.Len(t, client.puts, 1)
+	assert.Equal(t, "AmazonCloudWatch-linux", aws.StringValue(client.puts[0].Name))
+	assert.Equal(t, `{"agent": {"debug": true}}`, aws.StringValue(client.puts[0].Value))
+	assert.Equal(t, ssm.ParameterTypeSecureString, aws.StringValue(client.puts[0].Type))
+	assert.Equal(t, "alias/aws/ssm", aws.StringValue(client.puts[0].KeyId))
+	assert.Equal(t, ssm.ParameterTierStandard, aws.StringValue(client.puts[0].Tier))
+	assert.Equal(t, int64(4), ctx.SSMParameterVersion)
+
+	// the parameter changed since it was fetched
+	client.metadata["AmazonCloudWatch-linux"].Version = aws.Int64(5)
+	testutil.Type(inputChan, "", "")
+	Processor.Process(ctx, nil)
+	assert.Len(t, client.puts, 1)
+
+	testutil.Type(inputChan, "", "1", "none")
+	Processor.Process(ctx, nil)
+	require.Len(t, client.puts, 2)
+	assert.Equal(t, ssm.ParameterTypeString, aws.StringValue(client.puts[1].Type))
+	assert.Nil(t, client.puts[1].KeyId)
+	assert.Equal(t, int64(6), ctx.SSMParameterVersion)
+}
+
+func TestFetchConfigErrors(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	setUpMockSSMClient(t)
+
+	testutil.Type(inputChan, "us-west-2", "")
+	_, err := FetchConfig(&runtime.Context{IsOnPrem: true, SSMParameterName: "missing"})
+	assert.EqualError(t, err, "the parameter missing does not exist")
+
+	testutil.Type(inputChan, "us-west-2", "")
+	_, err = FetchConfig(&runtime.Context{IsOnPrem: true, SSMParameterName: "missing-*"})
+	assert.EqualError(t, err, "there is no parameter starting with missing-")
+
+	testutil.Type(inputChan, "us-west-2", "")
+	_, err = FetchConfig(&runtime.Context{IsOnPrem: true, SSMParameterName: "other"})
+	assert.ErrorContains(t, err, "the parameter other does not hold a json config")
+}
@@ -6,13 +6,14 @@ package ssm
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
@@ -29,23 +30,34 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
+	if ctx.SSMParameterName != "" {
+		writeBack(ctx)
+		return nil
+	}
 	answer := util.Yes("Do you want to store the config in the SSM parameter store?")
 	if !answer {
-		return
+		return nil
 	}
 
-	serializedConfig := util.ReadConfigFromJsonFile()
+	serializedConfig, err := util.ReadConfigFromJsonFileE()
+	if err != nil {
+		return err
+	}
 	parameterStoreName := determineParameterStoreName(ctx)
 	region := determineRegion(ctx)
+	tier, err := util.AskSSMTier(len(serializedConfig))
+	if err != nil {
+		fmt.Printf("Error in putting config to parameter store %s: %v\n", parameterStoreName, err)
+		return nil
+	}
 
-	var err error
 	for i := 0; i <= defaultRetryCount; i++ {
 		creds := determineCreds(ctx)
-		err = sendConfigToParameterStore(serializedConfig, parameterStoreName, region, creds)
+		err = sendConfigToParameterStore(serializedConfig, parameterStoreName, region, tier, creds)
 		if err == nil {
 			fmt.Printf("Successfully put config to parameter store %s.\n", parameterStoreName)
-			return
+			return nil
 		}
 
 		if awsErr, ok := err.(awserr.Error); ok {
@@ -60,6 +72,7 @@ func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
 		break
 	}
 	fmt.Printf("Error in putting config to parameter store %s: %v\n", parameterStoreName, err)
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
@@ -67,6 +80,10 @@ func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) int
 }
 
 func determineCreds(ctx *runtime.Context) *credentials.Credentials {
+	return askCredentials(ctx, "Which AWS credential should be used to send json config to parameter store?")
+}
+
+func askCredentials(ctx *runtime.Context, question string) *credentials.Credentials {
 	accessKeys := []string{}
 	sdkAccessKey, _, sdkCreds := util.SDKCredentials()
 	sdkAccessKeyDesc := ""
@@ -75,27 +92,33 @@ func determineCreds(ctx *runtime.Context) *credentials.Credentials {
 		accessKeys = append(accessKeys, sdkAccessKeyDesc)
 	}
 
-	fileAccessKey := ""
-	fileAccessKeyDesc := ""
-	fileCredentialsProvider := credentials.NewSharedCredentials("", "AmazonCloudWatchAgent")
-	fileCreds, err := fileCredentialsProvider.Get()
-	if err == nil {
-		fileAccessKey = fileCreds.AccessKeyID
-		fileAccessKeyDesc = fileAccessKey + "(From Profile: AmazonCloudWatchAgent)"
-		accessKeys = append(accessKeys, fileAccessKeyDesc)
+	// the profiles of the shared files, including the ones with AWS SSO or a role to assume
+	profileChoices, profileByChoice := util.ProfileChoices()
+	for _, choice := range profileChoices {
+		accessKeys = append(accessKeys, "Profile: "+choice)
 	}
 
-	if len(accessKeys) > 0 {
-		accessKeys = append(accessKeys, "Other")
-
-		answer := util.Choice("Which AWS credential should be used to send json config to parameter store?", 1, accessKeys)
+	if len(accessKeys) == 0 {
+		return askCreds()
+	}
+	accessKeys = append(accessKeys, "Other")
+	for {
+		answer := util.Choice(question, 1, accessKeys)
 		if answer == sdkAccessKeyDesc {
 			return sdkCreds
-		} else if answer == fileAccessKeyDesc {
-			return fileCredentialsProvider
 		}
+		profile, ok := profileByChoice[strings.TrimPrefix(answer, "Profile: ")]
+		if !ok {
+			return askCreds()
+		}
+		creds, arn, err := util.VerifyProfile(profile)
+		if err != nil {
+			fmt.Printf("W! %v\n", err)
+			continue
+		}
+		fmt.Printf("The profile %s authenticates as %s.\n", profile, arn)
+		return creds
 	}
-	return askCreds()
 }
 
 func askCreds() *credentials.Credentials {
@@ -112,37 +135,55 @@ func determineParameterStoreName(ctx *runtime.Context) string {
 }
 
 func determineRegion(ctx *runtime.Context) string {
+	return askRegion(ctx, "Which region do you want to store the config in the parameter store?")
+}
+
+func askRegion(ctx *runtime.Context, question string) string {
 	var region string
 	if !ctx.IsOnPrem {
-		region = util.DefaultEC2Region()
+		var err error
+		if region, err = util.DefaultEC2Region(); err != nil {
+			fmt.Printf("W! %v\n", err)
+		}
 	} else {
 		region = util.SDKRegionWithProfile("AmazonCloudWatchAgent")
 	}
 	if region == "" {
 		region = "us-east-1"
 	}
-	region = util.AskWithDefault("Which region do you want to store the config in the parameter store?", region)
+	region = util.AskWithDefault(question, region)
 	return region
 }
 
-func sendConfigToParameterStore(config, parameterStoreName, region string, creds *credentials.Credentials) error {
-	awsConfig := aws.NewConfig().WithRegion(region)
-	if creds != nil {
-		awsConfig = awsConfig.WithCredentials(creds)
-	}
-	ses, err := session.NewSession(awsConfig)
+func sendConfigToParameterStore(config, parameterStoreName, region, tier string, creds *credentials.Credentials) error {
+	client, err := newSSMClient(region, creds)
 	if err != nil {
 		fmt.Printf("Error in creating session:\n %v\n", err)
 		return err
 	}
-	ssmClient := ssm.New(ses)
+	_, err = putConfig(client, config, parameterStoreName, tier, "")
+	return err
+}
+
+// putConfig stores config in the parameter, as a SecureString encrypted with keyID unless it is empty, and
+// returns the version of the parameter it became.
+func putConfig(client ssmiface.SSMAPI, config, parameterStoreName, tier, keyID string) (int64, error) {
 	input := ssm.PutParameterInput{}
 	input.SetName(parameterStoreName)
 	input.SetOverwrite(true)
 	hostName, _ := os.Hostname()
 	input.SetDescription(fmt.Sprintf("Generated by wizard on %s at %s", hostName, time.Now().Format(time.RFC1123)))
-	input.SetType("String")
+	if keyID != "" {
+		input.SetType(ssm.ParameterTypeSecureString)
+		input.SetKeyId(keyID)
+	} else {
+		input.SetType(ssm.ParameterTypeString)
+	}
+	input.SetTier(tier)
 	input.SetValue(config)
-	_, err = ssmClient.PutParameter(&input)
-	return err
+	output, err := client.PutParameter(&input)
+	if err != nil {
+		return 0, err
+	}
+	return aws.Int64Value(output.Version), nil
 }
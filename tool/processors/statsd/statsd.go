@@ -18,7 +18,7 @@ var Processor processors.Processor = &processor{}
 
 type processor struct{}
 
-func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) error {
 	yes := util.Yes("Do you want to turn on StatsD daemon?")
 	if yes {
 		collection := config.MetricsConf().Collection()
@@ -27,6 +27,7 @@ func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
 		whichMetricsCollectionInterval(collection.StatsD)
 		whichMetricsAggregationInterval(collection.StatsD)
 	}
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
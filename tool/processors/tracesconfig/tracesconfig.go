@@ -26,27 +26,28 @@ type processor struct{}
 
 const addr = "127.0.0.1"
 
-func (p *processor) Process(ctx *runtime.Context, cfg *data.Config) {
+func (p *processor) Process(ctx *runtime.Context, cfg *data.Config) error {
 	//skip if linux or windows noninteractive migration
 	if ctx.WindowsNonInteractiveMigration {
-		return
+		return nil
 	}
 
 	if !ctx.TracesOnly {
 		yes := util.Yes("Do you want the CloudWatch agent to also retrieve X-ray traces?")
 		if !yes {
-			return
+			return nil
 		}
 	}
 	newTraces, err := generateTracesConfiguration(ctx)
 	if err != nil || newTraces == nil {
-		return
+		return nil
 	}
 	cfg.TracesConfig = newTraces
 	//user can review and update their current configurations
 	if cfg.TracesConfig != nil && !ctx.NonInteractiveXrayMigration {
 		cfg.TracesConfig = updateUserConfig(cfg.TracesConfig)
 	}
+	return nil
 }
 
 func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
@@ -100,6 +101,7 @@ func chooseBufferSize(tracesConfig *config.Traces) {
 		fmt.Println("Buffer size set to 3 because input smaller than 3 or not a number")
 		bufferSize = 3
 	}
+	util.PrintBufferSizeWarning(bufferSize)
 	tracesConfig.BufferSizeMB = bufferSize
 }
 
@@ -209,6 +211,7 @@ func updateIntValueInConfig(tracesConfig *config.Traces, option int, value int)
 			tracesConfig.BufferSizeMB = 3
 			return nil
 		}
+		util.PrintBufferSizeWarning(value)
 		tracesConfig.BufferSizeMB = value
 	default:
 		return errors.New("Unknown option")
@@ -3,21 +3,48 @@
 
 package runtime
 
+import (
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
 type Context struct {
 	OsParameter               string //we will do all the validation when setting this OsParameter value, skip all the validation afterwards.
 	IsOnPrem                  bool
+	WantHostMetrics           bool
 	WantPerInstanceMetrics    bool //CPU per core
 	WantEC2TagDimensions      bool
 	WantAggregateDimensions   bool
 	MetricsCollectionInterval int //sub minute, high resolution, metric collect interval, unit as sec.
 	ConfigOutputPath          string
+	MergeConfig               bool //merge the generated config into the config at the output path instead of overwriting it
+	CheckPermissions          bool //verify the credentials can call the APIs the config requires before confirming it
+	//on premises credentials
+	AgentProfile         string //the shared profile the agent sends with, set in common-config.toml once the config is saved
+	AgentCredentialsFile string
+
+	//container insights
+	ContainerEnv         string //util.ContainerEnvECS or util.ContainerEnvEKS when the agent runs in containers
+	ContainerClusterName string
 
 	//linux migration
 	HasExistingLinuxConfig bool
 	ConfigFilePath         string
 
+	//edit existing config
+	EditConfigPath string
+	ExistingConfig map[string]interface{} //the config read from EditConfigPath, which the edited config is merged back into
+
+	//edit a config stored in the SSM parameter store
+	SSMParameterName    string //a name ending with * chooses among the parameters starting with the rest of it
+	SSMRegion           string
+	SSMCredentials      *credentials.Credentials
+	SSMParameterVersion int64 //the version fetched, to notice the parameter changed before it is written back
+	SSMTier             string
+	SSMKMSKeyID         string //the KMS key the fetched parameter is encrypted with, if it is a SecureString
+
 	//windows migration
 	WindowsNonInteractiveMigration bool
+	WindowsConfigMigrated          bool //the config of the SSM agent was migrated and saved, so the default configs are skipped
 
 	//Xray Daemon Migration
 	TracesOnly                  bool
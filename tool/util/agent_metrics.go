@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"regexp"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+// agentProcessMeasurement tells how much the agent uses and, through pid_count dropping to 0, when it
+// stops running.
+var agentProcessMeasurement = []string{"cpu_usage", "memory_rss", "pid_count"}
+
+// AskAgentInternalMetrics asks whether the agent should report metrics about itself and, when it
+// should, returns the metrics_collected fragment doing so. The agent has no switch for its internal
+// telemetry in the json config, so the fragment monitors the agent process with procstat: its CPU
+// and memory, and a pid_count that an alarm can watch for the agent stopping.
+func AskAgentInternalMetrics() (bool, map[string]interface{}, error) {
+	PrintHint("Metrics about the agent itself help to notice when it stops or uses too many resources.")
+	PrintDetail("They add %d custom metrics per host to the bill.", len(agentProcessMeasurement))
+	if !No("Do you want the agent to report metrics about itself?") {
+		return false, nil, nil
+	}
+	return true, map[string]interface{}{
+		"procstat": []map[string]interface{}{{
+			procstatExe:       "^" + regexp.QuoteMeta(paths.AgentBinaryName) + "$",
+			MapKeyMeasurement: agentProcessMeasurement,
+		}},
+	}, nil
+}
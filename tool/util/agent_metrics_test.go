@@ -0,0 +1,36 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskAgentInternalMetrics(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	enabled, fragment, err := AskAgentInternalMetrics()
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+	assert.Nil(t, fragment)
+
+	testutil.Type(inputChan, "1")
+	enabled, fragment, err = AskAgentInternalMetrics()
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	entries := fragment["procstat"].([]map[string]interface{})
+	require.Len(t, entries, 1)
+	assert.Equal(t, []string{"cpu_usage", "memory_rss", "pid_count"}, entries[0][MapKeyMeasurement])
+	exe := regexp.MustCompile(entries[0][procstatExe].(string))
+	assert.True(t, exe.MatchString(paths.AgentBinaryName))
+	assert.False(t, exe.MatchString(paths.AgentBinaryName+"-config-wizard"))
+}
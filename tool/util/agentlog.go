@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/shirou/gopsutil/v3/disk"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+// The agent rotates its own log with a fixed policy, which the json config cannot change: the file is
+// rotated at agentLogMaxSizeMB, and up to agentLogMaxBackups compressed backups are kept for at most
+// agentLogMaxAgeDays.
+const (
+	agentLogMaxSizeMB  = 100
+	agentLogMaxBackups = 5
+	agentLogMaxAgeDays = 7
+)
+
+var diskUsage = disk.Usage
+
+// AskAgentLogRotation explains how the agent rotates its own log and prompts for where the log is
+// written, returning the logfile fragment for the agent section. The rotation limits are fixed, so what
+// can be chosen is a location with room for them. A warning is printed when the file system of the
+// chosen location has less free space than the current file plus the backups can take.
+func AskAgentLogRotation() (map[string]interface{}, error) {
+	PrintHint("The agent rotates its log at %d MB and keeps %d compressed backups for up to %d days, so the log never grows unbounded.",
+		agentLogMaxSizeMB, agentLogMaxBackups, agentLogMaxAgeDays)
+	PrintDetail("These limits are built into the agent, so put the log on a volume with enough room for them.")
+	answer, err := askValidated("Where should the agent write its own log?", paths.AgentLogFilePath, func(answer string) error {
+		if !filepath.IsAbs(answer) {
+			return fmt.Errorf("the log file must be an absolute path")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if free, ok := freeSpaceMB(filepath.Dir(answer)); ok && free < agentLogMaxSizeMB*(agentLogMaxBackups+1) {
+		fmt.Printf("W! Only %d MB are free for %s, while the agent log and its backups can take up to %d MB.\n",
+			free, answer, agentLogMaxSizeMB*(agentLogMaxBackups+1))
+	}
+	return map[string]interface{}{"logfile": answer}, nil
+}
+
+// freeSpaceMB returns the free space of the file system holding dir, or of its closest existing parent
+// when dir has not been created yet.
+func freeSpaceMB(dir string) (uint64, bool) {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			usage, err := diskUsage(dir)
+			if err != nil {
+				return 0, false
+			}
+			return usage.Free / (1024 * 1024), true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return 0, false
+		}
+		dir = parent
+	}
+}
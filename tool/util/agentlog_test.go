@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskAgentLogRotation(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	original := diskUsage
+	defer func() { diskUsage = original }()
+	var checked string
+	diskUsage = func(path string) (*disk.UsageStat, error) {
+		checked = path
+		return &disk.UsageStat{Free: 50 * 1024 * 1024}, nil
+	}
+
+	testutil.Type(inputChan, "")
+	fragment, err := AskAgentLogRotation()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"logfile": paths.AgentLogFilePath}, fragment)
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "missing", "agent.log")
+	testutil.Type(inputChan, "agent.log", logFile)
+	fragment, err = AskAgentLogRotation()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"logfile": logFile}, fragment)
+	assert.Equal(t, dir, checked)
+}
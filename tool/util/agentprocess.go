@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/process"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+// agentCommandLines returns the command line of every running agent process. It is replaced in tests.
+var agentCommandLines = func() ([][]string, error) {
+	processList, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	var cmdlines [][]string
+	for _, p := range processList {
+		name, err := p.Name()
+		if err != nil || name != paths.AgentBinaryName {
+			continue
+		}
+		if cmdline, err := p.CmdlineSlice(); err == nil {
+			cmdlines = append(cmdlines, cmdline)
+		}
+	}
+	return cmdlines, nil
+}
+
+// RunningAgentConfigPath returns the json config the running agent was set up with. The agent is
+// started with -config pointing at the toml translated from the json config, so the json config is
+// looked up next to it: the amazon-cloudwatch-agent.json file, or the single json file of the
+// amazon-cloudwatch-agent.d directory. The conventional locations are used when the agent is not
+// running or was started without -config, and an error is returned when none of them exist.
+func RunningAgentConfigPath() (string, error) {
+	var configDirs []string
+	cmdlines, err := agentCommandLines()
+	if err != nil {
+		PrintDetail("Unable to list the running processes: %v", err)
+	}
+	for _, cmdline := range cmdlines {
+		if configPath := configFlagValue(cmdline); configPath != "" {
+			if strings.EqualFold(filepath.Ext(configPath), ".json") {
+				return configPath, nil
+			}
+			configDirs = append(configDirs, filepath.Dir(configPath))
+		}
+	}
+	configDirs = append(configDirs, filepath.Dir(paths.JsonConfigPath))
+	for _, dir := range configDirs {
+		if configPath, ok := jsonConfigInDir(dir); ok {
+			return configPath, nil
+		}
+	}
+	return "", errors.New("the agent is not running with a known config and no config was found in " + strings.Join(configDirs, ", "))
+}
+
+// configFlagValue returns the value of the -config or -c flag of the command line.
+func configFlagValue(cmdline []string) string {
+	for i := 1; i < len(cmdline); i++ {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(cmdline[i], "-"), "=")
+		if !strings.HasPrefix(cmdline[i], "-") || (name != "config" && name != "c") {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(cmdline) {
+			return cmdline[i+1]
+		}
+	}
+	return ""
+}
+
+func jsonConfigInDir(dir string) (string, bool) {
+	jsonPath := filepath.Join(dir, paths.JSON)
+	if info, err := os.Stat(jsonPath); err == nil && !info.IsDir() {
+		return jsonPath, true
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, paths.JsonDir))
+	if err != nil {
+		return "", false
+	}
+	var jsonFiles []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			jsonFiles = append(jsonFiles, entry.Name())
+		}
+	}
+	if len(jsonFiles) != 1 {
+		if len(jsonFiles) > 1 {
+			sort.Strings(jsonFiles)
+			fmt.Printf("W! The agent merges several json configs in %s: %s\n", filepath.Join(dir, paths.JsonDir), strings.Join(jsonFiles, ", "))
+		}
+		return "", false
+	}
+	return filepath.Join(dir, paths.JsonDir, jsonFiles[0]), true
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+func setUpAgentCommandLines(t *testing.T, cmdlines ...[]string) {
+	original := agentCommandLines
+	agentCommandLines = func() ([][]string, error) { return cmdlines, nil }
+	t.Cleanup(func() { agentCommandLines = original })
+}
+
+func TestConfigFlagValue(t *testing.T) {
+	assert.Equal(t, "/etc/agent.toml", configFlagValue([]string{"agent", "-config", "/etc/agent.toml", "-envconfig", "/etc/env.json"}))
+	assert.Equal(t, "/etc/agent.toml", configFlagValue([]string{"agent", "--config=/etc/agent.toml"}))
+	assert.Equal(t, "/etc/agent.json", configFlagValue([]string{"agent", "-c", "/etc/agent.json"}))
+	assert.Equal(t, "", configFlagValue([]string{"agent", "-envconfig", "/etc/env.json", "-otelconfig", "/etc/otel.yaml"}))
+	assert.Equal(t, "", configFlagValue([]string{"agent", "-config"}))
+}
+
+func TestRunningAgentConfigPath(t *testing.T) {
+	dir := t.TempDir()
+	toml := filepath.Join(dir, paths.TOML)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, paths.JsonDir), 0755))
+	jsonPath := filepath.Join(dir, paths.JsonDir, "file_config.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{}`), 0644))
+
+	setUpAgentCommandLines(t, []string{paths.AgentBinaryName, "-config", toml})
+	configPath, err := RunningAgentConfigPath()
+	assert.NoError(t, err)
+	assert.Equal(t, jsonPath, configPath)
+
+	setUpAgentCommandLines(t, []string{paths.AgentBinaryName, "-config", "/custom/agent.json"})
+	configPath, err = RunningAgentConfigPath()
+	assert.NoError(t, err)
+	assert.Equal(t, "/custom/agent.json", configPath)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, paths.JsonDir, "other.json"), []byte(`{}`), 0644))
+	setUpAgentCommandLines(t, []string{paths.AgentBinaryName, "-config", toml})
+	_, ok := jsonConfigInDir(dir)
+	assert.False(t, ok)
+}
+
+func TestRunningAgentConfigPathNotRunning(t *testing.T) {
+	setUpAgentCommandLines(t)
+	if _, err := os.Stat(filepath.Dir(paths.JsonConfigPath)); err == nil {
+		t.Skip("an agent is installed on this host")
+	}
+	_, err := RunningAgentConfigPath()
+	assert.Error(t, err)
+}
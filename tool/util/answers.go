@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNoAnswer is returned when the answer source has no answer left for a question that has no
+// usable default.
+var ErrNoAnswer = errors.New("the answer source has no answer left")
+
+// AnswerSource supplies the answers to the wizard questions instead of the terminal, e.g. to generate
+// configs in CI where there is no TTY. Next returns false once it has no answer for question, in which
+// case the question falls back to its default.
+type AnswerSource interface {
+	Next(question string) (string, bool)
+}
+
+var answerSource AnswerSource
+
+// SetAnswerSource makes every question read its answer from src rather than stdin. A nil src restores
+// the interactive behavior.
+func SetAnswerSource(src AnswerSource) {
+	answerSource = src
+	answerErr = nil
+}
+
+// answerErr names the first question the answer source had no answer for, see AnswerErr.
+var answerErr error
+
+// AnswerErr returns the error naming the first question the answer source had no answer for, because
+// the question has no valid default or is asked again once its answers ran out, and nil otherwise. The
+// questions asked after it are left unanswered: the choices select nothing, so Yes and No are false and
+// the loops asking for another item end, and the prompts returning an error return this one. The wizard
+// stops with it after the processor asking the question.
+func AnswerErr() error {
+	return answerErr
+}
+
+func reportMissingAnswer(question string) {
+	if answerErr == nil {
+		answerErr = fmt.Errorf("%w for the question %q", ErrNoAnswer, strings.TrimSpace(question))
+	}
+}
+
+type readerAnswerSource struct {
+	scanner *bufio.Scanner
+}
+
+// NewReaderAnswerSource answers the questions in the order they are asked with the lines of r, one
+// answer per line. An empty line selects the default of its question.
+func NewReaderAnswerSource(r io.Reader) AnswerSource {
+	return &readerAnswerSource{scanner: bufio.NewScanner(r)}
+}
+
+func (s *readerAnswerSource) Next(string) (string, bool) {
+	if !s.scanner.Scan() {
+		return "", false
+	}
+	return strings.TrimSuffix(s.scanner.Text(), "\r"), true
+}
+
+type keyedAnswerSource struct {
+	answers map[string][]string
+	asked   map[string]bool
+}
+
+// NewKeyedAnswerSource answers every question with the answers listed under its text, ignoring
+// surrounding white space. A question asked several times, e.g. once per log file, takes the next
+// answer of its list each time. A question without answers falls back to its default the first time it
+// is asked, but asking it again once its answers have run out is reported as a missing answer, since a
+// question repeated with its default, e.g. a loop asking for another log file, would never end.
+func NewKeyedAnswerSource(answers map[string][]string) AnswerSource {
+	s := &keyedAnswerSource{answers: make(map[string][]string, len(answers)), asked: make(map[string]bool)}
+	for question, list := range answers {
+		key := strings.TrimSpace(question)
+		s.answers[key] = append(s.answers[key], list...)
+	}
+	return s
+}
+
+func (s *keyedAnswerSource) Next(question string) (string, bool) {
+	key := strings.TrimSpace(question)
+	asked := s.asked[key]
+	s.asked[key] = true
+	if list := s.answers[key]; len(list) > 0 {
+		s.answers[key] = list[1:]
+		return list[0], true
+	}
+	if asked {
+		reportMissingAnswer(question)
+	}
+	return "", false
+}
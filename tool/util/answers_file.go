@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadAnswersFile reads the answers of the wizard questions from a YAML or JSON file mapping every
+// question to its answer, or to the list of its answers when it is asked several times, e.g.
+//
+//	"Do you want to monitor any log files?": yes
+//	"Log file path:": [/var/log/messages, /var/log/secure]
+//
+// Answers are what would be typed at the prompt. They are read verbatim, so yes stays "yes" and 01
+// stays "01", and an empty or null answer selects the default of its question.
+func ReadAnswersFile(filePath string) (map[string][]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var document yaml.Node
+	if err = yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("error in parsing answers file %s: %w", filePath, err)
+	}
+	answers := make(map[string][]string)
+	if len(document.Content) == 0 {
+		return answers, nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("answers file %s must map the questions to their answers", filePath)
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		question := strings.TrimSpace(key.Value)
+		if _, ok := answers[question]; ok {
+			return nil, fmt.Errorf("answers file %s line %d: the question %q is listed twice", filePath, key.Line, question)
+		}
+		list, err := answerValues(value)
+		if err != nil {
+			return nil, fmt.Errorf("answers file %s line %d: %w", filePath, value.Line, err)
+		}
+		answers[question] = list
+	}
+	return answers, nil
+}
+
+func answerValues(node *yaml.Node) ([]string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []string{scalarAnswer(node)}, nil
+	case yaml.SequenceNode:
+		list := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("every answer of a list must be a single value")
+			}
+			list = append(list, scalarAnswer(item))
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("an answer must be a single value or a list of values")
+}
+
+func scalarAnswer(node *yaml.Node) string {
+	if node.Tag == "!!null" {
+		return ""
+	}
+	return node.Value
+}
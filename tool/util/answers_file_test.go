@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAnswersFile(t *testing.T, name, content string) string {
+	filePath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0600))
+	return filePath
+}
+
+func TestReadAnswersFile(t *testing.T) {
+	answers, err := ReadAnswersFile(writeAnswersFile(t, "answers.yaml", `
+"Do you want to monitor any log files?": yes
+"Log file path:": [/var/log/messages, /var/log/secure]
+"Log Group Retention in days": 07
+"Log group name:":
+"Do you want to specify any additional log files to monitor?":
+  - yes
+  - no
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Do you want to monitor any log files?": {"yes"},
+		"Log file path:":                        {"/var/log/messages", "/var/log/secure"},
+		"Log Group Retention in days":           {"07"},
+		"Log group name:":                       {""},
+		"Do you want to specify any additional log files to monitor?": {"yes", "no"},
+	}, answers)
+
+	answers, err = ReadAnswersFile(writeAnswersFile(t, "answers.json", `{"Log file path:": ["/var/log/messages"], "Which port do you want StatsD daemon to listen to?": 8125}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Log file path:": {"/var/log/messages"},
+		"Which port do you want StatsD daemon to listen to?": {"8125"},
+	}, answers)
+
+	answers, err = ReadAnswersFile(writeAnswersFile(t, "empty.yaml", ""))
+	require.NoError(t, err)
+	assert.Empty(t, answers)
+
+	testCases := map[string]string{
+		"- yes\n- no\n":                       "must map the questions to their answers",
+		"\"Log file path:\": {a: b}\n":        "line 1: an answer must be a single value",
+		"\"Log file path:\": [[a]]\n":         "line 1: every answer of a list must be a single value",
+		"\"A:\": x\n\"B:\": y\n\" A: \": z\n": "line 3: the question \"A:\" is listed twice",
+		"\"A: b\n":                            "error in parsing answers file",
+	}
+	for content, message := range testCases {
+		_, err = ReadAnswersFile(writeAnswersFile(t, "answers.yaml", content))
+		assert.ErrorContains(t, err, message, content)
+	}
+	_, err = ReadAnswersFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAnswersFileSource(t *testing.T) {
+	answers := map[string][]string{
+		"Do you want to add another?": {"yes", "1", "no"},
+		" Log group name: ":           {"app"},
+		"Interval:":                   {"abc"},
+	}
+	SetAnswerSource(NewKeyedAnswerSource(answers))
+	defer SetAnswerSource(nil)
+
+	count := 0
+	for Yes("Do you want to add another?") {
+		count++
+	}
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "app", AskWithDefault("Log group name:", "default"))
+	assert.Equal(t, "2", Choice("Unknown question", 2, []string{"1", "2"}))
+	assert.NoError(t, AnswerErr())
+	assert.Equal(t, "", Choice("Question without default", 0, []string{"1", "2"}))
+	assert.ErrorContains(t, AnswerErr(), `"Question without default"`)
+
+	SetAnswerSource(NewKeyedAnswerSource(answers))
+	_, err := askValidated("Interval:", "", validatePositiveInteger)
+	assert.ErrorIs(t, err, ErrNoAnswer)
+	assert.ErrorContains(t, AnswerErr(), `"Interval:"`)
+}
+
+func TestChoiceByValue(t *testing.T) {
+	SetAnswerSource(NewReaderAnswerSource(strings.NewReader("YES\nmaybe\nno\nStandard\n")))
+	defer SetAnswerSource(nil)
+
+	assert.True(t, Yes("Do you want to continue?"))
+	assert.False(t, Yes("Do you want to continue?"))
+	assert.Equal(t, "standard", Choice("Log class:", 1, []string{"standard", "infrequent_access"}))
+}
@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReaderAnswerSource(t *testing.T) {
+	SetAnswerSource(NewReaderAnswerSource(strings.NewReader("2\r\n\nmy answer\nabc\n9\n")))
+	defer SetAnswerSource(nil)
+
+	assert.Equal(t, "no", Choice("Enable?", 1, []string{"yes", "no"}))
+	assert.True(t, Yes("Keep?"))
+	assert.Equal(t, "my answer", Ask("Name:"))
+	assert.Equal(t, "abc", AskWithDefault("Value:", "default"))
+	// the invalid answer is retried with the next one, then the source runs out
+	assert.Equal(t, "c", Choice("Pick one", 3, []string{"a", "b", "c"}))
+	assert.False(t, No("Exhausted?"))
+	assert.Equal(t, "default", AskWithDefault("Value:", "default"))
+	assert.Equal(t, "", Ask("Name:"))
+	answer, err := askValidated("Interval:", "60", validateNotEmpty)
+	assert.NoError(t, err)
+	assert.Equal(t, "60", answer)
+	_, err = AskSecret("Secret:")
+	assert.ErrorIs(t, err, ErrNoAnswer)
+	assert.NoError(t, AnswerErr())
+
+	assert.Equal(t, -1, ChoiceIndex("No default", 0, []string{"a"}))
+	assert.ErrorContains(t, AnswerErr(), `"No default"`)
+	_, err = askValidated("Required:", "", validateNotEmpty)
+	assert.ErrorIs(t, err, ErrNoAnswer)
+}
+
+func TestKeyedAnswerSource(t *testing.T) {
+	SetAnswerSource(NewKeyedAnswerSource(map[string][]string{
+		"Do you want to add another?": {"1", "1"},
+		" Log group name: ":           {"app"},
+	}))
+	defer SetAnswerSource(nil)
+
+	assert.Equal(t, "app", AskWithDefault("Log group name:", "default"))
+	assert.Equal(t, "2", Choice("Unknown question", 2, []string{"1", "2"}))
+
+	assert.NoError(t, AnswerErr())
+	count := 0
+	for Yes("Do you want to add another?") {
+		count++
+	}
+	assert.Equal(t, 2, count)
+	assert.ErrorIs(t, AnswerErr(), ErrNoAnswer)
+	assert.ErrorContains(t, AnswerErr(), `"Do you want to add another?"`)
+
+	// the questions asked after it are left unanswered
+	assert.False(t, No("Do you want to stop?"))
+	assert.Equal(t, "", Choice("Unknown question", 2, []string{"1", "2"}))
+	assert.Equal(t, -1, ChoiceIndex("Unknown question", 2, []string{"1", "2"}))
+	_, err := askValidated("Interval:", "60", validatePositiveInteger)
+	assert.Same(t, AnswerErr(), err)
+
+	SetAnswerSource(NewKeyedAnswerSource(map[string][]string{" Log group name: ": {"app"}}))
+	assert.NoError(t, AnswerErr(), "a new answer source starts over")
+	assert.Equal(t, "app", AskWithDefault("Log group name:", "default"))
+	assert.Equal(t, "default", AskWithDefault("Log group name:", "default"))
+	assert.ErrorContains(t, AnswerErr(), `"Log group name:"`, "a question asked again once its answers ran out is reported")
+}
+
+func validateNotEmpty(answer string) error {
+	if answer == "" {
+		return ErrNoAnswer
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+const (
+	InitSystemSystemd = "systemd"
+	InitSystemUpstart = "upstart"
+	InitSystemSysV    = "sysvinit"
+	InitSystemLaunchd = "launchd"
+	InitSystemWindows = "windows-service"
+	InitSystemUnknown = "unknown"
+
+	// windowsServiceName is the service amazon-cloudwatch-agent-ctl.ps1 installs the agent as.
+	windowsServiceName = "AmazonCloudWatchAgent"
+)
+
+// initSystemRoot is prefixed to the files InitSystem looks for, so tests can fake a host.
+var initSystemRoot = "/"
+
+// InitSystem returns the init system that manages the agent service on this host, one of the
+// InitSystem constants.
+func InitSystem() string {
+	return detectInitSystem(CurOS(), initSystemRoot)
+}
+
+func detectInitSystem(osType, root string) string {
+	switch osType {
+	case OsTypeWindows:
+		return InitSystemWindows
+	case OsTypeDarwin:
+		return InitSystemLaunchd
+	}
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join(root, path))
+		return err == nil
+	}
+	switch {
+	case exists("run/systemd/system"):
+		return InitSystemSystemd
+	case exists("sbin/initctl") && exists("etc/init"):
+		return InitSystemUpstart
+	case exists("etc/init.d"):
+		return InitSystemSysV
+	}
+	return InitSystemUnknown
+}
+
+// ApplyCommand returns the command that loads the config at configPath and restarts the agent with it:
+// amazon-cloudwatch-agent-ctl with fetch-config, which translates the config, followed on Windows by a
+// restart of the agent service. An error is returned when the agent is not installed, or when the init
+// system of the host is not one amazon-cloudwatch-agent-ctl can restart the agent with.
+func ApplyCommand(configPath string) (string, error) {
+	return applyCommand(configPath, CurOS(), InitSystem())
+}
+
+func applyCommand(configPath, osType, initSystem string) (string, error) {
+	if _, err := os.Stat(paths.AgentBinaryPath); err != nil {
+		return "", fmt.Errorf("could not locate the agent binary %s, make sure the agent is installed: %w", paths.AgentBinaryPath, err)
+	}
+	ctl := filepath.Join(filepath.Dir(paths.AgentBinaryPath), paths.AgentStartName)
+	configPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return "", err
+	}
+	switch initSystem {
+	case InitSystemWindows:
+		return fmt.Sprintf("& %s -a fetch-config -m auto -c %s; Restart-Service -Name %s",
+			powerShellQuote(ctl), powerShellQuote("file:"+configPath), windowsServiceName), nil
+	case InitSystemSystemd, InitSystemUpstart, InitSystemLaunchd:
+		return fmt.Sprintf("sudo %s -a fetch-config -m auto -s -c %s", shellQuote(ctl), shellQuote("file:"+configPath)), nil
+	}
+	return "", fmt.Errorf("amazon-cloudwatch-agent-ctl cannot restart the agent with the %s init system of this %s host", initSystem, osType)
+}
+
+// shellQuote quotes s for a POSIX shell when it contains anything but safe characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// powerShellQuote quotes s as a literal PowerShell string.
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+func TestDetectInitSystem(t *testing.T) {
+	assert.Equal(t, InitSystemWindows, detectInitSystem(OsTypeWindows, t.TempDir()))
+	assert.Equal(t, InitSystemLaunchd, detectInitSystem(OsTypeDarwin, t.TempDir()))
+
+	root := t.TempDir()
+	assert.Equal(t, InitSystemUnknown, detectInitSystem(OsTypeLinux, root))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "etc", "init.d"), 0755))
+	assert.Equal(t, InitSystemSysV, detectInitSystem(OsTypeLinux, root))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "etc", "init"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sbin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sbin", "initctl"), nil, 0755))
+	assert.Equal(t, InitSystemUpstart, detectInitSystem(OsTypeLinux, root))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "run", "systemd", "system"), 0755))
+	assert.Equal(t, InitSystemSystemd, detectInitSystem(OsTypeLinux, root))
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "file:/etc/cwagent/config.json", shellQuote("file:/etc/cwagent/config.json"))
+	assert.Equal(t, `'file:/tmp/my config'\''s.json'`, shellQuote("file:/tmp/my config's.json"))
+	assert.Equal(t, "''", shellQuote(""))
+	assert.Equal(t, `'C:\it''s'`, powerShellQuote(`C:\it's`))
+}
+
+func TestApplyCommand(t *testing.T) {
+	dir := t.TempDir()
+	original := paths.AgentBinaryPath
+	defer func() { paths.AgentBinaryPath = original }()
+	paths.AgentBinaryPath = filepath.Join(dir, "missing", paths.AgentBinaryName)
+
+	_, err := applyCommand("config.json", OsTypeLinux, InitSystemSystemd)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	paths.AgentBinaryPath = filepath.Join(dir, paths.AgentBinaryName)
+	require.NoError(t, os.WriteFile(paths.AgentBinaryPath, nil, 0755))
+	ctl := filepath.Join(dir, paths.AgentStartName)
+
+	configPath := filepath.Join(dir, "config.json")
+	command, err := applyCommand(configPath, OsTypeLinux, InitSystemSystemd)
+	assert.NoError(t, err)
+	assert.Equal(t, "sudo "+shellQuote(ctl)+" -a fetch-config -m auto -s -c "+shellQuote("file:"+configPath), command)
+
+	command, err = applyCommand(configPath, OsTypeWindows, InitSystemWindows)
+	assert.NoError(t, err)
+	assert.Equal(t, "& '"+ctl+"' -a fetch-config -m auto -c 'file:"+configPath+"'; Restart-Service -Name AmazonCloudWatchAgent", command)
+
+	_, err = applyCommand("/config.json", OsTypeLinux, InitSystemSysV)
+	assert.ErrorContains(t, err, "sysvinit")
+}
@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// BackupRetention is how many backups of the config are kept when it gets overwritten.
+var BackupRetention = 10
+
+var configBackupNameRegex = regexp.MustCompile(`^config-(\d+)\.json$`)
+
+type configBackup struct {
+	name    string
+	number  int
+	modTime time.Time
+}
+
+// listConfigBackups returns the config-N.json backups in dir, newest first.
+func listConfigBackups(dir string) ([]configBackup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var backups []configBackup
+	for _, entry := range entries {
+		match := configBackupNameRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, configBackup{name: entry.Name(), number: number, modTime: info.ModTime()})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].modTime.Equal(backups[j].modTime) {
+			return backups[i].modTime.After(backups[j].modTime)
+		}
+		return backups[i].number > backups[j].number
+	})
+	return backups, nil
+}
+
+func nextBackupNumber(dir string) (int, error) {
+	backups, err := listConfigBackups(dir)
+	if err != nil {
+		return 0, err
+	}
+	highest := 0
+	for _, backup := range backups {
+		if backup.number > highest {
+			highest = backup.number
+		}
+	}
+	return highest + 1, nil
+}
+
+// PruneConfigBackups keeps the newest keep config backups in dir and removes the older ones. It
+// returns how many backups were removed, which is 0 when there are no more than keep of them. Files
+// that are not named like a backup are left alone.
+func PruneConfigBackups(dir string, keep int) (int, error) {
+	if keep < 0 {
+		return 0, fmt.Errorf("the number of backups to keep must not be negative, got %d", keep)
+	}
+	backups, err := listConfigBackups(dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for i := keep; i < len(backups); i++ {
+		if err = os.Remove(filepath.Join(dir, backups[i].name)); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// AskBackupRetention prompts for how many backups of the config to keep.
+func AskBackupRetention() (int, error) {
+	answer, err := askValidated("How many backups of the previous config do you want to keep?", strconv.Itoa(BackupRetention), func(answer string) error {
+		keep, err := strconv.Atoi(answer)
+		if err != nil || keep < 1 {
+			return fmt.Errorf("%s is not a positive whole number", answer)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(answer)
+}
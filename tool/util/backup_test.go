@@ -0,0 +1,86 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func writeConfigBackups(t *testing.T, dir string, count int) {
+	now := time.Now()
+	for i := 1; i <= count; i++ {
+		filePath := filepath.Join(dir, fmt.Sprintf("config-%d.json", i))
+		require.NoError(t, os.WriteFile(filePath, []byte(`{}`), 0644))
+		modTime := now.Add(time.Duration(i-count) * time.Minute)
+		require.NoError(t, os.Chtimes(filePath, modTime, modTime))
+	}
+}
+
+func TestPruneConfigBackups(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigBackups(t, dir, 5)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(`keep me`), 0644))
+
+	removed, err := PruneConfigBackups(dir, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	removed, err = PruneConfigBackups(dir, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, removed)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"config-4.json", "config-5.json", "notes.txt"}, names)
+
+	_, err = PruneConfigBackups(dir, -1)
+	assert.Error(t, err)
+	_, err = PruneConfigBackups(filepath.Join(dir, "missing"), 1)
+	assert.Error(t, err)
+}
+
+func TestBackupConfigFileRetention(t *testing.T) {
+	original := BackupRetention
+	t.Cleanup(func() { BackupRetention = original })
+	BackupRetention = 3
+
+	tmpDir := t.TempDir()
+	configFilePath := filepath.Join(tmpDir, "config.json")
+	require.NoError(t, os.WriteFile(configFilePath, []byte(`{}`), 0644))
+	backupDirPath := filepath.Join(tmpDir, "backup")
+	require.NoError(t, os.MkdirAll(backupDirPath, 0755))
+	writeConfigBackups(t, backupDirPath, 4)
+
+	assert.NoError(t, backupConfigFile(configFilePath, backupDirPath))
+	backups, err := listConfigBackups(backupDirPath)
+	require.NoError(t, err)
+	require.Len(t, backups, 3)
+	assert.Equal(t, "config-5.json", backups[0].name)
+}
+
+func TestAskBackupRetention(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	keep, err := AskBackupRetention()
+	assert.NoError(t, err)
+	assert.Equal(t, BackupRetention, keep)
+
+	testutil.Type(inputChan, "0", "three", "3")
+	keep, err = AskBackupRetention()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, keep)
+}
@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "strings"
+
+// booleanFields are the fields the schema declares as booleans. Each name is a boolean wherever it
+// appears in the config.
+var booleanFields = map[string]bool{
+	"auto_removal":              true,
+	"debug":                     true,
+	"disable_metric_extraction": true,
+	"drop_device":               true,
+	"insecure":                  true,
+	"local_mode":                true,
+	"metric_declaration_dedup":  true,
+	"omit_hostname":             true,
+	"publish_multi_logs":        true,
+	"registry_ssl_enabled":      true,
+	"totalcpu":                  true,
+}
+
+// booleanStrings are the spellings of booleans accepted in place of json booleans, compared ignoring case.
+var booleanStrings = map[string]bool{
+	"true":  true,
+	"yes":   true,
+	"on":    true,
+	"1":     true,
+	"false": false,
+	"no":    false,
+	"off":   false,
+	"0":     false,
+}
+
+// NormalizeBooleans replaces the strings standing for booleans, such as "true" or "No", with json
+// booleans in the boolean fields of an imported config, which the schema would otherwise reject, and
+// returns how many were replaced. Only fields the schema declares as booleans are touched, so strings
+// elsewhere are kept as they are, and strings that do not stand for a boolean are left for the
+// validation to report.
+func NormalizeBooleans(m map[string]interface{}) int {
+	converted := 0
+	for key, value := range m {
+		if s, ok := value.(string); ok && booleanFields[key] {
+			if b, ok := booleanStrings[strings.ToLower(strings.TrimSpace(s))]; ok {
+				m[key] = b
+				converted++
+			}
+			continue
+		}
+		converted += normalizeBooleanValue(value)
+	}
+	return converted
+}
+
+func normalizeBooleanValue(value interface{}) int {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return NormalizeBooleans(v)
+	case []interface{}:
+		converted := 0
+		for _, item := range v {
+			converted += normalizeBooleanValue(item)
+		}
+		return converted
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBooleans(t *testing.T) {
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"agent": {"debug": "TRUE", "omit_hostname": " no ", "run_as_user": "true"},
+		"metrics": {
+			"metrics_collected": {
+				"cpu": {"totalcpu": "1", "measurement": ["usage_idle"]},
+				"disk": {"drop_device": false, "resources": ["true"]}
+			}
+		},
+		"logs": {
+			"logs_collected": {
+				"files": {
+					"collect_list": [
+						{"file_path": "/var/log/app.log", "auto_removal": "Off", "publish_multi_logs": "maybe"}
+					]
+				}
+			},
+			"metrics_collected": {"emf": {}, "kubernetes": {"disable_metric_extraction": "false"}}
+		}
+	}`), &m))
+
+	assert.Equal(t, 5, NormalizeBooleans(m))
+	agent := getMap(m, "agent")
+	assert.Equal(t, true, agent["debug"])
+	assert.Equal(t, false, agent["omit_hostname"])
+	assert.Equal(t, "true", agent["run_as_user"])
+	assert.Equal(t, true, getMap(m, "metrics", "metrics_collected", "cpu")["totalcpu"])
+	disk := getMap(m, "metrics", "metrics_collected", "disk")
+	assert.Equal(t, false, disk["drop_device"])
+	assert.Equal(t, []interface{}{"true"}, disk["resources"])
+	file := getMapList(getMap(m, "logs", "logs_collected", "files"), "collect_list")[0]
+	assert.Equal(t, false, file["auto_removal"])
+	assert.Equal(t, "maybe", file["publish_multi_logs"])
+	assert.Equal(t, false, getMap(m, "logs", "metrics_collected", "kubernetes")["disable_metric_extraction"])
+
+	assert.Zero(t, NormalizeBooleans(m))
+}
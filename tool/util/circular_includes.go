@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DetectCircularIncludes looks for a cycle among the *.json fragments of a config directory, as
+// validated by ValidateConfigDir, and returns the files of the first one found, starting and ending
+// with the same file, or nil when there is none. The agent has no include directive, each fragment is
+// appended to the config on its own, so the only way fragments reference each other is through
+// symbolic links, which are followed from fragment to fragment. A cycle of links makes the fragments
+// involved unreadable. An error is returned when dir or a link cannot be read.
+func DetectCircularIncludes(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	checked := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink == 0 || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		cycle, err := followIncludes(filepath.Join(dir, entry.Name()), checked)
+		if err != nil || cycle != nil {
+			return cycle, err
+		}
+	}
+	return nil, nil
+}
+
+// followIncludes follows the chain of symbolic links starting at filePath. Files in checked are known
+// not to lead to a cycle, and the files of the chain are added to it once it ends.
+func followIncludes(filePath string, checked map[string]bool) ([]string, error) {
+	var chain []string
+	position := make(map[string]int)
+	current := filepath.Clean(filePath)
+	for !checked[current] {
+		if i, ok := position[current]; ok {
+			return append(chain[i:], current), nil
+		}
+		info, err := os.Lstat(current)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			// a missing target is reported when the fragment is read, not as a cycle
+			break
+		}
+		position[current] = len(chain)
+		chain = append(chain, current)
+		target, err := os.Readlink(current)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(current), target)
+		}
+		current = filepath.Clean(target)
+	}
+	for _, file := range chain {
+		checked[file] = true
+	}
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectCircularIncludes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "base.json"), []byte(`{"agent":{}}`), 0600))
+	if err := os.Symlink("base.json", filepath.Join(dir, "alias.json")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+	require.NoError(t, os.Symlink("missing.json", filepath.Join(dir, "dangling.json")))
+
+	cycle, err := DetectCircularIncludes(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, cycle)
+
+	require.NoError(t, os.Symlink("b.json", filepath.Join(dir, "a.json")))
+	require.NoError(t, os.Symlink("c.json", filepath.Join(dir, "b.json")))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "a.json"), filepath.Join(dir, "c.json")))
+	require.NoError(t, os.Symlink("a.json", filepath.Join(dir, "0-entry.json")))
+
+	cycle, err = DetectCircularIncludes(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.json"),
+		filepath.Join(dir, "b.json"),
+		filepath.Join(dir, "c.json"),
+		filepath.Join(dir, "a.json"),
+	}, cycle)
+
+	_, err = DetectCircularIncludes(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+)
+
+// collectdTypesDBPaths are where the collectd packages of each platform install types.db, in the order
+// they are looked up. The first one is the translator default.
+var collectdTypesDBPaths = map[string][]string{
+	OsTypeLinux:  {"/usr/share/collectd/types.db", "/opt/collectd/share/collectd/types.db"},
+	OsTypeDarwin: {"/opt/homebrew/share/collectd/types.db", "/usr/local/share/collectd/types.db"},
+}
+
+// DefaultCollectdTypesDB returns the types.db location for osType, preferring a candidate that exists on
+// this host.
+func DefaultCollectdTypesDB(osType string) string {
+	candidates := collectdTypesDBPaths[osType]
+	if len(candidates) == 0 {
+		candidates = collectdTypesDBPaths[OsTypeLinux]
+	}
+	for _, candidate := range candidates {
+		if checkReadableFile(candidate) == nil {
+			return candidate
+		}
+	}
+	return candidates[0]
+}
+
+// AskCollectdTypesDB prompts for the collectd types.db file, which the agent needs to parse the values
+// collectd sends, and returns the path for collectd_typesdb. A path that is not a readable file is asked
+// again unless the user confirms it, e.g. because collectd is installed later.
+func AskCollectdTypesDB(defaultPath string) (string, error) {
+	for {
+		answer, err := askValidated("Which types.db file does CollectD use?", defaultPath, func(answer string) error {
+			if answer == "" {
+				return fmt.Errorf("a path is required")
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		path := answer
+		if err = checkReadableFile(path); err == nil {
+			return path, nil
+		}
+		fmt.Printf("W! %s cannot be read: %v. The agent cannot parse CollectD metrics without it.\n", path, err)
+		if No(fmt.Sprintf("Do you want to use %s anyway?", path)) {
+			return path, nil
+		}
+	}
+}
+
+func checkReadableFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	return nil
+}
+
+const (
+	CollectdSecurityNone    = "none"
+	CollectdSecuritySign    = "sign"
+	CollectdSecurityEncrypt = "encrypt"
+
+	// defaultCollectdAuthFile is the auth file the translator uses when collectd_auth_file is not set.
+	defaultCollectdAuthFile = "/etc/collectd/auth_file"
+)
+
+// AskCollectdSecurity prompts for the security level of the collectd network plugin and, for sign and
+// encrypt, the auth file with the user names and passwords collectd signs or encrypts with. The level
+// has to match the SecurityLevel collectd sends with, otherwise the agent drops the metrics without an
+// error. The level is always returned because the translator defaults to encrypt when it is not set.
+func AskCollectdSecurity() (map[string]interface{}, error) {
+	PrintHint("The security level must match the SecurityLevel of the network plugin of CollectD, metrics sent with another level are dropped.")
+	level := Choice("Which security level does CollectD send metrics with?", 1, []string{CollectdSecurityNone, CollectdSecuritySign, CollectdSecurityEncrypt})
+	switch level {
+	case CollectdSecurityNone:
+		return map[string]interface{}{"collectd_security_level": level}, nil
+	case CollectdSecuritySign, CollectdSecurityEncrypt:
+	default:
+		return nil, fmt.Errorf("%s is not a valid CollectD security level", level)
+	}
+	authFile, err := askValidated("Which auth file holds the CollectD user names and passwords?", defaultCollectdAuthFile, checkReadableFile)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"collectd_security_level": level,
+		"collectd_auth_file":      authFile,
+	}, nil
+}
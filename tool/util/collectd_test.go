@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestDefaultCollectdTypesDB(t *testing.T) {
+	original := collectdTypesDBPaths
+	defer func() { collectdTypesDBPaths = original }()
+
+	dir := t.TempDir()
+	installed := filepath.Join(dir, "installed", "types.db")
+	require.NoError(t, os.MkdirAll(filepath.Dir(installed), 0755))
+	require.NoError(t, os.WriteFile(installed, nil, 0644))
+	collectdTypesDBPaths = map[string][]string{
+		OsTypeLinux:  {filepath.Join(dir, "missing", "types.db")},
+		OsTypeDarwin: {filepath.Join(dir, "missing", "types.db"), installed},
+	}
+
+	assert.Equal(t, installed, DefaultCollectdTypesDB(OsTypeDarwin))
+	assert.Equal(t, filepath.Join(dir, "missing", "types.db"), DefaultCollectdTypesDB(OsTypeLinux))
+	assert.Equal(t, filepath.Join(dir, "missing", "types.db"), DefaultCollectdTypesDB(""))
+}
+
+func TestAskCollectdTypesDB(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	dir := t.TempDir()
+	typesDB := filepath.Join(dir, "types.db")
+	require.NoError(t, os.WriteFile(typesDB, nil, 0644))
+	missing := filepath.Join(dir, "missing.db")
+
+	testutil.Type(inputChan, "")
+	path, err := AskCollectdTypesDB(typesDB)
+	assert.NoError(t, err)
+	assert.Equal(t, typesDB, path)
+
+	testutil.Type(inputChan, "", "", dir, "2", typesDB)
+	path, err = AskCollectdTypesDB(missing)
+	assert.NoError(t, err)
+	assert.Equal(t, typesDB, path)
+
+	testutil.Type(inputChan, missing, "1")
+	path, err = AskCollectdTypesDB(typesDB)
+	assert.NoError(t, err)
+	assert.Equal(t, missing, path)
+}
+
+func TestAskCollectdSecurity(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	dir := t.TempDir()
+	authFile := filepath.Join(dir, "auth_file")
+	require.NoError(t, os.WriteFile(authFile, []byte("user: secret\n"), 0600))
+
+	testutil.Type(inputChan, "")
+	security, err := AskCollectdSecurity()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"collectd_security_level": "none"}, security)
+
+	testutil.Type(inputChan, "3", filepath.Join(dir, "missing"), dir, authFile)
+	security, err = AskCollectdSecurity()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"collectd_security_level": "encrypt", "collectd_auth_file": authFile}, security)
+
+	testutil.Type(inputChan, "2", authFile)
+	security, err = AskCollectdSecurity()
+	assert.NoError(t, err)
+	assert.Equal(t, "sign", security["collectd_security_level"])
+}
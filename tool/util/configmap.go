@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "strings"
+
+// Helpers to read the json config as either parsed from a file ([]interface{} lists) or as built by the
+// wizard's ToMap converters (typed lists such as []map[string]interface{}).
+
+// getMap follows keys from m and returns the nested map, or nil if any level is missing.
+func getMap(m map[string]interface{}, keys ...string) map[string]interface{} {
+	current := m
+	for _, key := range keys {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// getMapList returns the list of objects stored under key.
+func getMapList(m map[string]interface{}, key string) []map[string]interface{} {
+	switch list := m[key].(type) {
+	case []map[string]interface{}:
+		return list
+	case []interface{}:
+		result := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if entry, ok := item.(map[string]interface{}); ok {
+				result = append(result, entry)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// toNumber converts a json number or an int set by the wizard into a float64.
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// getString returns the string stored under key, or "" when it is missing or not a string.
+func getString(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// getStringList returns the strings stored under key, skipping entries that are not strings.
+func getStringList(m map[string]interface{}, key string) []string {
+	switch list := m[key].(type) {
+	case []string:
+		return list
+	case []interface{}:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// ConfigMap follows keys from m and returns the nested object, or nil if any level is missing.
+func ConfigMap(m map[string]interface{}, keys ...string) map[string]interface{} {
+	return getMap(m, keys...)
+}
+
+// ConfigMapList returns the objects of the list stored under key, skipping entries that are not objects.
+func ConfigMapList(m map[string]interface{}, key string) []map[string]interface{} {
+	return getMapList(m, key)
+}
+
+// ConfigString returns the string stored under key, or "" when it is missing or not a string.
+func ConfigString(m map[string]interface{}, key string) string {
+	return getString(m, key)
+}
+
+// ConfigStringList returns the strings of the list stored under key, skipping entries that are not
+// strings.
+func ConfigStringList(m map[string]interface{}, key string) []string {
+	return getStringList(m, key)
+}
+
+// ConfigInt returns the number stored under key as an int, or 0 when it is missing or not a number.
+func ConfigInt(m map[string]interface{}, key string) int {
+	n, _ := toNumber(m[key])
+	return int(n)
+}
+
+// ConfigIntList returns the numbers of the list stored under key as ints, skipping entries that are not
+// numbers.
+func ConfigIntList(m map[string]interface{}, key string) []int {
+	list, ok := m[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	var result []int
+	for _, item := range list {
+		if n, ok := toNumber(item); ok {
+			result = append(result, int(n))
+		}
+	}
+	return result
+}
+
+// ConfigBool returns the boolean stored under key, accepting the boolean strings NormalizeBooleans
+// converts, or false when it is missing.
+func ConfigBool(m map[string]interface{}, key string) bool {
+	switch v := m[key].(type) {
+	case bool:
+		return v
+	case string:
+		b, ok := booleanStrings[strings.ToLower(strings.TrimSpace(v))]
+		return ok && b
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValues(t *testing.T) {
+	m := map[string]interface{}{
+		"interval":  float64(60),
+		"count":     3,
+		"enabled":   "Yes",
+		"disabled":  false,
+		"name":      "app",
+		"resources": []interface{}{"/", 1, "/data"},
+		"event_ids": []interface{}{float64(4624), "4625", 4634},
+		"agent":     map[string]interface{}{"debug": true},
+	}
+	assert.Equal(t, 60, ConfigInt(m, "interval"))
+	assert.Equal(t, 3, ConfigInt(m, "count"))
+	assert.Equal(t, 0, ConfigInt(m, "name"))
+	assert.True(t, ConfigBool(m, "enabled"))
+	assert.False(t, ConfigBool(m, "disabled"))
+	assert.False(t, ConfigBool(m, "name"))
+	assert.Equal(t, "app", ConfigString(m, "name"))
+	assert.Equal(t, []string{"/", "/data"}, ConfigStringList(m, "resources"))
+	assert.Equal(t, []int{4624, 4634}, ConfigIntList(m, "event_ids"))
+	assert.Nil(t, ConfigIntList(m, "name"))
+	assert.Equal(t, map[string]interface{}{"debug": true}, ConfigMap(m, "agent"))
+	assert.Nil(t, ConfigMap(m, "agent", "debug"))
+	assert.Nil(t, ConfigMapList(m, "agent"))
+}
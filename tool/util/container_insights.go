@@ -0,0 +1,176 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// The container environments Container Insights supports.
+const (
+	ContainerEnvECS = "ECS"
+	ContainerEnvEKS = "EKS"
+)
+
+const (
+	ecsMetadataEndpointEnv   = "ECS_CONTAINER_METADATA_URI_V4"
+	ecsMetadataEndpointV3Env = "ECS_CONTAINER_METADATA_URI"
+	// k8sServiceHostEnv is set by Kubernetes in every container of a pod.
+	k8sServiceHostEnv = "KUBERNETES_SERVICE_HOST"
+	// k8sNamespaceEnv is set from the pod namespace by the agent manifests.
+	k8sNamespaceEnv       = "K8S_NAMESPACE"
+	k8sClusterNameEnv     = "CLUSTER_NAME"
+	metadataClientTimeout = time.Second
+	// maxDimensionValueLength is the longest dimension value CloudWatch accepts.
+	maxDimensionValueLength = 1024
+	// maxClusterNameLength is the longest cluster_name the kubernetes section accepts.
+	maxClusterNameLength = 512
+)
+
+// ContainerMetricsIntervals are the intervals the Container Insights metrics can be collected at.
+var ContainerMetricsIntervals = []string{"60s", "30s", "15s"}
+
+// containerInsightsDimensions are the dimensions Container Insights reports the services of each
+// environment with, in the order they are asked.
+var containerInsightsDimensions = map[string][]string{
+	ContainerEnvECS: {"ClusterName", "ServiceName"},
+	ContainerEnvEKS: {"ClusterName", "Namespace", "Service"},
+}
+
+var (
+	// containerMetadata returns the dimensions known from the environment, so tests can fake it.
+	containerMetadata     = detectContainerMetadata
+	k8sNamespaceFile      = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	k8sTokenFile          = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	metadataRequestClient = &http.Client{Timeout: metadataClientTimeout}
+)
+
+// AskContainerInsightsDimensions prompts for the service level dimensions Container Insights needs in
+// env, ECS or EKS, and returns them by dimension name. Answers default to what the environment tells:
+// the cluster and service of the task from the ECS task metadata, or the namespace of the pod and the
+// cluster name of the agent manifest on EKS. Each dimension is required.
+func AskContainerInsightsDimensions(env string) (map[string]string, error) {
+	names, ok := containerInsightsDimensions[env]
+	if !ok {
+		return nil, fmt.Errorf("%q is not a Container Insights environment, use %s or %s", env, ContainerEnvECS, ContainerEnvEKS)
+	}
+	detected := containerMetadata(env)
+	dimensions := make(map[string]string, len(names))
+	for _, name := range names {
+		value, err := askValidated(fmt.Sprintf("What is the %s dimension of the %s metrics?", name, env), detected[name], validateDimensionValue)
+		if err != nil {
+			return nil, err
+		}
+		dimensions[name] = strings.TrimSpace(value)
+	}
+	return dimensions, nil
+}
+
+// DetectContainerEnv returns the container environment the wizard runs in, ECS when the ECS task
+// metadata endpoint is set, EKS when the pod has a Kubernetes service host or service account token,
+// and "" outside of containers.
+func DetectContainerEnv() string {
+	if os.Getenv(ecsMetadataEndpointEnv) != "" || os.Getenv(ecsMetadataEndpointV3Env) != "" {
+		return ContainerEnvECS
+	}
+	if os.Getenv(k8sServiceHostEnv) != "" {
+		return ContainerEnvEKS
+	}
+	if _, err := os.Stat(k8sTokenFile); err == nil {
+		return ContainerEnvEKS
+	}
+	return ""
+}
+
+// AskContainerClusterName prompts for the name of the ECS or Kubernetes cluster of env, defaulting to
+// the cluster the environment tells.
+func AskContainerClusterName(env string) (string, error) {
+	name, err := askValidated(fmt.Sprintf("What is the name of the %s cluster?", env), containerMetadata(env)["ClusterName"], func(answer string) error {
+		if strings.TrimSpace(answer) == "" {
+			return fmt.Errorf("the cluster name cannot be empty")
+		}
+		if len(answer) > maxClusterNameLength {
+			return fmt.Errorf("the cluster name must be at most %d characters", maxClusterNameLength)
+		}
+		return nil
+	})
+	return strings.TrimSpace(name), err
+}
+
+// ContainerInsightsLogGroup returns the log group Container Insights uses for the kind of logs of a
+// cluster, e.g. performance for the metrics or application for the logs of the containers.
+func ContainerInsightsLogGroup(clusterName, kind string) string {
+	return fmt.Sprintf("/aws/containerinsights/%s/%s", clusterName, kind)
+}
+
+func validateDimensionValue(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("the dimension value cannot be empty")
+	}
+	if len(value) > maxDimensionValueLength {
+		return fmt.Errorf("the dimension value must be at most %d characters", maxDimensionValueLength)
+	}
+	return nil
+}
+
+// detectContainerMetadata reads the dimensions of env that the environment of the wizard knows. Any
+// that cannot be read are left out.
+func detectContainerMetadata(env string) map[string]string {
+	detected := make(map[string]string)
+	switch env {
+	case ContainerEnvECS:
+		endpoint, ok := os.LookupEnv(ecsMetadataEndpointEnv)
+		if !ok {
+			break
+		}
+		task, err := fetchECSTaskMetadata(endpoint + "/task")
+		if err != nil {
+			PrintDetail("%v", err)
+			break
+		}
+		// the cluster is an ARN of the form arn:aws:ecs:region:account:cluster/name
+		detected["ClusterName"] = task.Cluster[strings.LastIndex(task.Cluster, "/")+1:]
+		detected["ServiceName"] = task.ServiceName
+	case ContainerEnvEKS:
+		detected["ClusterName"] = os.Getenv(k8sClusterNameEnv)
+		detected["Namespace"] = os.Getenv(k8sNamespaceEnv)
+		if detected["Namespace"] == "" {
+			if namespace, err := os.ReadFile(k8sNamespaceFile); err == nil {
+				detected["Namespace"] = strings.TrimSpace(string(namespace))
+			}
+		}
+	}
+	return detected
+}
+
+type ecsTaskMetadata struct {
+	Cluster     string `json:"Cluster"`
+	ServiceName string `json:"ServiceName"`
+}
+
+func fetchECSTaskMetadata(endpoint string) (*ecsTaskMetadata, error) {
+	resp, err := metadataRequestClient.Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the ECS task metadata: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read the ECS task metadata: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the ECS task metadata: %w", err)
+	}
+	var task ecsTaskMetadata
+	if err = json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("unable to parse the ECS task metadata: %w", err)
+	}
+	return &task, nil
+}
@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskContainerInsightsDimensions(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	original := containerMetadata
+	defer func() { containerMetadata = original }()
+	containerMetadata = func(env string) map[string]string {
+		if env == ContainerEnvECS {
+			return map[string]string{"ClusterName": "prod"}
+		}
+		return map[string]string{}
+	}
+
+	testutil.Type(inputChan, "", " ", "web")
+	dimensions, err := AskContainerInsightsDimensions(ContainerEnvECS)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ClusterName": "prod", "ServiceName": "web"}, dimensions)
+
+	testutil.Type(inputChan, "", "eks-prod", "default", " api ")
+	dimensions, err = AskContainerInsightsDimensions(ContainerEnvEKS)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ClusterName": "eks-prod", "Namespace": "default", "Service": "api"}, dimensions)
+
+	_, err = AskContainerInsightsDimensions("EC2")
+	assert.Error(t, err)
+}
+
+func TestDetectContainerMetadataECS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v4/task", r.URL.Path)
+		_, _ = w.Write([]byte(`{"Cluster":"arn:aws:ecs:us-east-1:123456789012:cluster/prod","ServiceName":"web","Family":"web-task"}`))
+	}))
+	defer server.Close()
+	t.Setenv(ecsMetadataEndpointEnv, server.URL+"/v4")
+
+	assert.Equal(t, map[string]string{"ClusterName": "prod", "ServiceName": "web"}, detectContainerMetadata(ContainerEnvECS))
+}
+
+func TestDetectContainerMetadataEKS(t *testing.T) {
+	originalFile := k8sNamespaceFile
+	defer func() { k8sNamespaceFile = originalFile }()
+	k8sNamespaceFile = filepath.Join(t.TempDir(), "namespace")
+	require.NoError(t, os.WriteFile(k8sNamespaceFile, []byte("amazon-cloudwatch\n"), 0600))
+	t.Setenv(k8sClusterNameEnv, "eks-prod")
+	t.Setenv(k8sNamespaceEnv, "")
+
+	assert.Equal(t, map[string]string{"ClusterName": "eks-prod", "Namespace": "amazon-cloudwatch"}, detectContainerMetadata(ContainerEnvEKS))
+
+	t.Setenv(k8sNamespaceEnv, "monitoring")
+	assert.Equal(t, "monitoring", detectContainerMetadata(ContainerEnvEKS)["Namespace"])
+}
+
+func TestDetectContainerEnv(t *testing.T) {
+	originalFile := k8sTokenFile
+	defer func() { k8sTokenFile = originalFile }()
+	k8sTokenFile = filepath.Join(t.TempDir(), "token")
+	t.Setenv(ecsMetadataEndpointEnv, "")
+	t.Setenv(ecsMetadataEndpointV3Env, "")
+	t.Setenv(k8sServiceHostEnv, "")
+	assert.Empty(t, DetectContainerEnv())
+
+	require.NoError(t, os.WriteFile(k8sTokenFile, []byte("token"), 0600))
+	assert.Equal(t, ContainerEnvEKS, DetectContainerEnv())
+
+	t.Setenv(ecsMetadataEndpointV3Env, "http://169.254.170.2/v3")
+	assert.Equal(t, ContainerEnvECS, DetectContainerEnv())
+}
+
+func TestAskContainerClusterName(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	original := containerMetadata
+	defer func() { containerMetadata = original }()
+	containerMetadata = func(env string) map[string]string {
+		return map[string]string{"ClusterName": "prod"}
+	}
+
+	testutil.Type(inputChan, "")
+	name, err := AskContainerClusterName(ContainerEnvECS)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", name)
+
+	containerMetadata = func(env string) map[string]string { return map[string]string{} }
+	testutil.Type(inputChan, "", strings.Repeat("a", maxClusterNameLength+1), " eks-prod ")
+	name, err = AskContainerClusterName(ContainerEnvEKS)
+	assert.NoError(t, err)
+	assert.Equal(t, "eks-prod", name)
+
+	assert.Equal(t, "/aws/containerinsights/eks-prod/performance", ContainerInsightsLogGroup(name, "performance"))
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+const (
+	secondsPerMonth = 30 * 24 * 60 * 60
+	// assumedLogGBPerFile is the monthly volume assumed for every collected log file or event log,
+	// since the config says nothing about how much is written.
+	assumedLogGBPerFile = 1.0
+)
+
+// pricingData holds approximate on demand CloudWatch prices in USD per region, for the first tier of
+// custom metrics. The "default" entry is used for regions without their own entry.
+//
+//go:embed pricing.json
+var pricingData []byte
+
+type regionPricing struct {
+	MetricMonth                    float64 `json:"metric_month"`
+	LogIngestionGB                 float64 `json:"log_ingestion_gb"`
+	LogIngestionGBInfrequentAccess float64 `json:"log_ingestion_gb_infrequent_access"`
+	ThousandAPIRequests            float64 `json:"thousand_api_requests"`
+}
+
+// CostEstimate is a rough monthly breakdown in USD of what a config costs for one host. It is an
+// estimate: Assumptions lists what it is based on.
+type CostEstimate struct {
+	Region string
+
+	MetricCount int
+	Metrics     float64
+
+	LogSources   int
+	LogGB        float64
+	LogIngestion float64
+
+	APIRequests     float64
+	APIRequestsCost float64
+
+	Total       float64
+	Assumptions []string
+}
+
+// EstimateMonthlyCost estimates the monthly cost of the custom metrics, the log ingestion and the
+// PutMetricData calls of the config for a single host in region, from the summary of the metrics and
+// bundled prices. Free tier, storage, dashboards and alarms are not included.
+func EstimateMonthlyCost(m map[string]interface{}, region string) (CostEstimate, error) {
+	if _, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); !ok {
+		return CostEstimate{}, fmt.Errorf("%s is not a known region", region)
+	}
+	var prices map[string]regionPricing
+	if err := json.Unmarshal(pricingData, &prices); err != nil {
+		return CostEstimate{}, fmt.Errorf("invalid bundled pricing: %w", err)
+	}
+	estimate := CostEstimate{
+		Region: region,
+		Assumptions: []string{
+			"prices are approximate on demand prices in USD for the first tier, without free tier",
+			"a month has 30 days and the host runs all month",
+		},
+	}
+	pricing, ok := prices[region]
+	if !ok {
+		pricing = prices["default"]
+		estimate.Assumptions = append(estimate.Assumptions, fmt.Sprintf("no bundled prices for %s, us-east-1 prices are used", region))
+	}
+
+	aggregations := 0
+	switch list := getMap(m, "metrics")["aggregation_dimensions"].(type) {
+	case []interface{}:
+		aggregations = len(list)
+	case [][]string:
+		aggregations = len(list)
+	}
+	var wildcards []string
+	for _, summary := range SummarizeMetrics(m) {
+		estimate.MetricCount += summary.Metrics() + summary.Measurements*aggregations
+		if summary.Wildcard {
+			wildcards = append(wildcards, summary.Plugin)
+		}
+	}
+	if len(wildcards) > 0 {
+		sort.Strings(wildcards)
+		estimate.Assumptions = append(estimate.Assumptions, fmt.Sprintf("the \"*\" resources of %s match a single instance", strings.Join(wildcards, ", ")))
+	}
+	if aggregations > 0 {
+		estimate.Assumptions = append(estimate.Assumptions, "every aggregation dimension set adds one metric per measurement")
+	}
+	estimate.Metrics = float64(estimate.MetricCount) * pricing.MetricMonth
+
+	logGroupClasses := logSourceClasses(m)
+	for _, class := range logGroupClasses {
+		estimate.LogSources++
+		estimate.LogGB += assumedLogGBPerFile
+		if class == InfrequentAccessLogGroupClass {
+			estimate.LogIngestion += assumedLogGBPerFile * pricing.LogIngestionGBInfrequentAccess
+		} else {
+			estimate.LogIngestion += assumedLogGBPerFile * pricing.LogIngestionGB
+		}
+	}
+	if estimate.LogSources > 0 {
+		estimate.Assumptions = append(estimate.Assumptions, fmt.Sprintf("every log file or event log ingests %.0f GB a month", assumedLogGBPerFile))
+	}
+
+	rate, _ := EstimatePutMetricDataRate(m)
+	estimate.APIRequests = rate * secondsPerMonth
+	estimate.APIRequestsCost = estimate.APIRequests / 1000 * pricing.ThousandAPIRequests
+
+	estimate.Total = estimate.Metrics + estimate.LogIngestion + estimate.APIRequestsCost
+	return estimate, nil
+}
+
+// logSourceClasses returns the log group class of every collected log file and event log.
+func logSourceClasses(m map[string]interface{}) []string {
+	var classes []string
+	for _, section := range []string{"files", "windows_events"} {
+		for _, entry := range getMapList(getMap(m, "logs", "logs_collected", section), collectListKey) {
+			classes = append(classes, getString(entry, "log_group_class"))
+		}
+	}
+	return classes
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateMonthlyCost(t *testing.T) {
+	m := map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"aggregation_dimensions": []interface{}{[]interface{}{"InstanceId"}},
+			"metrics_collected": map[string]interface{}{
+				"mem":  map[string]interface{}{MapKeyMeasurement: []interface{}{"mem_used_percent"}},
+				"disk": map[string]interface{}{MapKeyMeasurement: []interface{}{"used_percent"}, MapKeyInstances: []interface{}{"*"}},
+			},
+		},
+		"logs": map[string]interface{}{
+			"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{
+					"collect_list": []interface{}{
+						map[string]interface{}{"file_path": "/var/log/messages"},
+						map[string]interface{}{"file_path": "/var/log/app.log", "log_group_class": InfrequentAccessLogGroupClass},
+					},
+				},
+			},
+		},
+	}
+	estimate, err := EstimateMonthlyCost(m, "us-east-1")
+	require.NoError(t, err)
+	assert.Equal(t, 4, estimate.MetricCount)
+	assert.InDelta(t, 1.2, estimate.Metrics, 1e-9)
+	assert.Equal(t, 2, estimate.LogSources)
+	assert.InDelta(t, 0.75, estimate.LogIngestion, 1e-9)
+	assert.InDelta(t, float64(secondsPerMonth)/60, estimate.APIRequests, 1e-6)
+	assert.InDelta(t, estimate.Metrics+estimate.LogIngestion+estimate.APIRequestsCost, estimate.Total, 1e-9)
+	assert.Contains(t, estimate.Assumptions, `the "*" resources of disk match a single instance`)
+
+	estimate, err = EstimateMonthlyCost(m, "eu-north-1")
+	require.NoError(t, err)
+	assert.Contains(t, estimate.Assumptions, "no bundled prices for eu-north-1, us-east-1 prices are used")
+
+	_, err = EstimateMonthlyCost(m, "moon-1")
+	assert.Error(t, err)
+}
+
+func TestPricingData(t *testing.T) {
+	estimate, err := EstimateMonthlyCost(map[string]interface{}{}, "us-east-1")
+	require.NoError(t, err)
+	assert.Zero(t, estimate.Total)
+}
@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// allCores is the answer that selects every core.
+const allCores = "all"
+
+// numCPU is how the core count is detected, so tests can pretend to run on another host.
+var numCPU = runtime.NumCPU
+
+// AskCPUCoreSelection prompts for the indices of the cores to collect per core CPU metrics for, out
+// of numCores cores, or the cores detected on this host when numCores is not positive. Indices start
+// at 0 and are separated by commas, with ranges such as 4-7 for consecutive cores. The sorted indices
+// are returned, or nil when all cores are selected with "all" or an empty answer.
+func AskCPUCoreSelection(numCores int) ([]int, error) {
+	if numCores <= 0 {
+		numCores = numCPU()
+	}
+	var selected []int
+	_, err := askValidated(fmt.Sprintf("Which of the %d CPU cores do you want to collect per core metrics for? Enter their indices (0 to %d) or ranges separated by commas, e.g. 0,2,4-7, or %s for all cores.",
+		numCores, numCores-1, allCores), allCores, func(answer string) error {
+		if strings.EqualFold(strings.TrimSpace(answer), allCores) {
+			selected = nil
+			return nil
+		}
+		var err error
+		selected, err = parseCoreSelection(answer, numCores)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// CPUCoreResources returns the resources of the cpu input for cores as returned by AskCPUCoreSelection.
+// On Windows they are the Processor instances to collect, on Linux any resources other than "*" turn the
+// per core metrics off, since the cpu input there reports either every core or none.
+func CPUCoreResources(cores []int) []string {
+	if cores == nil {
+		return []string{allDevices}
+	}
+	resources := make([]string, 0, len(cores))
+	for _, core := range cores {
+		resources = append(resources, strconv.Itoa(core))
+	}
+	return resources
+}
+
+// parseCoreSelection parses comma separated core indices and ranges, rejecting indices of cores
+// beyond numCores.
+func parseCoreSelection(answer string, numCores int) ([]int, error) {
+	seen := make(map[int]bool)
+	for _, field := range strings.Split(answer, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(field, "-")
+		start, err := parseCoreIndex(strings.TrimSpace(first), numCores)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseCoreIndex(strings.TrimSpace(last), numCores); err != nil {
+				return nil, err
+			}
+			if end < start {
+				return nil, fmt.Errorf("range %s must go from the lower to the higher index", field)
+			}
+		}
+		for core := start; core <= end; core++ {
+			seen[core] = true
+		}
+	}
+	if len(seen) == 0 {
+		return nil, fmt.Errorf("select at least one core")
+	}
+	selected := make([]int, 0, len(seen))
+	for core := range seen {
+		selected = append(selected, core)
+	}
+	sort.Ints(selected)
+	return selected, nil
+}
+
+func parseCoreIndex(field string, numCores int) (int, error) {
+	core, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a core index", field)
+	}
+	if core < 0 || core >= numCores {
+		return 0, fmt.Errorf("core %d is out of range, this host has cores 0 to %d", core, numCores-1)
+	}
+	return core, nil
+}
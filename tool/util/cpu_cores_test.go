@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskCPUCoreSelection(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	original := numCPU
+	defer func() { numCPU = original }()
+	numCPU = func() int { return 4 }
+
+	testutil.Type(inputChan, "")
+	cores, err := AskCPUCoreSelection(0)
+	assert.NoError(t, err)
+	assert.Nil(t, cores)
+
+	testutil.Type(inputChan, "4", "-1", "one", "3-1", ",", "3, 0-1,1")
+	cores, err = AskCPUCoreSelection(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 3}, cores)
+
+	testutil.Type(inputChan, "ALL")
+	cores, err = AskCPUCoreSelection(64)
+	assert.NoError(t, err)
+	assert.Nil(t, cores)
+
+	testutil.Type(inputChan, "60-63")
+	cores, err = AskCPUCoreSelection(64)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{60, 61, 62, 63}, cores)
+}
+
+func TestCPUCoreResources(t *testing.T) {
+	assert.Equal(t, []string{"*"}, CPUCoreResources(nil))
+	assert.Equal(t, []string{"0", "2"}, CPUCoreResources([]int{0, 2}))
+}
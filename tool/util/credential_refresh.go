@@ -0,0 +1,79 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+const (
+	credentialRefreshSharedFile = "Shared credentials file (re-read every 10 minutes)"
+	credentialRefreshStatic     = "Static credentials from the agent environment"
+
+	sharedCredentialProfileKey = "shared_credential_profile"
+	sharedCredentialFileKey    = "shared_credential_file"
+	// defaultAgentProfile is the profile the agent reads when none is configured on premises.
+	defaultAgentProfile = "AmazonCloudWatchAgent"
+)
+
+// currentCredentials resolves the credentials of the default chain. It is replaced in tests.
+var currentCredentials = func() (credentials.Value, error) {
+	ses, err := session.NewSession()
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	return ses.Config.Credentials.Get()
+}
+
+// AskCredentialRefresh prompts whether the agent should read its credentials from a shared credentials
+// file, which it re-reads every 10 minutes so that a process rotating temporary credentials in the file
+// keeps it working, or use static credentials. It returns the fragment for the [credentials] section of
+// common-config.toml, which is empty for static credentials. The choice is checked against the
+// credentials the wizard currently resolves: static temporary credentials expire and stop the agent.
+func AskCredentialRefresh() (map[string]interface{}, error) {
+	value, err := currentCredentials()
+	if err != nil {
+		PrintDetail("Unable to resolve the current credentials: %v", err)
+	}
+	temporary := err == nil && value.SessionToken != ""
+	if err == nil {
+		PrintHint("The current credentials come from %s.", value.ProviderName)
+	}
+
+	for {
+		switch Choice("How should the agent get its credentials?", 1, []string{credentialRefreshSharedFile, credentialRefreshStatic}) {
+		case credentialRefreshStatic:
+			if temporary {
+				fmt.Println("W! The current credentials are temporary, they will expire and the agent will stop publishing. Use a shared credentials file that gets refreshed instead.")
+				if !No("Do you still want to use static credentials?") {
+					continue
+				}
+			}
+			return map[string]interface{}{}, nil
+		default:
+			credentialsFile, _ := SharedConfigFiles()
+			if _, statErr := os.Stat(credentialsFile); statErr != nil {
+				fmt.Printf("The shared credentials file %s does not exist on this host.\n", credentialsFile)
+			}
+			profile := AskWithDefault("Which profile of the shared credentials file should the agent use?", defaultAgentProfile)
+			filePath, err := askValidated("Shared credentials file path:", credentialsFile, func(answer string) error {
+				if answer == "" {
+					return fmt.Errorf("the path must not be empty")
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{
+				sharedCredentialProfileKey: profile,
+				sharedCredentialFileKey:    filePath,
+			}, nil
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func setUpCurrentCredentials(t *testing.T, value credentials.Value) {
+	original := currentCredentials
+	currentCredentials = func() (credentials.Value, error) { return value, nil }
+	t.Cleanup(func() { currentCredentials = original })
+}
+
+func TestAskCredentialRefresh(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsFile)
+
+	setUpCurrentCredentials(t, credentials.Value{ProviderName: "SharedCredentialsProvider"})
+	testutil.Type(inputChan, "", "", "")
+	fragment, err := AskCredentialRefresh()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"shared_credential_profile": "AmazonCloudWatchAgent", "shared_credential_file": credentialsFile}, fragment)
+
+	testutil.Type(inputChan, "2")
+	fragment, err = AskCredentialRefresh()
+	assert.NoError(t, err)
+	assert.Empty(t, fragment)
+
+	setUpCurrentCredentials(t, credentials.Value{ProviderName: "EnvConfigCredentials", SessionToken: "token"})
+	testutil.Type(inputChan, "2", "", "1", "default", "/etc/credentials")
+	fragment, err = AskCredentialRefresh()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"shared_credential_profile": "default", "shared_credential_file": "/etc/credentials"}, fragment)
+
+	testutil.Type(inputChan, "2", "1")
+	fragment, err = AskCredentialRefresh()
+	assert.NoError(t, err)
+	assert.Empty(t, fragment)
+}
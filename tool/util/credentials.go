@@ -0,0 +1,207 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+)
+
+const (
+	otherCredentialsOption = "Other (enter access keys)"
+	maskedSecret           = "********"
+)
+
+var accessKeyIDPattern = regexp.MustCompile(`^[A-Z0-9]{16,128}$`)
+
+// readSecret reads a line without echoing it when stdin is a terminal, and falls back to a plain read
+// otherwise (e.g. when the input is piped or provided by tests).
+var readSecret = func(question string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if answerSource != nil || !term.IsTerminal(fd) {
+		return readAnswer(question)
+	}
+	secret, err := term.ReadPassword(fd)
+	fmt.Println(maskedSecret)
+	return string(secret), err
+}
+
+// AskSecret prompts for a non-empty value such as a secret key without echoing it back.
+func AskSecret(question string) (string, error) {
+	for {
+		fmt.Printf("%s\n\r", question)
+		secret, err := readSecret(question)
+		if err != nil {
+			return "", err
+		}
+		secret = strings.TrimSpace(secret)
+		if secret != "" {
+			return secret, nil
+		}
+		fmt.Println("The value must not be empty.\nPlease retry to answer:")
+	}
+}
+
+// SharedConfigFiles returns the shared credentials and config file locations the SDK reads, honoring
+// AWS_SHARED_CREDENTIALS_FILE and AWS_CONFIG_FILE.
+func SharedConfigFiles() (string, string) {
+	home, _ := os.UserHomeDir()
+	credentialsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		credentialsFile = filepath.Join(home, ".aws", "credentials")
+	}
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = filepath.Join(home, ".aws", "config")
+	}
+	return credentialsFile, configFile
+}
+
+// ListProfiles returns the sorted names of the profiles defined in the shared credentials and config files.
+// Missing files are ignored.
+func ListProfiles() []string {
+	credentialsFile, configFile := SharedConfigFiles()
+	names := make(map[string]bool)
+	for _, name := range readProfileNames(credentialsFile, false) {
+		names[name] = true
+	}
+	for _, name := range readProfileNames(configFile, true) {
+		names[name] = true
+	}
+	profiles := make([]string, 0, len(names))
+	for name := range names {
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+// readProfileNames returns the section names of a shared ini file. Sections in the config file are
+// written as "[profile name]", except for "[default]", while other section types such as
+// "[sso-session name]" are not profiles.
+func readProfileNames(filePath string, isConfigFile bool) []string {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if name, ok := profileSection(scanner.Text(), isConfigFile); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// profileSection reports whether line starts a section, and returns the profile name when the section
+// is a profile. Other sections return an empty name.
+func profileSection(line string, isConfigFile bool) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+		return "", false
+	}
+	section := strings.TrimSpace(line[1 : len(line)-1])
+	if isConfigFile && section != "default" {
+		name, ok := strings.CutPrefix(section, "profile ")
+		if !ok {
+			return "", true
+		}
+		section = strings.TrimSpace(name)
+	}
+	return section, true
+}
+
+// readProfileSettings returns the key value pairs of every profile of a shared ini file.
+func readProfileSettings(filePath string, isConfigFile bool) map[string]map[string]string {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	profiles := make(map[string]map[string]string)
+	var current map[string]string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if name, ok := profileSection(scanner.Text(), isConfigFile); ok {
+			current = nil
+			if name != "" {
+				if profiles[name] == nil {
+					profiles[name] = make(map[string]string)
+				}
+				current = profiles[name]
+			}
+			continue
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if current == nil || line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return profiles
+}
+
+// readSharedProfiles returns the settings of every profile of the shared credentials and config files,
+// the credentials file taking precedence for the settings both set as it does for the SDK.
+func readSharedProfiles(credentialsFile, configFile string) map[string]map[string]string {
+	profiles := readProfileSettings(configFile, true)
+	if profiles == nil {
+		profiles = make(map[string]map[string]string)
+	}
+	for name, settings := range readProfileSettings(credentialsFile, false) {
+		if profiles[name] == nil {
+			profiles[name] = settings
+			continue
+		}
+		for key, value := range settings {
+			profiles[name][key] = value
+		}
+	}
+	return profiles
+}
+
+// AskDestinationCredentials collects the credentials used to send telemetry to a single destination
+// (e.g. "metrics" or "logs"), which lets each destination publish to a different account. The user either
+// picks one of the existing shared profiles or enters an access key pair.
+func AskDestinationCredentials(destination string) (*configaws.CredentialConfig, error) {
+	PrintHint("Separate credentials let %s be published to a different AWS account.", destination)
+	credentialsFile, configFile := SharedConfigFiles()
+	PrintDetail("Profiles are read from %s and %s. Prefer a profile over access keys so the keys are not copied into the agent config.", credentialsFile, configFile)
+	options := append(ListProfiles(), otherCredentialsOption)
+	answer := Choice(fmt.Sprintf("Which AWS credential should be used to send %s?", destination), 1, options)
+	if answer != otherCredentialsOption {
+		return &configaws.CredentialConfig{Profile: answer}, nil
+	}
+
+	accessKey, err := askValidated(fmt.Sprintf("AWS Access Key for %s:", destination), "", validateAccessKeyID)
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := AskSecret(fmt.Sprintf("AWS Secret Key for %s:", destination))
+	if err != nil {
+		return nil, err
+	}
+	return &configaws.CredentialConfig{AccessKey: accessKey, SecretKey: secretKey}, nil
+}
+
+func validateAccessKeyID(accessKey string) error {
+	if !accessKeyIDPattern.MatchString(accessKey) {
+		return errors.New("access key must be 16 to 128 upper case letters or digits")
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// sectionListPaths are the config lists whose entries are whole sections. Merging config fragments or
+// re-running the wizard appends to these lists, so they are where duplicate entries show up.
+var sectionListPaths = [][]string{
+	{"logs", "logs_collected", "files", "collect_list"},
+	{"logs", "logs_collected", "windows_events", "collect_list"},
+	{"metrics", "metrics_collected", "procstat"},
+	{"metrics", "aggregation_dimensions"},
+}
+
+// DeduplicateSections removes entries of the known section lists that are structurally identical to an
+// earlier entry of the same list, keeping the first occurrence and the original order. Entries that
+// only differ in a single field are kept. It returns the number of entries removed.
+func DeduplicateSections(m map[string]interface{}) int {
+	removed := 0
+	for _, path := range sectionListPaths {
+		removed += deduplicateAtPath(m, path)
+	}
+	return removed
+}
+
+func deduplicateAtPath(m map[string]interface{}, path []string) int {
+	key := path[0]
+	if len(path) == 1 {
+		list, ok := m[key]
+		if !ok {
+			return 0
+		}
+		deduplicated, removed := deduplicateList(list)
+		m[key] = deduplicated
+		return removed
+	}
+	if childMap, ok := m[key].(map[string]interface{}); ok {
+		return deduplicateAtPath(childMap, path[1:])
+	}
+	return 0
+}
+
+// deduplicateList works on any slice type, so it handles both lists parsed from json and the typed
+// lists built by the wizard's ToMap converters.
+func deduplicateList(list interface{}) (interface{}, int) {
+	entries := reflect.ValueOf(list)
+	if entries.Kind() != reflect.Slice {
+		return list, 0
+	}
+	result := reflect.MakeSlice(entries.Type(), 0, entries.Len())
+	for i := 0; i < entries.Len(); i++ {
+		if !containsEqual(result, entries.Index(i).Interface()) {
+			result = reflect.Append(result, entries.Index(i))
+		}
+	}
+	return result.Interface(), entries.Len() - result.Len()
+}
+
+func containsEqual(list reflect.Value, value interface{}) bool {
+	for i := 0; i < list.Len(); i++ {
+		if reflect.DeepEqual(list.Index(i).Interface(), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// deduplicateMeasurements removes the measurements listed again in the same measurement list, keeping
+// the first one, and returns a description of every removal. A measurement is a name or an object with
+// a name and its rename or unit, and objects count as the same measurement as the bare name. The
+// agent publishes a metric listed twice only once, so the later entries just obscure which settings
+// apply.
+func deduplicateMeasurements(value interface{}, path string) []string {
+	var removed []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if list, ok := v[key].([]interface{}); ok && key == MapKeyMeasurement {
+				var r []string
+				v[key], r = deduplicateMeasurementList(list, childPath)
+				removed = append(removed, r...)
+				continue
+			}
+			removed = append(removed, deduplicateMeasurements(v[key], childPath)...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			removed = append(removed, deduplicateMeasurements(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return removed
+}
+
+func deduplicateMeasurementList(list []interface{}, path string) ([]interface{}, []string) {
+	var removed []string
+	seen := make(map[string]bool, len(list))
+	result := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		name, ok := item.(string)
+		if entry, isMap := item.(map[string]interface{}); isMap {
+			name, ok = entry["name"].(string)
+		}
+		if ok {
+			if seen[name] {
+				removed = append(removed, fmt.Sprintf("removed the duplicate measurement %s of %s", name, path))
+				continue
+			}
+			seen[name] = true
+		}
+		result = append(result, item)
+	}
+	return result, removed
+}
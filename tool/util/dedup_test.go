@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicateSections(t *testing.T) {
+	// the result of merging the same generated fragment twice, with one near-duplicate entry added by hand
+	merged := `{
+		"logs": {"logs_collected": {"files": {"collect_list": [
+			{"file_path": "/var/log/messages", "log_group_name": "messages"},
+			{"file_path": "/var/log/secure", "log_group_name": "secure"},
+			{"file_path": "/var/log/messages", "log_group_name": "messages"},
+			{"file_path": "/var/log/messages", "log_group_name": "messages-copy"}
+		]}}},
+		"metrics": {
+			"aggregation_dimensions": [["InstanceId"], ["InstanceId"], []],
+			"metrics_collected": {"procstat": [{"exe": "nginx"}, {"exe": "nginx"}]}
+		}
+	}`
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(merged), &m))
+
+	assert.Equal(t, 3, DeduplicateSections(m))
+
+	collectList := m["logs"].(map[string]interface{})["logs_collected"].(map[string]interface{})["files"].(map[string]interface{})["collect_list"]
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"file_path": "/var/log/messages", "log_group_name": "messages"},
+		map[string]interface{}{"file_path": "/var/log/secure", "log_group_name": "secure"},
+		map[string]interface{}{"file_path": "/var/log/messages", "log_group_name": "messages-copy"},
+	}, collectList)
+	metrics := m["metrics"].(map[string]interface{})
+	assert.Equal(t, []interface{}{[]interface{}{"InstanceId"}, []interface{}{}}, metrics["aggregation_dimensions"])
+	assert.Len(t, metrics["metrics_collected"].(map[string]interface{})["procstat"], 1)
+
+	assert.Equal(t, 0, DeduplicateSections(m))
+}
+
+func TestDeduplicateSectionsTypedLists(t *testing.T) {
+	m := map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"aggregation_dimensions": [][]string{{"InstanceId"}, {"InstanceId"}},
+		},
+	}
+	assert.Equal(t, 1, DeduplicateSections(m))
+	assert.Equal(t, [][]string{{"InstanceId"}}, m["metrics"].(map[string]interface{})["aggregation_dimensions"])
+}
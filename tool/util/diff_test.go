@@ -0,0 +1,21 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	diff, err := UnifiedDiff("old.json", "new.json", []byte("{\n\t\"a\": 1,\n\t\"b\": 2\n}"), []byte("{\n\t\"a\": 1,\n\t\"b\": 3\n}"))
+	require.NoError(t, err)
+	assert.Equal(t, "--- old.json\n+++ new.json\n@@ -1,4 +1,4 @@\n {\n \t\"a\": 1,\n-\t\"b\": 2\n+\t\"b\": 3\n }\n", diff)
+
+	diff, err = UnifiedDiff("old.json", "new.json", []byte("{}"), []byte("{}"))
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
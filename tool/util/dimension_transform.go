@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "fmt"
+
+// The actions of a dimension transform rule.
+const (
+	DimensionTransformRename = "rename"
+	DimensionTransformDrop   = "drop"
+)
+
+// AskDimensionTransform lets the user rename or drop any of the available dimensions, such as the keys
+// of append_dimensions, one at a time until an empty answer. Each rule is a map with the action, the
+// source dimension and, to rename, the target name, in the order the rules were entered. Every
+// dimension is transformed at most once and targets follow the CloudWatch rules for dimension names
+// without clashing with the other dimensions. An empty list is returned when nothing is transformed.
+func AskDimensionTransform(available []string) ([]map[string]string, error) {
+	transform := []map[string]string{}
+	names := make(map[string]bool, len(available))
+	for _, name := range available {
+		names[name] = true
+	}
+	remaining := append([]string(nil), available...)
+	for len(remaining) > 0 {
+		options := ""
+		for i, name := range remaining {
+			options = fmt.Sprintf("%s%d. %s\n", options, i+1, name)
+		}
+		var source string
+		_, err := askValidated(fmt.Sprintf("Which dimension do you want to rename or drop? Enter its number or name, or nothing to finish.\n%s", options), "", func(answer string) error {
+			selected, err := parseNameSelection(answer, remaining)
+			if err != nil {
+				return err
+			}
+			if len(selected) > 1 {
+				return fmt.Errorf("enter a single dimension")
+			}
+			source = ""
+			if len(selected) == 1 {
+				source = selected[0]
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if source == "" {
+			break
+		}
+		rule := map[string]string{"source": source}
+		if Choice(fmt.Sprintf("What do you want to do with the %s dimension?", source), 1, []string{DimensionTransformRename, DimensionTransformDrop}) == DimensionTransformDrop {
+			rule["action"] = DimensionTransformDrop
+		} else {
+			target, err := askValidated(fmt.Sprintf("What do you want to rename the %s dimension to?", source), "", func(answer string) error {
+				if names[answer] {
+					return fmt.Errorf("the dimension %s already exists", answer)
+				}
+				return ValidateDimensionName(answer)
+			})
+			if err != nil {
+				return nil, err
+			}
+			rule["action"] = DimensionTransformRename
+			rule["target"] = target
+			names[target] = true
+		}
+		delete(names, source)
+		transform = append(transform, rule)
+		remaining = removeString(remaining, source)
+	}
+	return transform, nil
+}
+
+// ApplyDimensionTransform applies the rules of AskDimensionTransform to dimensions, a map from
+// dimension names to their values such as append_dimensions. Rules for dimensions that are not in the
+// map are ignored.
+func ApplyDimensionTransform(dimensions map[string]interface{}, transform []map[string]string) {
+	for _, rule := range transform {
+		value, ok := dimensions[rule["source"]]
+		if !ok {
+			continue
+		}
+		delete(dimensions, rule["source"])
+		if rule["action"] == DimensionTransformRename {
+			dimensions[rule["target"]] = value
+		}
+	}
+}
+
+func removeString(list []string, s string) []string {
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if item != s {
+			result = append(result, item)
+		}
+	}
+	return result
+}
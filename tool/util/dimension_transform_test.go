@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskDimensionTransform(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	available := []string{"InstanceId", "InstanceType", "ImageId"}
+
+	testutil.Type(inputChan, "")
+	transform, err := AskDimensionTransform(available)
+	assert.NoError(t, err)
+	assert.Empty(t, transform)
+	assert.NotNil(t, transform)
+
+	testutil.Type(inputChan, "Hostname", "1,2", "InstanceId", "", "InstanceType", ":Instance", "Instance",
+		"2", "2", "")
+	transform, err = AskDimensionTransform(available)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"action": DimensionTransformRename, "source": "InstanceId", "target": "Instance"},
+		{"action": DimensionTransformDrop, "source": "ImageId"},
+	}, transform)
+
+	testutil.Type(inputChan, "1", "1", "InstanceId", "Id")
+	transform, err = AskDimensionTransform([]string{"InstanceId"})
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"action": DimensionTransformRename, "source": "InstanceId", "target": "Id"}}, transform)
+
+	transform, err = AskDimensionTransform(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, transform)
+}
+
+func TestApplyDimensionTransform(t *testing.T) {
+	dimensions := map[string]interface{}{
+		"InstanceId":   "${aws:InstanceId}",
+		"InstanceType": "${aws:InstanceType}",
+		"ImageId":      "${aws:ImageId}",
+	}
+	ApplyDimensionTransform(dimensions, []map[string]string{
+		{"action": DimensionTransformRename, "source": "InstanceId", "target": "Instance"},
+		{"action": DimensionTransformDrop, "source": "ImageId"},
+		{"action": DimensionTransformDrop, "source": "AutoScalingGroupName"},
+	})
+	assert.Equal(t, map[string]interface{}{"Instance": "${aws:InstanceId}", "InstanceType": "${aws:InstanceType}"}, dimensions)
+}
@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// allDevices selects every device in the diskio resources.
+const allDevices = "*"
+
+// diskIOCounters is how the diskio input finds devices too, so the names match what it reports.
+var diskIOCounters = disk.IOCounters
+
+// BlockDevices returns the sorted names of the block devices the diskio input can report on this host.
+func BlockDevices() ([]string, error) {
+	counters, err := diskIOCounters()
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]string, 0, len(counters))
+	for name := range counters {
+		devices = append(devices, name)
+	}
+	sort.Strings(devices)
+	return devices, nil
+}
+
+// AskDiskIODevices prompts for which of the available devices, as returned by BlockDevices, the diskio
+// input should collect and returns them for its resources. Devices are selected by number or name,
+// separated by commas, and "*" or an empty answer selects all of them, including devices attached later.
+// Without any available device every device is collected, since none can be listed.
+func AskDiskIODevices(available []string) ([]string, error) {
+	if len(available) == 0 {
+		fmt.Println("No block devices were found, so disk I/O will be collected for all devices.")
+		return []string{allDevices}, nil
+	}
+	options := ""
+	for i, name := range available {
+		options = fmt.Sprintf("%s%d. %s\n", options, i+1, name)
+	}
+	var selected []string
+	_, err := askValidated(fmt.Sprintf("Which devices do you want to collect disk I/O metrics for? Enter their numbers or names separated by commas, or %s for all devices.\n%s",
+		allDevices, options), allDevices, func(answer string) error {
+		if strings.TrimSpace(answer) == allDevices {
+			selected = []string{allDevices}
+			return nil
+		}
+		var err error
+		selected, err = parseNameSelection(answer, available)
+		if err == nil && len(selected) == 0 {
+			err = fmt.Errorf("select at least one device")
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestBlockDevices(t *testing.T) {
+	original := diskIOCounters
+	defer func() { diskIOCounters = original }()
+
+	diskIOCounters = func(...string) (map[string]disk.IOCountersStat, error) {
+		return map[string]disk.IOCountersStat{"nvme1n1": {}, "nvme0n1": {}, "nvme0n1p1": {}}, nil
+	}
+	devices, err := BlockDevices()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nvme0n1", "nvme0n1p1", "nvme1n1"}, devices)
+
+	diskIOCounters = func(...string) (map[string]disk.IOCountersStat, error) {
+		return nil, errors.New("not implemented yet")
+	}
+	_, err = BlockDevices()
+	assert.Error(t, err)
+}
+
+func TestAskDiskIODevices(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	available := []string{"nvme0n1", "nvme0n1p1", "nvme1n1"}
+
+	testutil.Type(inputChan, "")
+	devices, err := AskDiskIODevices(available)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"*"}, devices)
+
+	testutil.Type(inputChan, "sda", "4", ",", "3,nvme0n1")
+	devices, err = AskDiskIODevices(available)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nvme1n1", "nvme0n1"}, devices)
+
+	devices, err = AskDiskIODevices(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"*"}, devices)
+}
@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AskDropOriginalMetrics prompts for which of the available measurements to list in
+// drop_original_metrics, so that only their aggregated metrics are published. Measurements can be
+// selected by number or by name, separated by commas. An empty answer selects none.
+func AskDropOriginalMetrics(available []string) ([]string, error) {
+	if len(available) == 0 {
+		return nil, nil
+	}
+	PrintHint("Dropping the original metrics of a measurement only publishes it aggregated by aggregation_dimensions, which lowers the cost.")
+	options := ""
+	for i, name := range available {
+		options = fmt.Sprintf("%s%d. %s\n", options, i+1, name)
+	}
+	var selected []string
+	_, err := askValidated(fmt.Sprintf("Which original metrics do you want to drop? Enter their numbers or names separated by commas, or nothing to keep them all.\n%s", options), "", func(answer string) error {
+		var err error
+		selected, err = parseNameSelection(answer, available)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// parseNameSelection resolves the comma separated option numbers or names of answer against available.
+func parseNameSelection(answer string, available []string) ([]string, error) {
+	var selected []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(answer, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		name := ""
+		if option, err := strconv.Atoi(field); err == nil && option >= 1 && option <= len(available) {
+			name = available[option-1]
+		} else {
+			for _, candidate := range available {
+				if candidate == field {
+					name = candidate
+					break
+				}
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%q is not one of the options", field)
+		}
+		if !seen[name] {
+			seen[name] = true
+			selected = append(selected, name)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskDropOriginalMetrics(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	available := []string{"cpu_usage_idle", "cpu_usage_user", "cpu_usage_system"}
+
+	testutil.Type(inputChan, "")
+	selected, err := AskDropOriginalMetrics(available)
+	assert.NoError(t, err)
+	assert.Empty(t, selected)
+
+	testutil.Type(inputChan, "4", "cpu_usage_nice", "3, cpu_usage_idle,3")
+	selected, err = AskDropOriginalMetrics(available)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu_usage_system", "cpu_usage_idle"}, selected)
+
+	selected, err = AskDropOriginalMetrics(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, selected)
+}
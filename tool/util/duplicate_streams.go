@@ -0,0 +1,148 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// instanceDerivedDimensions only ever take one value per InstanceId, so adding them to a dimension set
+// that already has InstanceId does not split the data any further.
+var instanceDerivedDimensions = map[string]bool{
+	"host":                 true,
+	"ImageId":              true,
+	"InstanceType":         true,
+	"AutoScalingGroupName": true,
+}
+
+// DetectDuplicateStreams looks for dimension settings under metrics that publish the same data more
+// than once: aggregation_dimensions entries that repeat a dimension set, entries that only differ by
+// dimensions that follow from InstanceId, and entries equal to the dimensions every metric is already
+// published with (append_dimensions plus host unless omit_hostname is set). Each of them is a separate
+// set of custom metrics. It returns a description of each overlap.
+func DetectDuplicateStreams(m map[string]interface{}) []string {
+	metrics := getMap(m, "metrics")
+	if metrics == nil {
+		return nil
+	}
+	sets := dimensionSets(metrics["aggregation_dimensions"])
+	var warnings []string
+	for i, set := range sets {
+		if duplicates := repeatedNames(set); len(duplicates) > 0 {
+			warnings = append(warnings, fmt.Sprintf("aggregation_dimensions[%d] lists %s more than once", i, strings.Join(duplicates, ", ")))
+		}
+	}
+	reported := make(map[int]bool)
+	for i := range sets {
+		for j := i + 1; j < len(sets); j++ {
+			if reported[j] {
+				continue
+			}
+			switch {
+			case sameDimensions(sets[i], sets[j]):
+				warnings = append(warnings, fmt.Sprintf("aggregation_dimensions[%d] and aggregation_dimensions[%d] are the same dimension set %s, "+
+					"so the aggregated metrics are published twice", i, j, formatDimensionSet(sets[i])))
+				reported[j] = true
+			case sameDimensions(reduceToInstance(sets[i]), reduceToInstance(sets[j])):
+				warnings = append(warnings, fmt.Sprintf("aggregation_dimensions[%d] %s and aggregation_dimensions[%d] %s only differ by dimensions that "+
+					"have one value per InstanceId, so they aggregate the same data", i, formatDimensionSet(sets[i]), j, formatDimensionSet(sets[j])))
+				reported[j] = true
+			}
+		}
+	}
+	base := baseDimensions(m, metrics)
+	for i, set := range sets {
+		if !reported[i] && len(base) > 0 && sameDimensions(set, base) {
+			warnings = append(warnings, fmt.Sprintf("aggregation_dimensions[%d] %s matches the dimensions every metric is already published with, "+
+				"so metrics without dimensions of their own are published twice", i, formatDimensionSet(set)))
+		}
+	}
+	return warnings
+}
+
+// dimensionSets reads aggregation_dimensions as either [][]string from the wizard or nested lists
+// parsed from json.
+func dimensionSets(value interface{}) [][]string {
+	switch sets := value.(type) {
+	case [][]string:
+		return sets
+	case []interface{}:
+		result := make([][]string, 0, len(sets))
+		for _, set := range sets {
+			result = append(result, getStringList(map[string]interface{}{"set": set}, "set"))
+		}
+		return result
+	}
+	return nil
+}
+
+// baseDimensions are the dimensions the agent adds to all metrics.
+func baseDimensions(m, metrics map[string]interface{}) []string {
+	var base []string
+	if omit, _ := getMap(m, "agent")["omit_hostname"].(bool); !omit {
+		base = append(base, "host")
+	}
+	switch appended := metrics["append_dimensions"].(type) {
+	case map[string]interface{}:
+		for name := range appended {
+			base = append(base, name)
+		}
+	case map[string]string:
+		for name := range appended {
+			base = append(base, name)
+		}
+	}
+	return base
+}
+
+func reduceToInstance(set []string) []string {
+	hasInstanceID := false
+	for _, name := range set {
+		if name == "InstanceId" {
+			hasInstanceID = true
+		}
+	}
+	if !hasInstanceID {
+		return set
+	}
+	var reduced []string
+	for _, name := range set {
+		if !instanceDerivedDimensions[name] {
+			reduced = append(reduced, name)
+		}
+	}
+	return reduced
+}
+
+func repeatedNames(set []string) []string {
+	seen := make(map[string]int)
+	var repeated []string
+	for _, name := range set {
+		seen[name]++
+		if seen[name] == 2 {
+			repeated = append(repeated, name)
+		}
+	}
+	return repeated
+}
+
+// sameDimensions compares dimension sets regardless of order and repeated names.
+func sameDimensions(a, b []string) bool {
+	return formatDimensionSet(a) == formatDimensionSet(b)
+}
+
+func formatDimensionSet(set []string) string {
+	unique := make(map[string]bool, len(set))
+	names := make([]string, 0, len(set))
+	for _, name := range set {
+		if !unique[name] {
+			unique[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return "[" + strings.Join(names, ", ") + "]"
+}
@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDuplicateStreams(t *testing.T) {
+	testCases := map[string]struct {
+		config string
+		want   []string
+	}{
+		"WizardDefault": {
+			config: `{"metrics":{"append_dimensions":{"ImageId":"${aws:ImageId}","InstanceId":"${aws:InstanceId}","InstanceType":"${aws:InstanceType}","AutoScalingGroupName":"${aws:AutoScalingGroupName}"},"aggregation_dimensions":[["InstanceId"]]}}`,
+		},
+		"NoDimensions": {
+			config: `{"metrics":{"metrics_collected":{"cpu":{}}}}`,
+		},
+		"RepeatedSet": {
+			config: `{"metrics":{"aggregation_dimensions":[["InstanceId","cpu"],["cpu","InstanceId"]]}}`,
+			want:   []string{"aggregation_dimensions[0] and aggregation_dimensions[1] are the same dimension set [InstanceId, cpu], so the aggregated metrics are published twice"},
+		},
+		"RepeatedName": {
+			config: `{"metrics":{"aggregation_dimensions":[["path","path"]]}}`,
+			want:   []string{"aggregation_dimensions[0] lists path more than once"},
+		},
+		"InstanceDerived": {
+			config: `{"metrics":{"aggregation_dimensions":[["InstanceId"],["InstanceId","InstanceType"],[]]}}`,
+			want: []string{"aggregation_dimensions[0] [InstanceId] and aggregation_dimensions[1] [InstanceId, InstanceType] only differ by dimensions that " +
+				"have one value per InstanceId, so they aggregate the same data"},
+		},
+		"MatchesBase": {
+			config: `{"agent":{"omit_hostname":true},"metrics":{"append_dimensions":{"InstanceId":"${aws:InstanceId}"},"aggregation_dimensions":[["InstanceId"]]}}`,
+			want: []string{"aggregation_dimensions[0] [InstanceId] matches the dimensions every metric is already published with, " +
+				"so metrics without dimensions of their own are published twice"},
+		},
+		"HostKept": {
+			config: `{"metrics":{"append_dimensions":{"InstanceId":"${aws:InstanceId}"},"aggregation_dimensions":[["InstanceId"]]}}`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var m map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.config), &m))
+			assert.Equal(t, testCase.want, DetectDuplicateStreams(m))
+		})
+	}
+}
+
+func TestDetectDuplicateStreamsTyped(t *testing.T) {
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{"omit_hostname": true},
+		"metrics": map[string]interface{}{
+			"append_dimensions":      map[string]interface{}{"InstanceId": "${aws:InstanceId}"},
+			"aggregation_dimensions": [][]string{{"InstanceId"}, {"InstanceId"}},
+		},
+	}
+	assert.Len(t, DetectDuplicateStreams(m), 2)
+}
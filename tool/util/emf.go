@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	defaultEMFNamespace   = "CWAgent/Prometheus"
+	maxNamespaceLength    = 255
+	defaultMetricSelector = ".*"
+)
+
+// namespaceRegex holds the characters CloudWatch allows in a namespace.
+var namespaceRegex = regexp.MustCompile(`^[0-9A-Za-z.\-_/#: ]+$`)
+
+// AskEnableEMF asks whether the agent should accept logs in the embedded metric format and returns the
+// answer. When enabled, the config gets logs.metrics_collected.emf, which listens on port 25888 over TCP
+// and UDP. The namespace and dimensions of those metrics are chosen by the applications in each log
+// event, not in the agent config.
+func AskEnableEMF() (bool, error) {
+	PrintHint("The embedded metric format lets applications publish metrics by writing structured json logs, " +
+		"which CloudWatch turns into metrics without PutMetricData calls.")
+	PrintDetail("Applications send the logs to the agent on port 25888. Each log event names its own namespace and dimensions.")
+	return No("Do you want the agent to accept logs in the embedded metric format (EMF)?"), nil
+}
+
+// AskEMFProcessor prompts for the namespace and dimension sets the agent uses when it converts scraped
+// metrics into the embedded metric format, and returns the emf_processor fragment with metric_namespace
+// and a metric_declaration per dimension set. Names are checked with ValidateDimensionName and against
+// the names CloudWatch reserves.
+func AskEMFProcessor() (map[string]interface{}, error) {
+	namespace, err := askValidated("Which namespace do you want the metrics published to?", defaultEMFNamespace, ValidateNamespace)
+	if err != nil {
+		return nil, err
+	}
+	var declarations []interface{}
+	for {
+		answer, err := askValidated("Which dimensions (separated by commas) do you want the metrics published with?", "", validateDimensionList)
+		if err != nil {
+			return nil, err
+		}
+		selector, err := askValidated("Which metrics (regular expression) do these dimensions apply to?", defaultMetricSelector, func(answer string) error {
+			_, err := regexp.Compile(answer)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		declarations = append(declarations, map[string]interface{}{
+			"dimensions":       [][]string{splitList(answer)},
+			"metric_selectors": []string{selector},
+		})
+		if !No("Do you want to add another dimension set?") {
+			break
+		}
+	}
+	return map[string]interface{}{
+		"metric_namespace":   namespace,
+		"metric_declaration": declarations,
+	}, nil
+}
+
+// ValidateNamespace checks namespace follows the CloudWatch rules for namespaces and is not reserved.
+func ValidateNamespace(namespace string) error {
+	if namespace == "" || len(namespace) > maxNamespaceLength {
+		return fmt.Errorf("the namespace must be 1 to %d characters long", maxNamespaceLength)
+	}
+	if !namespaceRegex.MatchString(namespace) {
+		return fmt.Errorf("the namespace may only contain letters, digits and the characters . - _ / # : and space")
+	}
+	if message := reservedNamespaceMessage(namespace); message != "" {
+		return errors.New(message)
+	}
+	return nil
+}
+
+func validateDimensionList(answer string) error {
+	names := splitList(answer)
+	if len(names) == 0 {
+		return fmt.Errorf("at least one dimension is required")
+	}
+	for _, name := range names {
+		if err := ValidateDimensionName(name); err != nil {
+			return fmt.Errorf("%q: %w", name, err)
+		}
+		if message := reservedDimensionMessage(name); message != "" {
+			return errors.New(message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskEnableEMF(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	enabled, err := AskEnableEMF()
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+
+	testutil.Type(inputChan, "1")
+	enabled, err = AskEnableEMF()
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+}
+
+func TestAskEMFProcessor(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "AWS/Custom", "", "", "AWS:Service", "Service,Namespace", "", "1", ":pod", "pod", "[a-", "^http_.*", "")
+	processor, err := AskEMFProcessor()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"metric_namespace": defaultEMFNamespace,
+		"metric_declaration": []interface{}{
+			map[string]interface{}{"dimensions": [][]string{{"Service", "Namespace"}}, "metric_selectors": []string{".*"}},
+			map[string]interface{}{"dimensions": [][]string{{"pod"}}, "metric_selectors": []string{"^http_.*"}},
+		},
+	}, processor)
+}
+
+func TestValidateNamespace(t *testing.T) {
+	for _, namespace := range []string{"CWAgent", "CWAgent/Prometheus", "my-app_v2.metrics#1: prod"} {
+		assert.NoError(t, ValidateNamespace(namespace), namespace)
+	}
+	for _, namespace := range []string{"", "AWS/EC2", "app(metrics)", string(make([]byte, 256))} {
+		assert.Error(t, ValidateNamespace(namespace), namespace)
+	}
+}
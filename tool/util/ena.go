@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	sysruntime "runtime"
+	"sort"
+	"strings"
+)
+
+const enaDriver = "ena"
+
+// sysClassNetDir lists the network interfaces of a Linux host, with a device/driver link for the
+// physical ones.
+var sysClassNetDir = "/sys/class/net"
+
+// enaAllowanceMetrics are the ENA driver statistics counting the packets the instance dropped or queued
+// for exceeding an allowance of its instance type.
+var enaAllowanceMetrics = []string{
+	"bw_in_allowance_exceeded",
+	"bw_out_allowance_exceeded",
+	"conntrack_allowance_exceeded",
+	"linklocal_allowance_exceeded",
+	"pps_allowance_exceeded",
+}
+
+// AskENAMetrics asks whether the agent should collect the allowance metrics of the ENA driver, such as
+// bw_in_allowance_exceeded, and when it should returns the metrics_collected fragment doing so with the
+// ethtool plugin. The plugin reads the driver statistics of the interfaces, so the question is only
+// asked on Linux hosts with interfaces using the ENA driver; elsewhere a warning is printed and false
+// is returned.
+func AskENAMetrics() (bool, map[string]interface{}, error) {
+	interfaces, err := enaInterfaces()
+	if err != nil {
+		return false, nil, err
+	}
+	if len(interfaces) == 0 {
+		fmt.Println("W! No network interface of this host uses the ENA driver, ENA metrics cannot be collected.")
+		return false, nil, nil
+	}
+	PrintHint("ENA metrics count the packets dropped or queued because the instance exceeded the bandwidth, packet rate or connection allowances of its type.")
+	if !No(fmt.Sprintf("Do you want to collect ENA metrics for %s?", strings.Join(interfaces, ", "))) {
+		return false, nil, nil
+	}
+	return true, map[string]interface{}{
+		"ethtool": map[string]interface{}{
+			"interface_include": interfaces,
+			"metrics_include":   enaAllowanceMetrics,
+		},
+	}, nil
+}
+
+// enaInterfaces returns the sorted names of the network interfaces using the ENA driver. Only Linux
+// exposes the driver of an interface, so nothing is returned elsewhere.
+func enaInterfaces() ([]string, error) {
+	if sysruntime.GOOS != OsTypeLinux {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(sysClassNetDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var interfaces []string
+	for _, entry := range entries {
+		driver, err := filepath.EvalSymlinks(filepath.Join(sysClassNetDir, entry.Name(), "device", "driver"))
+		if err != nil {
+			// virtual interfaces such as lo have no device
+			continue
+		}
+		if filepath.Base(driver) == enaDriver {
+			interfaces = append(interfaces, entry.Name())
+		}
+	}
+	sort.Strings(interfaces)
+	return interfaces, nil
+}
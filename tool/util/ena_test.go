@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	sysruntime "runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+// fakeNetDevice adds interface name to the fake sysClassNetDir, using driver unless it is empty.
+func fakeNetDevice(t *testing.T, dir, name, driver string) {
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "net", name), 0755))
+	if driver == "" {
+		return
+	}
+	driverDir := filepath.Join(dir, "drivers", driver)
+	deviceDir := filepath.Join(dir, "net", name, "device")
+	require.NoError(t, os.MkdirAll(driverDir, 0755))
+	require.NoError(t, os.MkdirAll(deviceDir, 0755))
+	require.NoError(t, os.Symlink(driverDir, filepath.Join(deviceDir, "driver")))
+}
+
+func TestAskENAMetrics(t *testing.T) {
+	if sysruntime.GOOS != OsTypeLinux {
+		t.Skip("the driver of an interface is only detected on Linux")
+	}
+	dir := t.TempDir()
+	original := sysClassNetDir
+	sysClassNetDir = filepath.Join(dir, "net")
+	defer func() { sysClassNetDir = original }()
+	fakeNetDevice(t, dir, "lo", "")
+	fakeNetDevice(t, dir, "eth0", "virtio_net")
+
+	enabled, fragment, err := AskENAMetrics()
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+	assert.Nil(t, fragment)
+
+	fakeNetDevice(t, dir, "ens6", "ena")
+	fakeNetDevice(t, dir, "ens5", "ena")
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	enabled, fragment, err = AskENAMetrics()
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+	assert.Nil(t, fragment)
+
+	testutil.Type(inputChan, "1")
+	enabled, fragment, err = AskENAMetrics()
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	ethtool := fragment["ethtool"].(map[string]interface{})
+	assert.Equal(t, []string{"ens5", "ens6"}, ethtool["interface_include"])
+	assert.Contains(t, ethtool["metrics_include"], "bw_in_allowance_exceeded")
+}
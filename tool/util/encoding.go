@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+const (
+	EncodingUTF8    = "utf-8"
+	EncodingUTF16LE = "utf-16le"
+	EncodingUTF16BE = "utf-16be"
+	EncodingLatin1  = "iso-8859-1"
+
+	encodingSampleSize = 4096
+)
+
+// DetectLogEncoding guesses the encoding of the log file at path from its first bytes: a byte order
+// mark, the zero bytes of UTF-16 text without one, valid UTF-8 (including plain ASCII) and, failing
+// all of those, Latin-1. It returns one of the Encoding constants.
+func DetectLogEncoding(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sample := make([]byte, encodingSampleSize)
+	n, err := io.ReadFull(f, sample)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return detectEncoding(sample[:n], n == encodingSampleSize), nil
+}
+
+func detectEncoding(sample []byte, truncated bool) string {
+	switch {
+	case bytes.HasPrefix(sample, []byte{0xEF, 0xBB, 0xBF}):
+		return EncodingUTF8
+	case bytes.HasPrefix(sample, []byte{0xFF, 0xFE}):
+		return EncodingUTF16LE
+	case bytes.HasPrefix(sample, []byte{0xFE, 0xFF}):
+		return EncodingUTF16BE
+	}
+	evenZeros, oddZeros := 0, 0
+	for i, b := range sample {
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			evenZeros++
+		} else {
+			oddZeros++
+		}
+	}
+	// mostly ASCII text in UTF-16 has a zero in every other byte
+	if half := len(sample) / 2; half > 0 {
+		if oddZeros > half*3/4 {
+			return EncodingUTF16LE
+		}
+		if evenZeros > half*3/4 {
+			return EncodingUTF16BE
+		}
+	}
+	if truncated {
+		// the sample may end in the middle of a multi byte character
+		for i := len(sample) - 1; i >= 0 && i >= len(sample)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(sample[i]) {
+				if !utf8.FullRune(sample[i:]) {
+					sample = sample[:i]
+				}
+				break
+			}
+		}
+	}
+	if utf8.Valid(sample) {
+		return EncodingUTF8
+	}
+	return EncodingLatin1
+}
+
+// AskLogEncoding prompts to confirm the detected encoding of a log file or enter another one, and
+// returns it for the encoding field of the log entry. Any IANA encoding name the agent supports is
+// accepted.
+func AskLogEncoding(detected string) (string, error) {
+	PrintHint("The agent reads log files as %s unless an encoding is set.", EncodingUTF8)
+	return askValidated("Which encoding is the log file in?", detected, func(answer string) error {
+		if enc, err := ianaindex.IANA.Encoding(answer); err != nil || enc == nil {
+			return fmt.Errorf("%s is not a supported encoding", answer)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestDetectLogEncoding(t *testing.T) {
+	dir := t.TempDir()
+	testCases := map[string]struct {
+		content []byte
+		want    string
+	}{
+		"ASCII":         {content: []byte("2024-01-01 INFO started\n"), want: EncodingUTF8},
+		"UTF8":          {content: []byte("2024-01-01 INFO café ☕\n"), want: EncodingUTF8},
+		"UTF8BOM":       {content: []byte("\xEF\xBB\xBFINFO\n"), want: EncodingUTF8},
+		"UTF16LEBOM":    {content: []byte("\xFF\xFEI\x00N\x00"), want: EncodingUTF16LE},
+		"UTF16BEBOM":    {content: []byte("\xFE\xFF\x00I\x00N"), want: EncodingUTF16BE},
+		"UTF16LE":       {content: []byte("I\x00N\x00F\x00O\x00\n\x00"), want: EncodingUTF16LE},
+		"UTF16BE":       {content: []byte("\x00I\x00N\x00F\x00O\x00\n"), want: EncodingUTF16BE},
+		"Latin1":        {content: []byte("caf\xE9 cr\xE8me\n"), want: EncodingLatin1},
+		"Empty":         {content: []byte{}, want: EncodingUTF8},
+		"TruncatedUTF8": {content: []byte(strings.Repeat("a", encodingSampleSize-1) + "é"), want: EncodingUTF8},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name+".log")
+			require.NoError(t, os.WriteFile(path, testCase.content, 0644))
+			encoding, err := DetectLogEncoding(path)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, encoding)
+		})
+	}
+
+	_, err := DetectLogEncoding(filepath.Join(dir, "missing.log"))
+	assert.Error(t, err)
+}
+
+func TestAskLogEncoding(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	encoding, err := AskLogEncoding(EncodingLatin1)
+	assert.NoError(t, err)
+	assert.Equal(t, EncodingLatin1, encoding)
+
+	testutil.Type(inputChan, "klingon", "shift_jis")
+	encoding, err = AskLogEncoding(EncodingLatin1)
+	assert.NoError(t, err)
+	assert.Equal(t, "shift_jis", encoding)
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"regexp"
+	"sort"
+)
+
+// envReferenceRegex matches ${NAME} references. Placeholders with a colon, such as ${aws:InstanceId},
+// are resolved by the agent from the instance metadata and are not environment variables.
+var envReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// DetectEnvReferences returns the sorted names of the environment variables referenced as ${NAME} in
+// the string values of the config, and the subset of them that is not set in the current environment.
+// A variable that is unset where the agent runs resolves to an empty string, so the wizard warns about
+// the unset ones when it runs on the host the config is for.
+func DetectEnvReferences(m map[string]interface{}) ([]string, []string) {
+	seen := make(map[string]bool)
+	addReferences := func(value string) {
+		for _, match := range envReferenceRegex.FindAllStringSubmatch(value, -1) {
+			seen[match[1]] = true
+		}
+	}
+	walkConfig(m, "", func(_, _ string, value interface{}) {
+		switch v := value.(type) {
+		case string:
+			addReferences(v)
+		case []string:
+			for _, item := range v {
+				addReferences(item)
+			}
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					addReferences(s)
+				}
+			}
+		}
+	})
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	references := make([]string, 0, len(seen))
+	for name := range seen {
+		references = append(references, name)
+	}
+	sort.Strings(references)
+	var unset []string
+	for _, name := range references {
+		if _, ok := os.LookupEnv(name); !ok {
+			unset = append(unset, name)
+		}
+	}
+	return references, unset
+}
@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectEnvReferences(t *testing.T) {
+	references, unset := DetectEnvReferences(map[string]interface{}{"agent": map[string]interface{}{"run_as_user": "cwagent"}})
+	assert.Empty(t, references)
+	assert.Empty(t, unset)
+
+	t.Setenv("CWAGENT_TEST_LOG_GROUP", "app")
+	t.Setenv("CWAGENT_TEST_EMPTY", "")
+	m := map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"append_dimensions": map[string]interface{}{"InstanceId": "${aws:InstanceId}"},
+			"metrics_collected": map[string]interface{}{
+				"disk": map[string]interface{}{"resources": []string{"${CWAGENT_TEST_MOUNT}"}},
+			},
+		},
+		"logs": map[string]interface{}{
+			"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{
+					"collect_list": []interface{}{
+						map[string]interface{}{
+							"file_path":      "/var/log/${CWAGENT_TEST_APP}/*.log",
+							"log_group_name": "${CWAGENT_TEST_LOG_GROUP}-${CWAGENT_TEST_APP}",
+							"filters":        []interface{}{map[string]interface{}{"type": "include", "expression": "$CWAGENT_TEST_NOT_A_REFERENCE$"}},
+						},
+					},
+				},
+			},
+			"log_stream_name": "${CWAGENT_TEST_EMPTY}",
+		},
+	}
+	references, unset = DetectEnvReferences(m)
+	assert.Equal(t, []string{"CWAGENT_TEST_APP", "CWAGENT_TEST_EMPTY", "CWAGENT_TEST_LOG_GROUP", "CWAGENT_TEST_MOUNT"}, references)
+	assert.Equal(t, []string{"CWAGENT_TEST_APP", "CWAGENT_TEST_MOUNT"}, unset)
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "errors"
+
+// The codes of the errors the wizard stops with. They are part of the json output, so automation can
+// match them, and do not change between versions.
+const (
+	ErrCodeInvalidArgument = "InvalidArgument"
+	ErrCodePermission      = "PermissionDenied"
+	ErrCodeConfigRead      = "ConfigReadFailed"
+	ErrCodeConfigParse     = "ConfigParseFailed"
+	ErrCodeConfigSerialize = "ConfigSerializeFailed"
+	ErrCodeConfigWrite     = "ConfigWriteFailed"
+	ErrCodeConfigInvalid   = "ConfigInvalid"
+	ErrCodeMissingAnswer   = "MissingAnswer"
+	ErrCodeInternal        = "InternalError"
+)
+
+// WizardError is an error the wizard stops with, with the code telling what failed and the file it
+// failed on, if any. It wraps the underlying error, e.g. an *os.PathError.
+type WizardError struct {
+	Code string
+	Path string
+	Err  error
+}
+
+func (e *WizardError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *WizardError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the code of the WizardError in the chain of err, and ErrCodeInternal when there is
+// none.
+func ErrorCode(err error) string {
+	var wizardErr *WizardError
+	if errors.As(err, &wizardErr) {
+		return wizardErr.Code
+	}
+	return ErrCodeInternal
+}
+
+// ErrorPath returns the path of the WizardError in the chain of err, if any.
+func ErrorPath(err error) string {
+	var wizardErr *WizardError
+	if errors.As(err, &wizardErr) {
+		return wizardErr.Path
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+// +build !windows
+
+package util
+
+import "errors"
+
+// windowsEventChannels returns an error since only windows has event log channels to list.
+func windowsEventChannels() ([]string, error) {
+	return nil, errors.New("event log channels can only be listed on windows")
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+// +build windows
+
+package util
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// errorNoMoreItems is ERROR_NO_MORE_ITEMS, which EvtNextChannelPath returns after the last channel.
+const errorNoMoreItems = syscall.Errno(259)
+
+var (
+	modwevtapi             = syscall.NewLazyDLL("wevtapi.dll")
+	procEvtOpenChannelEnum = modwevtapi.NewProc("EvtOpenChannelEnum")
+	procEvtNextChannelPath = modwevtapi.NewProc("EvtNextChannelPath")
+	procEvtClose           = modwevtapi.NewProc("EvtClose")
+)
+
+// windowsEventChannels returns the event log channels registered on the host, e.g. System or
+// Microsoft-Windows-PowerShell/Operational.
+func windowsEventChannels() ([]string, error) {
+	if err := procEvtOpenChannelEnum.Find(); err != nil {
+		return nil, err
+	}
+	enum, _, e1 := syscall.SyscallN(procEvtOpenChannelEnum.Addr(), 0, 0)
+	if enum == 0 {
+		return nil, fmt.Errorf("error in listing the event log channels: %w", e1)
+	}
+	defer syscall.SyscallN(procEvtClose.Addr(), enum)
+
+	var channels []string
+	buf := make([]uint16, 512)
+	for {
+		var used uint32
+		r1, _, e1 := syscall.SyscallN(procEvtNextChannelPath.Addr(), enum, uintptr(len(buf)), uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&used)))
+		if r1 == 0 {
+			switch e1 {
+			case syscall.ERROR_INSUFFICIENT_BUFFER:
+				buf = make([]uint16, used)
+				continue
+			case errorNoMoreItems:
+				return channels, nil
+			default:
+				return nil, fmt.Errorf("error in listing the event log channels: %w", e1)
+			}
+		}
+		channels = append(channels, syscall.UTF16ToString(buf[:used]))
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// windowsPathRegex matches file paths with a drive letter or a UNC prefix, which are normalized the way
+// they are on Windows whatever OS the config is finalized on.
+var windowsPathRegex = regexp.MustCompile(`^([A-Za-z]:[\\/]|\\\\)`)
+
+// FinalizeConfig cleans up a config before it is saved and describes every change made, in the order
+// they were made: deprecated keys are migrated, boolean strings become json booleans, file paths are
+// normalized, and duplicate sections and measurements are removed. m is left unchanged; the cleaned
+// config is a copy of it with the lists the wizard builds turned into plain json lists. An error is
+// returned when a deprecated key cannot be migrated.
+func FinalizeConfig(m map[string]interface{}) (map[string]interface{}, []string, error) {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return nil, nil, fmt.Errorf("the config cannot be serialized: %w", err)
+	}
+	var result map[string]interface{}
+	if err = json.Unmarshal(content, &result); err != nil {
+		return nil, nil, fmt.Errorf("the config cannot be serialized: %w", err)
+	}
+
+	changes, err := MigrateDeprecatedKeys(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	if converted := NormalizeBooleans(result); converted > 0 {
+		changes = append(changes, fmt.Sprintf("converted %d boolean strings to json booleans", converted))
+	}
+	changes = append(changes, normalizeFilePaths(result)...)
+	for _, path := range sectionListPaths {
+		if removed := deduplicateAtPath(result, path); removed > 0 {
+			changes = append(changes, fmt.Sprintf("removed %d duplicate entries of %s", removed, strings.Join(path, ".")))
+		}
+	}
+	changes = append(changes, deduplicateMeasurements(result, "")...)
+	return result, changes, nil
+}
+
+// normalizeFilePaths applies NormalizeConfigPath to the file_path of every collect_list entry of the
+// files section, and returns a description of every path it changed.
+func normalizeFilePaths(m map[string]interface{}) []string {
+	var changes []string
+	files := getMap(m, "logs", "logs_collected", "files")
+	for i, entry := range getMapList(files, collectListKey) {
+		filePath, ok := entry[filePathKey].(string)
+		if !ok {
+			continue
+		}
+		osType := OsTypeLinux
+		if windowsPathRegex.MatchString(strings.TrimSpace(filePath)) {
+			osType = OsTypeWindows
+		}
+		if normalized := NormalizeConfigPath(filePath, osType); normalized != filePath {
+			entry[filePathKey] = normalized
+			changes = append(changes, fmt.Sprintf("normalized logs.logs_collected.files.collect_list[%d].%s from %q to %q", i, filePathKey, filePath, normalized))
+		}
+	}
+	return changes
+}
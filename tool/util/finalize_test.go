@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalizeConfig(t *testing.T) {
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{"debug": "yes", "role_arn": "arn:aws:iam::123456789012:role/agent"},
+		"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{
+			"cpu": map[string]interface{}{"measurement": []string{"usage_idle", "usage_user", "usage_idle"}},
+			"mem": map[string]interface{}{"measurement": []interface{}{
+				map[string]interface{}{"name": "mem_used_percent", "rename": "MemoryUsed"},
+				"mem_used_percent",
+			}},
+		}},
+		"logs": map[string]interface{}{"logs_collected": map[string]interface{}{
+			"files": map[string]interface{}{"collect_list": []map[string]interface{}{
+				{"file_path": `C:\logs\\app.log`, "log_group_name": "app"},
+				{"file_path": "C:/logs/app.log", "log_group_name": "app"},
+				{"file_path": " /var/log/messages", "log_group_name": "messages"},
+			}},
+		}},
+	}
+
+	finalized, changes, err := FinalizeConfig(m)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"moved agent.role_arn to agent.credentials.role_arn",
+		"converted 1 boolean strings to json booleans",
+		`normalized logs.logs_collected.files.collect_list[0].file_path from "C:\\logs\\\\app.log" to "C:/logs/app.log"`,
+		`normalized logs.logs_collected.files.collect_list[2].file_path from " /var/log/messages" to "/var/log/messages"`,
+		"removed 1 duplicate entries of logs.logs_collected.files.collect_list",
+		"removed the duplicate measurement usage_idle of metrics.metrics_collected.cpu.measurement",
+		"removed the duplicate measurement mem_used_percent of metrics.metrics_collected.mem.measurement",
+	}, changes)
+
+	var expected map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"agent": {"debug": true, "credentials": {"role_arn": "arn:aws:iam::123456789012:role/agent"}},
+		"metrics": {"metrics_collected": {
+			"cpu": {"measurement": ["usage_idle", "usage_user"]},
+			"mem": {"measurement": [{"name": "mem_used_percent", "rename": "MemoryUsed"}]}
+		}},
+		"logs": {"logs_collected": {"files": {"collect_list": [
+			{"file_path": "C:/logs/app.log", "log_group_name": "app"},
+			{"file_path": "/var/log/messages", "log_group_name": "messages"}
+		]}}}
+	}`), &expected))
+	assert.Equal(t, expected, finalized)
+	assert.Equal(t, "yes", m["agent"].(map[string]interface{})["debug"], "the original config must not change")
+
+	finalized, changes, err = FinalizeConfig(finalized)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, expected, finalized)
+}
+
+func TestFinalizeConfigError(t *testing.T) {
+	_, _, err := FinalizeConfig(map[string]interface{}{
+		"agent": map[string]interface{}{
+			"role_arn":    "arn:aws:iam::123456789012:role/a",
+			"credentials": map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/b"},
+		},
+	})
+	assert.ErrorContains(t, err, "agent.role_arn cannot be moved")
+
+	_, _, err = FinalizeConfig(map[string]interface{}{"agent": make(chan int)})
+	assert.ErrorContains(t, err, "cannot be serialized")
+}
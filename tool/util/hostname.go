@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const maxHostnameLength = 253
+
+var hostnameLabelPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+
+// DetectHostname returns the hostname the agent reports for the {hostname} placeholder and host dimension.
+// The fully qualified name is preferred when a reverse lookup of the host's own address resolves to one,
+// otherwise the short name from the OS is returned.
+func DetectHostname() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine hostname: %w", err)
+	}
+	if strings.Contains(hostname, ".") {
+		return hostname, nil
+	}
+	addrs, err := net.LookupHost(hostname)
+	if err != nil {
+		return hostname, nil
+	}
+	for _, addr := range addrs {
+		names, err := net.LookupAddr(addr)
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			name = strings.TrimSuffix(name, ".")
+			if strings.HasPrefix(name, hostname+".") {
+				return name, nil
+			}
+		}
+	}
+	return hostname, nil
+}
+
+// ValidateHostname checks that name is a valid RFC 1123 hostname.
+func ValidateHostname(name string) error {
+	if name == "" || len(name) > maxHostnameLength {
+		return fmt.Errorf("hostname must be between 1 and %d characters", maxHostnameLength)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if !hostnameLabelPattern.MatchString(label) {
+			return fmt.Errorf("hostname label %q must be 1 to 63 letters, digits or hyphens and must not start or end with a hyphen", label)
+		}
+	}
+	return nil
+}
+
+// AskHostnameOverride lets the user keep the detected hostname or replace it with their own.
+// When no hostname could be detected, the user must provide one.
+func AskHostnameOverride(detected string) (string, error) {
+	return askValidated("Which hostname should the agent report?", detected, ValidateHostname)
+}
@@ -0,0 +1,21 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "fmt"
+
+// AskOmitHostname asks whether to leave the host dimension off the metrics and returns the value for
+// the omit_hostname field of the agent section. Every metric is published once per host by default,
+// so omitting it lowers the number of metrics when the hosts are interchangeable.
+func AskOmitHostname() (bool, error) {
+	PrintHint("The agent adds a host dimension to every metric, which creates a separate set of metrics per host.")
+	PrintDetail("Omitting it lowers the metric count when many hosts run the same workload, " +
+		"but the metrics of different hosts are then combined unless another dimension such as InstanceId tells them apart.")
+	omit := No("Do you want to omit the host dimension from the metrics?")
+	if omit {
+		fmt.Println("W! Without the host dimension, metrics from different hosts can only be told apart through append_dimensions, " +
+			"which are only resolved on EC2 instances.")
+	}
+	return omit, nil
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskOmitHostname(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	omit, err := AskOmitHostname()
+	assert.NoError(t, err)
+	assert.False(t, omit)
+
+	testutil.Type(inputChan, "1")
+	omit, err = AskOmitHostname()
+	assert.NoError(t, err)
+	assert.True(t, omit)
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestDetectHostname(t *testing.T) {
+	expected, err := os.Hostname()
+	assert.NoError(t, err)
+
+	hostname, err := DetectHostname()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hostname, expected))
+}
+
+func TestValidateHostname(t *testing.T) {
+	for _, name := range []string{"localhost", "ip-10-0-0-1", "ip-10-0-0-1.ec2.internal", "web01"} {
+		assert.NoError(t, ValidateHostname(name), name)
+	}
+	for _, name := range []string{"", "-web", "web-", "web..internal", "web_01", "web 01", strings.Repeat("a", 64)} {
+		assert.Error(t, ValidateHostname(name), name)
+	}
+}
+
+func TestAskHostnameOverride(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	hostname, err := AskHostnameOverride("ip-10-0-0-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ip-10-0-0-1", hostname)
+
+	testutil.Type(inputChan, "bad_host", "web01.example.com")
+	hostname, err = AskHostnameOverride("ip-10-0-0-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "web01.example.com", hostname)
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+)
+
+const (
+	// imdsIPv6Endpoint is where the instance metadata service listens on instances with IPv6 enabled.
+	imdsIPv6Endpoint = "http://[fd00:ec2::254]"
+	// defaultIMDSTimeout is the timeout the SDK gives metadata requests.
+	defaultIMDSTimeout = time.Second
+)
+
+// ErrNoEC2Region is returned by DefaultEC2Region when the instance metadata service has no region.
+var ErrNoEC2Region = errors.New("the instance metadata service returned no region")
+
+var (
+	interfaceAddrs = net.InterfaceAddrs
+	forceIMDSIPv6  bool
+	// imdsEndpoint overrides the metadata endpoint, so tests can use a fake metadata service.
+	imdsEndpoint string
+	imdsTimeout  = defaultIMDSTimeout
+	imdsRetries  = -1
+)
+
+// SetIMDSOptions sets the timeout of each instance metadata request and how many times a failed request
+// is retried, e.g. to wait out the network settling while an instance boots. A timeout that is not
+// positive restores the default of one second, and negative retries restore the number of retries of
+// the agent, which can be set with the IMDS_NUMBER_RETRY environment variable.
+func SetIMDSOptions(timeout time.Duration, retries int) {
+	if timeout <= 0 {
+		timeout = defaultIMDSTimeout
+	}
+	imdsTimeout = timeout
+	imdsRetries = retries
+}
+
+func imdsRetryNumber() int {
+	if imdsRetries < 0 {
+		return retryer.GetDefaultRetryNumber()
+	}
+	return imdsRetries
+}
+
+// SetIMDSIPv6 forces the wizard to reach the instance metadata service over IPv6 even when the host
+// also has an IPv4 address.
+func SetIMDSIPv6(force bool) {
+	forceIMDSIPv6 = force
+}
+
+// IsIPv6Only is true when the host has a global IPv6 address but no IPv4 address apart from loopback
+// and link local ones, in which case the default IPv4 metadata endpoint cannot be reached.
+func IsIPv6Only() bool {
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return false
+	}
+	hasIPv6 := false
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return false
+		}
+		hasIPv6 = true
+	}
+	return hasIPv6
+}
+
+// imdsEndpointMode picks the IPv6 metadata endpoint when it was forced or when the host is IPv6 only.
+func imdsEndpointMode() endpoints.EC2IMDSEndpointModeState {
+	if forceIMDSIPv6 {
+		return endpoints.EC2IMDSEndpointModeStateIPv6
+	}
+	if IsIPv6Only() {
+		fmt.Printf("W! No IPv4 address was found on this host, using the IPv6 instance metadata endpoint %s. "+
+			"Make sure the IPv6 endpoint is enabled in the instance metadata options.\n", imdsIPv6Endpoint)
+		return endpoints.EC2IMDSEndpointModeStateIPv6
+	}
+	return endpoints.EC2IMDSEndpointModeStateUnset
+}
@@ -0,0 +1,118 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/stretchr/testify/assert"
+)
+
+func setUpInterfaceAddrs(t *testing.T, cidrs ...string) {
+	var addrs []net.Addr
+	for _, cidr := range cidrs {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		assert.NoError(t, err)
+		ipNet.IP = ip
+		addrs = append(addrs, ipNet)
+	}
+	original := interfaceAddrs
+	interfaceAddrs = func() ([]net.Addr, error) { return addrs, nil }
+	t.Cleanup(func() { interfaceAddrs = original })
+}
+
+func TestIsIPv6Only(t *testing.T) {
+	setUpInterfaceAddrs(t, "127.0.0.1/8", "::1/128", "fe80::1/64", "2600:1f14::10/64")
+	assert.True(t, IsIPv6Only())
+
+	setUpInterfaceAddrs(t, "127.0.0.1/8", "10.0.0.5/24", "2600:1f14::10/64")
+	assert.False(t, IsIPv6Only())
+
+	setUpInterfaceAddrs(t, "127.0.0.1/8", "::1/128")
+	assert.False(t, IsIPv6Only())
+}
+
+func TestIMDSEndpointMode(t *testing.T) {
+	t.Cleanup(func() { SetIMDSIPv6(false) })
+
+	setUpInterfaceAddrs(t, "10.0.0.5/24")
+	assert.Equal(t, endpoints.EC2IMDSEndpointModeStateUnset, imdsEndpointMode())
+
+	SetIMDSIPv6(true)
+	assert.Equal(t, endpoints.EC2IMDSEndpointModeStateIPv6, imdsEndpointMode())
+
+	SetIMDSIPv6(false)
+	setUpInterfaceAddrs(t, "2600:1f14::10/64")
+	assert.Equal(t, endpoints.EC2IMDSEndpointModeStateIPv6, imdsEndpointMode())
+}
+
+// fakeIMDS serves the instance identity document with region only to requests that carry the session
+// token, like an instance that requires IMDSv2.
+func fakeIMDS(t *testing.T, region string) *httptest.Server {
+	const token = "fake-token"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			w.Header().Set("x-aws-ec2-metadata-token-ttl-seconds", r.Header.Get("x-aws-ec2-metadata-token-ttl-seconds"))
+			w.Write([]byte(token))
+		case r.Header.Get("x-aws-ec2-metadata-token") != token:
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/latest/dynamic/instance-identity/document":
+			w.Write([]byte(`{"region":"` + region + `","instanceId":"i-1234567890abcdef0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func setUpIMDS(t *testing.T, endpoint string) {
+	setUpInterfaceAddrs(t, "10.0.0.5/24")
+	imdsEndpoint = endpoint
+	SetIMDSOptions(100*time.Millisecond, 0)
+	t.Cleanup(func() {
+		imdsEndpoint = ""
+		SetIMDSOptions(0, -1)
+	})
+}
+
+func TestDefaultEC2Region(t *testing.T) {
+	setUpIMDS(t, fakeIMDS(t, "eu-west-1").URL)
+	region, err := DefaultEC2Region()
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", region)
+
+	setUpIMDS(t, fakeIMDS(t, "").URL)
+	_, err = DefaultEC2Region()
+	assert.ErrorIs(t, err, ErrNoEC2Region)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(time.Second)
+	}))
+	defer server.Close()
+	setUpIMDS(t, server.URL)
+	start := time.Now()
+	_, err = DefaultEC2Region()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNoEC2Region)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestSetIMDSOptions(t *testing.T) {
+	defer SetIMDSOptions(0, -1)
+	t.Setenv("IMDS_NUMBER_RETRY", "3")
+	SetIMDSOptions(0, -1)
+	assert.Equal(t, defaultIMDSTimeout, imdsTimeout)
+	assert.Equal(t, 3, imdsRetryNumber())
+
+	SetIMDSOptions(5*time.Second, 0)
+	assert.Equal(t, 5*time.Second, imdsTimeout)
+	assert.Equal(t, 0, imdsRetryNumber())
+}
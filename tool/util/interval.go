@@ -0,0 +1,120 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// highResolutionIntervals are the sub-minute periods CloudWatch supports for high resolution metrics.
+var highResolutionIntervals = []int{1, 5, 10, 30}
+
+// metricsCollectionInterval is the agent-wide interval chosen in the wizard, in seconds.
+var metricsCollectionInterval = defaultMetricsCollectionInterval
+
+// SetMetricsCollectionInterval records the agent-wide interval so that per metric prompts can offer it
+// as their default.
+func SetMetricsCollectionInterval(interval int) {
+	metricsCollectionInterval = interval
+}
+
+// ValidateResolution checks that a metrics_collection_interval (in seconds) maps onto a period CloudWatch
+// can store: one of the high resolution periods below a minute, or a whole number of minutes.
+func ValidateResolution(interval int) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be a positive number of seconds, got %d", interval)
+	}
+	if interval >= 60 {
+		if interval%60 != 0 {
+			return fmt.Errorf("interval %ds must be a multiple of 60 seconds", interval)
+		}
+		return nil
+	}
+	for _, valid := range highResolutionIntervals {
+		if interval == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("sub-minute interval %ds must be one of %v seconds", interval, highResolutionIntervals)
+}
+
+// ParseDuration converts an interval typed by the user into whole seconds. It accepts a plain integer
+// number of seconds ("60") as well as a Go duration string ("30s", "1m", "1m30s"). Durations that are
+// negative, shorter than a second, or not a whole number of seconds are rejected.
+func ParseDuration(input string) (int, error) {
+	input = strings.TrimSpace(input)
+	if seconds, err := strconv.Atoi(input); err == nil {
+		if seconds < 1 {
+			return 0, fmt.Errorf("interval must be at least 1 second, got %d", seconds)
+		}
+		return seconds, nil
+	}
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, fmt.Errorf("%q is neither a number of seconds nor a duration such as 30s or 1m", input)
+	}
+	if duration < time.Second {
+		return 0, fmt.Errorf("interval must be at least 1 second, got %s", duration)
+	}
+	if duration%time.Second != 0 {
+		return 0, fmt.Errorf("interval must be a whole number of seconds, got %s", duration)
+	}
+	return int(duration / time.Second), nil
+}
+
+// AskMetricsCollectionInterval prompts for the agent-wide metrics_collection_interval. The answer can be
+// given in seconds or as a duration such as "1m". An empty answer keeps the default of 60 seconds.
+func AskMetricsCollectionInterval() (int, error) {
+	question := "What metrics_collection_interval (e.g. 60 or 1m) do you want for all metrics? " +
+		"Intervals under a minute collect them at high resolution, and you can customize it for specific metrics in the output json file."
+	PrintDetail("Intervals of %v seconds are published as high resolution metrics, which are billed at a higher rate. "+
+		"Longer intervals must be whole minutes.", highResolutionIntervals)
+	answer, err := askValidated(question, strconv.Itoa(defaultMetricsCollectionInterval), validateIntervalAnswer)
+	if err != nil {
+		return 0, err
+	}
+	return ParseDuration(answer)
+}
+
+// AskPluginInterval prompts for the metrics_collection_interval of a single plugin, overriding the
+// agent-wide interval. The answer can be given in seconds or as a duration such as "1m". An empty
+// answer keeps defaultInterval.
+func AskPluginInterval(pluginName string, defaultInterval int) (int, error) {
+	question := fmt.Sprintf("What metrics_collection_interval (e.g. 60 or 1m) do you want for %s?", pluginName)
+	PrintHint("This overrides the agent-wide interval for %s only.", pluginName)
+	PrintDetail("Intervals of %v seconds are published as high resolution metrics, which are billed at a higher rate. "+
+		"Longer intervals must be whole minutes.", highResolutionIntervals)
+	answer, err := askValidated(question, strconv.Itoa(defaultInterval), validateIntervalAnswer)
+	if err != nil {
+		return 0, err
+	}
+	return ParseDuration(answer)
+}
+
+// AskPerMetricResolution prompts for the metrics_collection_interval of each of metricNames in turn,
+// defaulting to the agent-wide interval, and returns the interval per name. The agent only takes an
+// interval per plugin, so the names are the keys under metrics_collected (cpu, disk, ...) and the
+// intervals go into those sections.
+func AskPerMetricResolution(metricNames []string) (map[string]int, error) {
+	intervals := make(map[string]int, len(metricNames))
+	for _, name := range metricNames {
+		interval, err := AskPluginInterval(name, metricsCollectionInterval)
+		if err != nil {
+			return nil, err
+		}
+		intervals[name] = interval
+	}
+	return intervals, nil
+}
+
+func validateIntervalAnswer(answer string) error {
+	interval, err := ParseDuration(answer)
+	if err != nil {
+		return err
+	}
+	return ValidateResolution(interval)
+}
@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestValidateResolution(t *testing.T) {
+	for _, interval := range []int{1, 5, 10, 30, 60, 120, 300} {
+		assert.NoError(t, ValidateResolution(interval), interval)
+	}
+	for _, interval := range []int{-1, 0, 2, 45, 61, 90} {
+		assert.Error(t, ValidateResolution(interval), interval)
+	}
+}
+
+func TestAskPluginInterval(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	interval, err := AskPluginInterval("cpu", 60)
+	assert.NoError(t, err)
+	assert.Equal(t, 60, interval)
+
+	testutil.Type(inputChan, "abc", "45", "10")
+	interval, err = AskPluginInterval("cpu", 60)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, interval)
+
+	testutil.Type(inputChan, "90s", "2m")
+	interval, err = AskPluginInterval("cpu", 60)
+	assert.NoError(t, err)
+	assert.Equal(t, 120, interval)
+}
+
+func TestAskMetricsCollectionInterval(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	interval, err := AskMetricsCollectionInterval()
+	assert.NoError(t, err)
+	assert.Equal(t, 60, interval)
+
+	testutil.Type(inputChan, "15", "90s", "30s")
+	interval, err = AskMetricsCollectionInterval()
+	assert.NoError(t, err)
+	assert.Equal(t, 30, interval)
+
+	testutil.Type(inputChan, "5m")
+	interval, err = AskMetricsCollectionInterval()
+	assert.NoError(t, err)
+	assert.Equal(t, 300, interval)
+}
+
+func TestAskPerMetricResolution(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	defer SetMetricsCollectionInterval(defaultMetricsCollectionInterval)
+
+	testutil.Type(inputChan, "1s", "45", "")
+	intervals, err := AskPerMetricResolution([]string{"cpu", "disk"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"cpu": 1, "disk": 60}, intervals)
+
+	SetMetricsCollectionInterval(10)
+	testutil.Type(inputChan, "")
+	intervals, err = AskPerMetricResolution([]string{"mem"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"mem": 10}, intervals)
+
+	intervals, err = AskPerMetricResolution(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, intervals)
+}
+
+func TestParseDuration(t *testing.T) {
+	testCases := map[string]int{
+		"60":    60,
+		" 10 ":  10,
+		"30s":   30,
+		"1m":    60,
+		"1m30s": 90,
+		"1h":    3600,
+	}
+	for input, expected := range testCases {
+		seconds, err := ParseDuration(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, seconds, input)
+	}
+	for _, input := range []string{"", "0", "-5", "-1m", "500ms", "1.5s", "0s", "one minute"} {
+		_, err := ParseDuration(input)
+		assert.Error(t, err, input)
+	}
+}
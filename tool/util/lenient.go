@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DetectTrailingCommas returns the byte offsets of the commas directly followed, apart from
+// whitespace, by the closing brace or bracket of their object or array. Commas inside strings are
+// ignored. An error is returned when a string is not terminated.
+func DetectTrailingCommas(b []byte) ([]int, error) {
+	var offsets []int
+	pendingComma := -1
+	inString, escaped := false, false
+	stringStart := 0
+	for i, c := range b {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '}', ']':
+			if pendingComma >= 0 {
+				offsets = append(offsets, pendingComma)
+			}
+		case '"':
+			inString, stringStart = true, i
+		}
+		pendingComma = -1
+		if c == ',' {
+			pendingComma = i
+		}
+	}
+	if inString {
+		line, column := lineColumn(b, stringStart)
+		return offsets, fmt.Errorf("string starting at line %d, column %d is not terminated", line, column)
+	}
+	return offsets, nil
+}
+
+// lineColumn converts a byte offset into a 1 based line and column.
+func lineColumn(b []byte, offset int) (int, int) {
+	before := b[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// ReadConfigFromJsonFileLenient reads the json config at ConfigFilePath like ReadConfigFromJsonFile,
+// but accepts trailing commas, which hand edited configs often have: they are reported with their
+// line and column and removed from the returned content. Other syntax errors are returned with
+// their location.
+func ReadConfigFromJsonFileLenient() (string, error) {
+	filePath := ConfigFilePath()
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error in reading config from file %s: %w", filePath, err)
+	}
+	if json.Valid(content) {
+		return string(content), nil
+	}
+	offsets, err := DetectTrailingCommas(content)
+	if err != nil {
+		return "", fmt.Errorf("error in parsing json config %s: %w", filePath, err)
+	}
+	var locations []string
+	cleaned := make([]byte, 0, len(content))
+	previous := 0
+	for _, offset := range offsets {
+		line, column := lineColumn(content, offset)
+		locations = append(locations, fmt.Sprintf("line %d, column %d", line, column))
+		cleaned = append(cleaned, content[previous:offset]...)
+		previous = offset + 1
+	}
+	cleaned = append(cleaned, content[previous:]...)
+	if err = syntaxError(cleaned); err != nil {
+		return "", fmt.Errorf("error in parsing json config %s: %w", filePath, err)
+	}
+	fmt.Printf("W! Ignored trailing commas in %s at %s\n", filePath, strings.Join(locations, "; "))
+	return string(cleaned), nil
+}
+
+// syntaxError returns the json syntax error of b, if any, with its line and column.
+func syntaxError(b []byte) error {
+	var v interface{}
+	err := json.Unmarshal(b, &v)
+	var jsonErr *json.SyntaxError
+	if errors.As(err, &jsonErr) {
+		line, column := lineColumn(b, int(jsonErr.Offset))
+		return fmt.Errorf("%w at line %d, column %d", err, line, column)
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectTrailingCommas(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  []int
+	}{
+		"Valid":       {input: `{"a":[1,2],"b":{"c":1}}`},
+		"Object":      {input: `{"a":1,}`, want: []int{6}},
+		"Array":       {input: `{"a":[1,2,]}`, want: []int{9}},
+		"Whitespace":  {input: "{\"a\":[1,\n\t],\n}", want: []int{7, 11}},
+		"InString":    {input: `{"a":",}","b":"\",]"}`},
+		"EmptyObject": {input: `{,}`, want: []int{1}},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			offsets, err := DetectTrailingCommas([]byte(testCase.input))
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, offsets)
+		})
+	}
+
+	_, err := DetectTrailingCommas([]byte(`{"a":"unterminated}`))
+	assert.ErrorContains(t, err, "line 1, column 6")
+}
+
+func TestLineColumn(t *testing.T) {
+	b := []byte("{\n  \"a\": 1,\n}")
+	line, column := lineColumn(b, 10)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, 9, column)
+	line, column = lineColumn(b, 0)
+	assert.Equal(t, 1, line)
+	assert.Equal(t, 1, column)
+}
+
+func TestReadConfigFromJsonFileLenient(t *testing.T) {
+	filePath := ConfigFilePath()
+	t.Cleanup(func() { os.Remove(filePath) })
+
+	require.NoError(t, os.WriteFile(filePath, []byte("{\n\t\"agent\": {\n\t\t\"debug\": true,\n\t},\n}"), 0644))
+	content, err := ReadConfigFromJsonFileLenient()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"agent":{"debug":true}}`, content)
+
+	require.NoError(t, os.WriteFile(filePath, []byte("{\n\t\"agent\": {\n\t\t\"debug\" true\n\t}\n}"), 0644))
+	_, err = ReadConfigFromJsonFileLenient()
+	assert.ErrorContains(t, err, "line 3")
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "fmt"
+
+const (
+	// smallInstanceCPUs and smallInstanceMemory are the core count and RAM in bytes at or below which
+	// compressing log payloads takes a noticeable share of the host.
+	smallInstanceCPUs   = 1
+	smallInstanceMemory = 1 << 30
+)
+
+// systemMemory is how the RAM of the host is read, so tests can pretend to run on another host.
+var systemMemory = SystemMemory
+
+// AskLogCompression asks whether to gzip the log events sent to CloudWatch Logs, after explaining that
+// it lowers the data transferred at the cost of CPU, and warns first when this host is small enough for
+// that cost to matter. The agent compresses PutLogEvents payloads whenever that makes them smaller and
+// the logs section has no field to turn it off, so a config declining compression cannot be written:
+// the answer is returned for the caller to act on, and declining prints how much of the cost remains.
+func AskLogCompression() (bool, error) {
+	PrintHint("Compressing log events reduces the data transferred to CloudWatch Logs, and its cost, but uses more CPU.")
+	if warning := WarnSmallInstance(numCPU(), readSystemMemory()); warning != "" {
+		fmt.Println(warning)
+	}
+	compress := Yes("Do you want the agent to compress the log events it sends?")
+	if !compress {
+		fmt.Println("The agent compresses log events whenever it makes them smaller and this cannot be turned off in the config, " +
+			"reduce the CPU it uses by collecting fewer or smaller log events instead.")
+	}
+	return compress, nil
+}
+
+// WarnSmallInstance returns a warning when a host with cpus cores and totalMemory bytes of RAM is small
+// enough for compression to take a noticeable share of its CPU, or "" otherwise. A totalMemory of 0
+// means the memory is unknown and only the cores are considered.
+func WarnSmallInstance(cpus int, totalMemory uint64) string {
+	if cpus > smallInstanceCPUs && (totalMemory == 0 || totalMemory > smallInstanceMemory) {
+		return ""
+	}
+	return fmt.Sprintf("W! This host has %d CPU cores and %d MB of memory, compressing a high volume of log events can take a noticeable share of its CPU.",
+		cpus, totalMemory/1024/1024)
+}
+
+func readSystemMemory() uint64 {
+	totalMemory, err := systemMemory()
+	if err != nil {
+		PrintDetail("%v", err)
+		return 0
+	}
+	return totalMemory
+}
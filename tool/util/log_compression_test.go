@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskLogCompression(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	originalCPU, originalMemory := numCPU, systemMemory
+	defer func() { numCPU, systemMemory = originalCPU, originalMemory }()
+	numCPU = func() int { return 1 }
+	systemMemory = func() (uint64, error) { return 0, errors.New("not implemented yet") }
+
+	testutil.Type(inputChan, "")
+	compress, err := AskLogCompression()
+	assert.NoError(t, err)
+	assert.True(t, compress)
+
+	testutil.Type(inputChan, "2")
+	compress, err = AskLogCompression()
+	assert.NoError(t, err)
+	assert.False(t, compress)
+}
+
+func TestWarnSmallInstance(t *testing.T) {
+	assert.Empty(t, WarnSmallInstance(4, 8<<30))
+	assert.Empty(t, WarnSmallInstance(2, 0))
+	assert.Equal(t, "W! This host has 1 CPU cores and 0 MB of memory, compressing a high volume of log events can take a noticeable share of its CPU.",
+		WarnSmallInstance(1, 0))
+	assert.Contains(t, WarnSmallInstance(2, 512<<20), "2 CPU cores and 512 MB of memory")
+}
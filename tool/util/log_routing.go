@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const maxLogGroupNameLength = 512
+
+// logGroupNameRegex matches the characters CloudWatch Logs allows in a log group name.
+var logGroupNameRegex = regexp.MustCompile(`^[\.\-_/#A-Za-z0-9]+$`)
+
+// ValidLogGroupName checks name can be used as a log group name. The placeholders the agent resolves,
+// such as {instance_id}, are accepted.
+func ValidLogGroupName(name string) error {
+	if name == "" {
+		return fmt.Errorf("the log group name must not be empty")
+	}
+	literal := name
+	for _, placeholder := range logStreamPlaceholders {
+		literal = strings.ReplaceAll(literal, placeholder, "")
+	}
+	if literal != "" && !logGroupNameRegex.MatchString(literal) {
+		return fmt.Errorf("the log group name may only contain letters, digits and the characters . - _ / #")
+	}
+	if len(literal) > maxLogGroupNameLength {
+		return fmt.Errorf("the log group name must be at most %d characters, got %d", maxLogGroupNameLength, len(literal))
+	}
+	return nil
+}
+
+// AskLogRouting prompts for the log groups the lines of a log file are also published to depending on
+// their content. The agent has no routing of its own, so each destination is a collect_list entry of its
+// own for the same file_path, with the log_group_name of the destination and an include filter publishing
+// only the lines matching the expression. The entries returned lack the file_path and the other settings
+// of the file, which the caller adds. A line matching several expressions is published to every one of
+// their log groups, and every destination is in the account the agent sends to. The entry of the log group
+// of the file gets the filters of LogRoutingDefaultFilters so that it keeps the lines routed nowhere else.
+// An empty list is returned when no routing is wanted.
+func AskLogRouting() ([]map[string]interface{}, error) {
+	var routes []map[string]interface{}
+	if !No("Do you want to publish log lines to other log groups depending on their content?") {
+		return routes, nil
+	}
+	PrintHint("The file is collected once for every log group, each publishing only the lines matching its expression. The log group of the file keeps the lines matching none of them.")
+	expressions := make(map[string]bool)
+	for {
+		expression, err := askValidated("Filter expression of the lines to publish to the log group (regular expression):", "", func(answer string) error {
+			if expressions[answer] {
+				return fmt.Errorf("the lines matching %s are already published to another log group", answer)
+			}
+			return ValidateLogFilter(answer)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if regexp.MustCompile(expression).MatchString("") {
+			fmt.Printf("W! The expression %s matches empty lines, so every line is published to the log group.\n", expression)
+		}
+		logGroupName, err := askValidated("Log group name of the lines:", "", ValidLogGroupName)
+		if err != nil {
+			return nil, err
+		}
+		expressions[expression] = true
+		routes = append(routes, map[string]interface{}{
+			"log_group_name": logGroupName,
+			"filters":        []map[string]string{{"type": LogFilterInclude, "expression": expression}},
+		})
+		if !No("Do you want to publish other lines to another log group?") {
+			return routes, nil
+		}
+	}
+}
+
+// LogRoutingDefaultFilters returns the exclude filters dropping the lines the routes of AskLogRouting
+// publish elsewhere, for the entry of the log group of the file.
+func LogRoutingDefaultFilters(routes []map[string]interface{}) []map[string]string {
+	var filters []map[string]string
+	for _, route := range routes {
+		routeFilters, _ := route["filters"].([]map[string]string)
+		for _, filter := range routeFilters {
+			if filter["type"] == LogFilterInclude {
+				filters = append(filters, map[string]string{"type": LogFilterExclude, "expression": filter["expression"]})
+			}
+		}
+	}
+	return filters
+}
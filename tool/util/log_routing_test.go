@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestValidLogGroupName(t *testing.T) {
+	for _, name := range []string{"app", "/aws/ec2/{instance_id}/errors", "team#1.audit-log_v2", "{hostname}"} {
+		assert.NoError(t, ValidLogGroupName(name), name)
+	}
+	for _, name := range []string{"", "app logs", "app:errors", "app*", strings.Repeat("a", 513)} {
+		assert.Error(t, ValidLogGroupName(name), name)
+	}
+}
+
+func TestAskLogRouting(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	routes, err := AskLogRouting()
+	assert.NoError(t, err)
+	assert.Empty(t, routes)
+	assert.Empty(t, LogRoutingDefaultFilters(routes))
+
+	testutil.Type(inputChan, "1",
+		"(ERROR", "ERROR", "app errors", "app-errors", "1",
+		"ERROR", "AUDIT", "audit", "")
+	routes, err = AskLogRouting()
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"log_group_name": "app-errors", "filters": []map[string]string{{"type": "include", "expression": "ERROR"}}},
+		{"log_group_name": "audit", "filters": []map[string]string{{"type": "include", "expression": "AUDIT"}}},
+	}, routes)
+	assert.Equal(t, []map[string]string{
+		{"type": "exclude", "expression": "ERROR"},
+		{"type": "exclude", "expression": "AUDIT"},
+	}, LogRoutingDefaultFilters(routes))
+}
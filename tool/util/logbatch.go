@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// PutLogEvents limits, which the agent batches up to before sending.
+	putLogEventsMaxBytes  = 1024 * 1024
+	putLogEventsMaxEvents = 10000
+
+	defaultLogsForceFlushInterval = 5
+	// maxForceFlushInterval is the largest force_flush_interval the config schema accepts.
+	maxForceFlushInterval = 172800
+)
+
+// AskLogBatchSettings prompts for how often the agent publishes log events and returns the fragment for
+// the logs section. The agent sends a batch as soon as it reaches the PutLogEvents limits of 1 MB or
+// 10000 events, so the size of a batch cannot be set beyond those; what can be tuned is
+// force_flush_interval, the longest a batch waits before it is sent.
+func AskLogBatchSettings() (map[string]interface{}, error) {
+	PrintHint("The agent sends log events once a batch reaches %d bytes or %d events, or when the flush interval passes, whichever comes first.",
+		putLogEventsMaxBytes, putLogEventsMaxEvents)
+	PrintDetail("A longer interval sends fewer, larger requests but delays the events in CloudWatch Logs.")
+	answer, err := askValidated("How many seconds should log events wait at most before they are published (force_flush_interval)?",
+		strconv.Itoa(defaultLogsForceFlushInterval), validateForceFlushInterval)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := ParseDuration(answer)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"force_flush_interval": interval}, nil
+}
+
+func validateForceFlushInterval(answer string) error {
+	interval, err := ParseDuration(answer)
+	if err != nil {
+		return err
+	}
+	if interval > maxForceFlushInterval {
+		return fmt.Errorf("force_flush_interval must be at most %d seconds, got %d", maxForceFlushInterval, interval)
+	}
+	return nil
+}
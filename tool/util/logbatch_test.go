@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskLogBatchSettings(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	settings, err := AskLogBatchSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"force_flush_interval": defaultLogsForceFlushInterval}, settings)
+
+	testutil.Type(inputChan, "0", "500ms", "172801", "1m")
+	settings, err = AskLogBatchSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"force_flush_interval": 60}, settings)
+}
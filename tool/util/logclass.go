@@ -0,0 +1,18 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "fmt"
+
+// AskLogClass prompts for the class of the log group the agent creates, either StandardLogGroupClass
+// or InfrequentAccessLogGroupClass.
+func AskLogClass() (string, error) {
+	PrintHint("%s log groups support every CloudWatch Logs feature. %s log groups cost about half as much to ingest, "+
+		"but do not support metric filters, subscription filters, Live Tail or anomaly detection.", StandardLogGroupClass, InfrequentAccessLogGroupClass)
+	logGroupClass := Choice("Log group class:", 1, []string{StandardLogGroupClass, InfrequentAccessLogGroupClass})
+	if logGroupClass != StandardLogGroupClass && logGroupClass != InfrequentAccessLogGroupClass {
+		return "", fmt.Errorf("%s is not a valid log group class", logGroupClass)
+	}
+	return logGroupClass, nil
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskLogClass(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	logGroupClass, err := AskLogClass()
+	assert.NoError(t, err)
+	assert.Equal(t, StandardLogGroupClass, logGroupClass)
+
+	testutil.Type(inputChan, "3", "2")
+	logGroupClass, err = AskLogClass()
+	assert.NoError(t, err)
+	assert.Equal(t, InfrequentAccessLogGroupClass, logGroupClass)
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// fileDescriptorLimit returns the limit of open files of this process, or 0 when there is none.
+var fileDescriptorLimit = openFileLimit
+
+// WarnLogFileCount estimates how many files the collect_list entries of the config monitor and warns
+// when that is more than half of the open file limit of this host, since the agent keeps every
+// monitored file open and needs descriptors for its connections too. When glob is true, file paths
+// with wildcards are expanded to count the files they match right now, otherwise each entry counts
+// as a single file. The warning includes both the estimate and the limit.
+func WarnLogFileCount(m map[string]interface{}, glob bool) []string {
+	files := getMap(m, "logs", "logs_collected", "files")
+	if files == nil {
+		return nil
+	}
+	count := 0
+	for _, entry := range getMapList(files, collectListKey) {
+		filePath := getString(entry, filePathKey)
+		if filePath == "" {
+			continue
+		}
+		if !glob || !strings.ContainsAny(filePath, "*?[") {
+			count++
+			continue
+		}
+		matches, err := filepath.Glob(filePath)
+		if err != nil {
+			count++
+			continue
+		}
+		count += len(matches)
+	}
+
+	limit, err := fileDescriptorLimit()
+	if err != nil || limit == 0 {
+		return nil
+	}
+	if uint64(count) <= limit/2 {
+		return nil
+	}
+	return []string{fmt.Sprintf("the config monitors about %d log files, more than half of the open file limit of %d on this host. Raise the limit (ulimit -n) or narrow the file paths.", count, limit)}
+}
@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarnLogFileCount(t *testing.T) {
+	original := fileDescriptorLimit
+	t.Cleanup(func() { fileDescriptorLimit = original })
+	fileDescriptorLimit = func() (uint64, error) { return 8, nil }
+
+	dir := t.TempDir()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("app-%d.log", i)), []byte("line"), 0644))
+	}
+	m := map[string]interface{}{
+		"logs": map[string]interface{}{
+			"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{
+					"collect_list": []interface{}{
+						map[string]interface{}{"file_path": filepath.Join(dir, "*.log")},
+						map[string]interface{}{"file_path": "/var/log/messages"},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Empty(t, WarnLogFileCount(m, false))
+	assert.Equal(t, []string{"the config monitors about 6 log files, more than half of the open file limit of 8 on this host. Raise the limit (ulimit -n) or narrow the file paths."}, WarnLogFileCount(m, true))
+
+	fileDescriptorLimit = func() (uint64, error) { return 1024, nil }
+	assert.Empty(t, WarnLogFileCount(m, true))
+	assert.Empty(t, WarnLogFileCount(map[string]interface{}{}, true))
+}
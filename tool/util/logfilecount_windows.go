@@ -0,0 +1,12 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+// +build windows
+
+package util
+
+// openFileLimit returns 0 since windows has no per process limit of open files to check against.
+func openFileLimit() (uint64, error) {
+	return 0, nil
+}
@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	LogFilterInclude = "include"
+	LogFilterExclude = "exclude"
+)
+
+// ValidateLogFilter checks pattern compiles as the regular expression of a log filter. The agent uses
+// Go's RE2 engine, which matches in linear time, so there are no catastrophically slow patterns to
+// reject; patterns that cannot be compiled, e.g. because of unbalanced groups, lookarounds or
+// backreferences, are reported instead.
+func ValidateLogFilter(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("the filter expression must not be empty")
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("%q is not a valid regular expression: %w", pattern, err)
+	}
+	return nil
+}
+
+// AskLogFilters prompts for the include and exclude filters of a log file, in the order the agent
+// applies them. An empty list is returned when no filter is wanted.
+func AskLogFilters() ([]map[string]string, error) {
+	var filters []map[string]string
+	if !No("Do you want to filter which log lines are published?") {
+		return filters, nil
+	}
+	PrintHint("An include filter only publishes the lines matching its expression, an exclude filter drops them.")
+	for {
+		filterType := Choice("Filter type:", 1, []string{LogFilterInclude, LogFilterExclude})
+		expression, err := askValidated("Filter expression (regular expression):", "", ValidateLogFilter)
+		if err != nil {
+			return nil, err
+		}
+		if regexp.MustCompile(expression).MatchString("") {
+			fmt.Printf("W! The expression %s matches empty lines, so it matches every line.\n", expression)
+		}
+		filters = append(filters, map[string]string{"type": filterType, "expression": expression})
+		if !No("Do you want to add another filter?") {
+			return filters, nil
+		}
+	}
+}
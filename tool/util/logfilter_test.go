@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestValidateLogFilter(t *testing.T) {
+	for _, pattern := range []string{"ERROR", "^\\[(WARN|ERROR)\\]", "(a+)+$", "user=\\w+"} {
+		assert.NoError(t, ValidateLogFilter(pattern), pattern)
+	}
+	for _, pattern := range []string{"", "(unclosed", "[a-", "a{1001}", "(?=lookahead)", "(a)\\1"} {
+		assert.Error(t, ValidateLogFilter(pattern), pattern)
+	}
+}
+
+func TestAskLogFilters(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	filters, err := AskLogFilters()
+	assert.NoError(t, err)
+	assert.Empty(t, filters)
+
+	testutil.Type(inputChan, "1", "", "(ERROR", "ERROR", "1", "2", "healthcheck", "")
+	filters, err = AskLogFilters()
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"type": LogFilterInclude, "expression": "ERROR"},
+		{"type": LogFilterExclude, "expression": "healthcheck"},
+	}, filters)
+}
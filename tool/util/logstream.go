@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+const maxLogStreamNameLength = 512
+
+// logStreamPlaceholders are replaced by the agent before the log stream is created.
+var logStreamPlaceholders = []string{
+	"{instance_id}",
+	"{hostname}",
+	"{local_hostname}",
+	"{ip_address}",
+	"{aws_region}",
+	"{date}",
+	"{account_id}",
+}
+
+// ValidLogStreamName checks name can be used as a log stream name. CloudWatch Logs does not allow ':' or
+// '*' in stream names, but the placeholders the agent resolves, such as {instance_id}, are accepted.
+func ValidLogStreamName(name string) error {
+	if name == "" {
+		return fmt.Errorf("the log stream name must not be empty")
+	}
+	literal := name
+	for _, placeholder := range logStreamPlaceholders {
+		literal = strings.ReplaceAll(literal, placeholder, "")
+	}
+	if i := strings.IndexAny(literal, ":*"); i >= 0 {
+		return fmt.Errorf("the log stream name must not contain the character %q", literal[i])
+	}
+	if len(literal) > maxLogStreamNameLength {
+		return fmt.Errorf("the log stream name must be at most %d characters, got %d", maxLogStreamNameLength, len(literal))
+	}
+	return nil
+}
+
+// AskLogStreamName prompts for a log stream name, which defaults to defaultName, until it is valid.
+func AskLogStreamName(defaultName string) (string, error) {
+	return askValidated("Log stream name:", defaultName, ValidLogStreamName)
+}
@@ -0,0 +1,47 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestValidLogStreamName(t *testing.T) {
+	for _, name := range []string{"{instance_id}", "{hostname}-{date}", "app/{ip_address}_{aws_region}", "my stream.log", "{unknown}"} {
+		assert.NoError(t, ValidLogStreamName(name), name)
+	}
+	testCases := map[string]string{
+		"{instance_id}:app": `':'`,
+		"app*":              `'*'`,
+		"a:b*c":             `':'`,
+		"{date}*":           `'*'`,
+	}
+	for name, character := range testCases {
+		err := ValidLogStreamName(name)
+		if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), character, name)
+		}
+	}
+	assert.Error(t, ValidLogStreamName(""))
+	assert.Error(t, ValidLogStreamName(strings.Repeat("a", 513)))
+}
+
+func TestAskLogStreamName(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	name, err := AskLogStreamName("{instance_id}")
+	assert.NoError(t, err)
+	assert.Equal(t, "{instance_id}", name)
+
+	testutil.Type(inputChan, "web:1", "web*", "web-{hostname}")
+	name, err = AskLogStreamName("{instance_id}")
+	assert.NoError(t, err)
+	assert.Equal(t, "web-{hostname}", name)
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"reflect"
+)
+
+// The metric plugins of tool/data have a bool field per measurement the wizard can select, tagged with
+// the measurement name the field stands for, e.g.
+//
+//	UsageIdle bool `cpu_usage_idle`
+
+// MeasurementFields returns the measurement names of the bool fields of plugin, a pointer to a metric
+// plugin struct, in the order of the fields.
+func MeasurementFields(plugin interface{}) []string {
+	var names []string
+	v := reflect.ValueOf(plugin).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if field := v.Type().Field(i); field.Type.Kind() == reflect.Bool && field.Tag != "" {
+			names = append(names, string(field.Tag))
+		}
+	}
+	return names
+}
+
+// MeasurementField returns whether the bool field of plugin standing for measurement is set.
+func MeasurementField(plugin interface{}, measurement string) bool {
+	if field, ok := measurementField(plugin, measurement); ok {
+		return field.Bool()
+	}
+	return false
+}
+
+// SetMeasurementField sets the bool field of plugin standing for measurement, and returns false when
+// there is none.
+func SetMeasurementField(plugin interface{}, measurement string, value bool) bool {
+	field, ok := measurementField(plugin, measurement)
+	if ok {
+		field.SetBool(value)
+	}
+	return ok
+}
+
+// SetMeasurementFields sets the bool fields of plugin, whose key in metrics_collected is pluginKey, to
+// whether their measurement is listed in the measurement list of a config, and returns the listed
+// measurements no field stands for.
+func SetMeasurementFields(plugin interface{}, pluginKey string, measurement interface{}) []string {
+	for _, name := range MeasurementFields(plugin) {
+		SetMeasurementField(plugin, name, false)
+	}
+	var unknown []string
+	for _, item := range measurementList(measurement) {
+		name := MeasurementName(item)
+		if field := MatchMeasurementField(plugin, pluginKey, name); field != "" {
+			SetMeasurementField(plugin, field, true)
+		} else if name != "" {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// MatchMeasurementField returns the measurement of the field of plugin the config measurement name
+// stands for, or "" when there is none. The agent accepts measurement names with or without the prefix
+// of their plugin, so cpu's usage_idle and cpu_usage_idle are the same measurement.
+func MatchMeasurementField(plugin interface{}, pluginKey, name string) string {
+	if name == "" {
+		return ""
+	}
+	for _, field := range MeasurementFields(plugin) {
+		if field == name || field == pluginKey+"_"+name || pluginKey+"_"+field == name {
+			return field
+		}
+	}
+	return ""
+}
+
+// MeasurementName returns the name of an entry of a measurement list, which is either the name or an
+// object with the name and its rename or unit, or "" when it has none.
+func MeasurementName(item interface{}) string {
+	switch v := item.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		name, _ := v["name"].(string)
+		return name
+	}
+	return ""
+}
+
+func measurementList(measurement interface{}) []interface{} {
+	switch list := measurement.(type) {
+	case []interface{}:
+		return list
+	case []string:
+		result := make([]interface{}, len(list))
+		for i, name := range list {
+			result[i] = name
+		}
+		return result
+	}
+	return nil
+}
+
+func measurementField(plugin interface{}, measurement string) (reflect.Value, bool) {
+	v := reflect.ValueOf(plugin).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if field := v.Type().Field(i); field.Type.Kind() == reflect.Bool && string(field.Tag) == measurement {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// maxBufferMemoryRatio is the share of the RAM above which buffers are considered too large.
+const maxBufferMemoryRatio = 0.25
+
+// SystemMemory returns the total RAM of this host in bytes. An error is returned on platforms where it
+// cannot be read.
+func SystemMemory() (uint64, error) {
+	v, err := mem.VirtualMemory()
+	if err != nil {
+		return 0, fmt.Errorf("unable to read the system memory: %w", err)
+	}
+	return v.Total, nil
+}
+
+// WarnBufferSize returns a warning when bufferMB is more than a quarter of totalMemory bytes, or ""
+// when the buffer is reasonable or the memory is unknown.
+func WarnBufferSize(bufferMB int, totalMemory uint64) string {
+	if totalMemory == 0 || bufferMB <= 0 {
+		return ""
+	}
+	bufferBytes := uint64(bufferMB) * 1024 * 1024
+	if float64(bufferBytes) <= float64(totalMemory)*maxBufferMemoryRatio {
+		return ""
+	}
+	return fmt.Sprintf("W! A buffer of %d MB is more than %d%% of the %d MB of memory on this host, the agent may run out of memory.",
+		bufferMB, int(maxBufferMemoryRatio*100), totalMemory/1024/1024)
+}
+
+// PrintBufferSizeWarning prints the WarnBufferSize advisory for the memory of this host, if any.
+func PrintBufferSizeWarning(bufferMB int) {
+	totalMemory, err := SystemMemory()
+	if err != nil {
+		PrintDetail("%v", err)
+		return
+	}
+	if warning := WarnBufferSize(bufferMB, totalMemory); warning != "" {
+		fmt.Println(warning)
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnBufferSize(t *testing.T) {
+	const gib = uint64(1024 * 1024 * 1024)
+	assert.Empty(t, WarnBufferSize(3, gib))
+	assert.Empty(t, WarnBufferSize(256, gib))
+	assert.Equal(t, "W! A buffer of 512 MB is more than 25% of the 1024 MB of memory on this host, the agent may run out of memory.", WarnBufferSize(512, gib))
+	assert.Empty(t, WarnBufferSize(512, 0))
+}
@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// MergeWithConfigFile returns resultMap deep merged with MergeConfigMaps into the config already at
+// filePath, so that the sections edited by hand survive a new run of the wizard. Without a config at
+// filePath resultMap is returned, and one that is not valid json is overwritten with a warning. The error
+// is a *WizardError, from converting resultMap to json.
+func MergeWithConfigFile(resultMap map[string]interface{}, filePath string) (map[string]interface{}, error) {
+	existing, err := ReadConfigMap(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return resultMap, nil
+	}
+	if err != nil {
+		fmt.Printf("W! The existing config %s is overwritten instead of merged: %v\n", filePath, err)
+		return resultMap, nil
+	}
+	if converted := NormalizeBooleans(existing); converted > 0 {
+		fmt.Printf("I! %d boolean fields of the existing config %s were strings and are converted to booleans.\n", converted, filePath)
+	}
+	// the lists of resultMap are typed, e.g. []string, so it is converted to the json types of existing
+	// for its lists to be merged
+	content, err := SerializeResultMapToJsonByteArrayE(resultMap)
+	if err != nil {
+		return nil, err
+	}
+	var generated map[string]interface{}
+	if err = json.Unmarshal(content, &generated); err != nil {
+		return nil, &WizardError{Code: ErrCodeConfigParse, Err: fmt.Errorf("error in parsing the generated config: %w", err)}
+	}
+	fmt.Printf("The config is merged into the existing config %s.\n", filePath)
+	return MergeConfigMaps(existing, generated), nil
+}
+
+// MergeConfigMaps deep merges generated into existing and returns existing. Objects are merged key by
+// key, arrays get the items of generated they do not already contain appended, and for any other value,
+// or when the types differ, the generated value wins.
+func MergeConfigMaps(existing, generated map[string]interface{}) map[string]interface{} {
+	for key, value := range generated {
+		existing[key] = mergeConfigValue(existing[key], value)
+	}
+	return existing
+}
+
+func mergeConfigValue(existing, generated interface{}) interface{} {
+	switch g := generated.(type) {
+	case map[string]interface{}:
+		if e, ok := existing.(map[string]interface{}); ok {
+			return MergeConfigMaps(e, g)
+		}
+	case []interface{}:
+		if e, ok := existing.([]interface{}); ok {
+			for _, item := range g {
+				if !containsValue(e, item) {
+					e = append(e, item)
+				}
+			}
+			return e
+		}
+	}
+	return generated
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeConfigMaps(t *testing.T) {
+	existing := map[string]interface{}{
+		"agent": map[string]interface{}{"metrics_collection_interval": float64(60), "debug": true},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{
+					"measurement": []interface{}{"cpu_usage_idle"},
+					"resources":   []interface{}{"*"},
+				},
+			},
+		},
+		"logs": map[string]interface{}{"force_flush_interval": float64(5)},
+	}
+	generated := map[string]interface{}{
+		"agent": map[string]interface{}{"metrics_collection_interval": float64(10)},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{
+					"measurement": []interface{}{"cpu_usage_idle", "cpu_usage_user"},
+					"resources":   "*",
+				},
+				"mem": map[string]interface{}{"measurement": []interface{}{"mem_used_percent"}},
+			},
+		},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"agent": map[string]interface{}{"metrics_collection_interval": float64(10), "debug": true},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{
+					"measurement": []interface{}{"cpu_usage_idle", "cpu_usage_user"},
+					"resources":   "*",
+				},
+				"mem": map[string]interface{}{"measurement": []interface{}{"mem_used_percent"}},
+			},
+		},
+		"logs": map[string]interface{}{"force_flush_interval": float64(5)},
+	}, MergeConfigMaps(existing, generated))
+}
+
+func TestMergeWithConfigFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), configJsonFileName)
+
+	generated := map[string]interface{}{"agent": map[string]interface{}{"debug": true}}
+	m, err := MergeWithConfigFile(generated, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, generated, m)
+
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"agent":{"debug":"true","omit_hostname":"false"},"metrics":{"metrics_collected":{"cpu":{"measurement":["cpu_usage_idle"]}}}}`), 0600))
+	m, err = MergeWithConfigFile(map[string]interface{}{
+		"agent":   map[string]interface{}{"region": "us-west-2"},
+		"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{"cpu": map[string]interface{}{"measurement": []string{"cpu_usage_user"}}}},
+	}, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"agent":   map[string]interface{}{"debug": true, "omit_hostname": false, "region": "us-west-2"},
+		"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{"cpu": map[string]interface{}{"measurement": []interface{}{"cpu_usage_idle", "cpu_usage_user"}}}},
+	}, m)
+
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"agent":`), 0600))
+	generated = map[string]interface{}{"logs": map[string]interface{}{}}
+	m, err = MergeWithConfigFile(generated, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, generated, m)
+}
@@ -0,0 +1,82 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	MetricsProtocolCloudWatch = "cloudwatch"
+	MetricsProtocolOTLP       = "otlp"
+)
+
+var metricsProtocolDescriptions = []struct {
+	protocol    string
+	description string
+}{
+	{MetricsProtocolCloudWatch, "send the metrics to CloudWatch"},
+	{MetricsProtocolOTLP, "export the metrics over OTLP/HTTP to another backend"},
+}
+
+// AskMetricsProtocol prompts for where the metrics are sent, either MetricsProtocolCloudWatch or
+// MetricsProtocolOTLP. For OTLP the endpoint and the headers of the export requests are collected as
+// well and returned as the "endpoint" and "headers" settings, otherwise the settings are nil.
+// The agent JSON config only has the CloudWatch metrics destination, so the OTLP settings are meant for
+// an OpenTelemetry configuration the agent is started with alongside the JSON config.
+func AskMetricsProtocol() (string, map[string]interface{}, error) {
+	options := make([]string, len(metricsProtocolDescriptions))
+	for i, option := range metricsProtocolDescriptions {
+		options[i] = fmt.Sprintf("%s - %s", option.protocol, option.description)
+	}
+	answer := Choice("Which protocol do you want to send the metrics with?", 1, options)
+	protocol, _, _ := strings.Cut(answer, " - ")
+	switch protocol {
+	case MetricsProtocolCloudWatch:
+		return protocol, nil, nil
+	case MetricsProtocolOTLP:
+	default:
+		return "", nil, fmt.Errorf("%q is not a valid metrics protocol", answer)
+	}
+	endpoint, err := askValidated("OTLP endpoint URL, e.g. https://otlp.example.com:4318/v1/metrics:", "", ValidateEndpointURL)
+	if err != nil {
+		return "", nil, err
+	}
+	settings := map[string]interface{}{"endpoint": endpoint}
+	if No("Does the endpoint require headers, e.g. for authentication?") {
+		headers, err := AskOTLPHeaders()
+		if err != nil {
+			return "", nil, err
+		}
+		settings["headers"] = headers
+	}
+	return protocol, settings, nil
+}
+
+// ValidateEndpointURL checks endpoint is an absolute http or https URL with a host, which is what the
+// exporters and the endpoint_override settings accept.
+func ValidateEndpointURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must start with http:// or https://", endpoint)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("%q has no host", endpoint)
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("%q has an invalid port %s", endpoint, port)
+		}
+	}
+	if u.User != nil {
+		return fmt.Errorf("%q must not contain credentials, send them in a header instead", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	defaultMetricsCollectionInterval = 60
+	defaultForceFlushInterval        = 60
+	// maxDatumsPerCall matches the batch size the agent uses for PutMetricData.
+	maxDatumsPerCall = 1000
+	// putMetricDataTPSLimit is the default PutMetricData quota per account and region.
+	putMetricDataTPSLimit = 500
+	// putMetricDataWarnRate is the rate per host from which a few hundred hosts exhaust the quota.
+	putMetricDataWarnRate = 1.0
+)
+
+// MetricSummary describes what one entry of metrics_collected sends to CloudWatch.
+type MetricSummary struct {
+	Plugin       string
+	Measurements int
+	// Instances is the number of resources the measurements are collected for. A "*" resource counts
+	// as one instance and sets Wildcard, since how many it matches is only known on the host.
+	Instances int
+	Wildcard  bool
+	// Interval is the collection interval in seconds.
+	Interval int
+}
+
+// Metrics is the number of distinct metrics of the entry.
+func (s MetricSummary) Metrics() int {
+	return s.Measurements * s.Instances
+}
+
+// SummarizeMetrics returns a summary per entry of metrics_collected, sorted by plugin name. Plugins
+// configured as a list of entries, such as procstat, get one summary per entry.
+func SummarizeMetrics(m map[string]interface{}) []MetricSummary {
+	collected := getMap(m, "metrics", "metrics_collected")
+	if collected == nil {
+		return nil
+	}
+	defaultInterval := defaultMetricsCollectionInterval
+	if interval, ok := toNumber(getMap(m, "agent")[MapKeyMetricsCollectionInterval]); ok && interval > 0 {
+		defaultInterval = int(interval)
+	}
+	plugins := make([]string, 0, len(collected))
+	for plugin := range collected {
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+
+	var summaries []MetricSummary
+	for _, plugin := range plugins {
+		entries := getMapList(collected, plugin)
+		if entry, ok := collected[plugin].(map[string]interface{}); ok {
+			entries = []map[string]interface{}{entry}
+		}
+		for _, entry := range entries {
+			summaries = append(summaries, summarizeMetricEntry(plugin, entry, defaultInterval))
+		}
+	}
+	return summaries
+}
+
+func summarizeMetricEntry(plugin string, entry map[string]interface{}, defaultInterval int) MetricSummary {
+	summary := MetricSummary{Plugin: plugin, Instances: 1, Interval: defaultInterval}
+	switch measurement := entry[MapKeyMeasurement].(type) {
+	case []string:
+		summary.Measurements = len(measurement)
+	case []interface{}:
+		summary.Measurements = len(measurement)
+	}
+	if resources := getStringList(entry, MapKeyInstances); len(resources) > 0 {
+		summary.Instances = 0
+		for _, resource := range resources {
+			if resource == "*" {
+				summary.Wildcard = true
+			}
+			summary.Instances++
+		}
+	}
+	if interval, ok := toNumber(entry[MapKeyMetricsCollectionInterval]); ok && interval > 0 {
+		summary.Interval = int(interval)
+	}
+	return summary
+}
+
+// EstimatePutMetricDataRate estimates how many PutMetricData calls per second the agent makes for the
+// config, from the number of metrics and how often they are collected, and warns when the rate is high
+// enough for a few hundred such hosts to exceed the default PutMetricData quota of the region. This is
+// an estimate: resources matched by "*" count once and aggregation is ignored.
+func EstimatePutMetricDataRate(m map[string]interface{}) (float64, []string) {
+	var datapointsPerSecond float64
+	for _, summary := range SummarizeMetrics(m) {
+		datapointsPerSecond += float64(summary.Metrics()) / float64(summary.Interval)
+	}
+	if datapointsPerSecond == 0 {
+		return 0, nil
+	}
+	flushInterval := forceFlushInterval(m)
+	// the agent sends a batch when it is full or at every flush interval, whichever comes first
+	rate := datapointsPerSecond / maxDatumsPerCall
+	if flushRate := 1 / float64(flushInterval); rate < flushRate {
+		rate = flushRate
+	}
+
+	var warnings []string
+	if rate >= putMetricDataWarnRate {
+		warnings = append(warnings, fmt.Sprintf("this config makes an estimated %.2f PutMetricData calls per second per host, "+
+			"%.0f such hosts reach the default quota of %d calls per second per region. "+
+			"Consider longer collection intervals or fewer metrics.", rate, putMetricDataTPSLimit/rate, putMetricDataTPSLimit))
+	}
+	return rate, warnings
+}
+
+// ValidateMetricsPerRequest estimates how many datapoints the agent sends for the config at every
+// flush interval and warns when they do not fit in a single PutMetricData request, which takes at
+// most 1000 metrics, so that every flush is split into several calls. Entries collected less often
+// than the flush interval count once per flush. Like EstimatePutMetricDataRate, resources matched by
+// "*" count once, so the estimate is a lower bound for them.
+func ValidateMetricsPerRequest(m map[string]interface{}) (int, []string) {
+	flushInterval := forceFlushInterval(m)
+	datapoints := 0
+	wildcard := false
+	for _, summary := range SummarizeMetrics(m) {
+		collections := flushInterval / summary.Interval
+		if collections < 1 {
+			collections = 1
+		}
+		datapoints += summary.Metrics() * collections
+		wildcard = wildcard || summary.Wildcard
+	}
+	if datapoints <= maxDatumsPerCall {
+		return datapoints, nil
+	}
+	calls := (datapoints + maxDatumsPerCall - 1) / maxDatumsPerCall
+	warning := fmt.Sprintf("metrics: this config sends an estimated %d datapoints every %d seconds, more than the %d metrics "+
+		"a PutMetricData request takes, so every flush needs at least %d calls", datapoints, flushInterval, maxDatumsPerCall, calls)
+	if wildcard {
+		warning += ", more for every resource matched by *"
+	}
+	return datapoints, []string{warning + ". Consider longer collection intervals or fewer metrics, measurements or resources."}
+}
+
+// forceFlushInterval is the force_flush_interval of the metrics section in seconds, or its default.
+func forceFlushInterval(m map[string]interface{}) int {
+	if interval, ok := toNumber(getMap(m, "metrics")["force_flush_interval"]); ok && interval > 0 {
+		return int(interval)
+	}
+	return defaultForceFlushInterval
+}
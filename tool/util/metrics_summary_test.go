@@ -0,0 +1,110 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeMetrics(t *testing.T) {
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{MapKeyMetricsCollectionInterval: 30},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{
+					MapKeyMeasurement: []interface{}{"cpu_usage_idle", map[string]interface{}{"name": "cpu_usage_user"}},
+					MapKeyInstances:   []interface{}{"*"},
+				},
+				"disk": map[string]interface{}{
+					MapKeyMeasurement:               []string{"used_percent"},
+					MapKeyInstances:                 []string{"/", "/data"},
+					MapKeyMetricsCollectionInterval: float64(60),
+				},
+				"procstat": []interface{}{
+					map[string]interface{}{"exe": "nginx", MapKeyMeasurement: []interface{}{"cpu_usage", "memory_rss"}},
+					map[string]interface{}{"exe": "sshd", MapKeyMeasurement: []interface{}{"cpu_usage"}},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []MetricSummary{
+		{Plugin: "cpu", Measurements: 2, Instances: 1, Wildcard: true, Interval: 30},
+		{Plugin: "disk", Measurements: 1, Instances: 2, Interval: 60},
+		{Plugin: "procstat", Measurements: 2, Instances: 1, Interval: 30},
+		{Plugin: "procstat", Measurements: 1, Instances: 1, Interval: 30},
+	}, SummarizeMetrics(m))
+	assert.Empty(t, SummarizeMetrics(map[string]interface{}{}))
+}
+
+func TestEstimatePutMetricDataRate(t *testing.T) {
+	rate, warnings := EstimatePutMetricDataRate(map[string]interface{}{})
+	assert.Zero(t, rate)
+	assert.Empty(t, warnings)
+
+	m := map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"mem": map[string]interface{}{MapKeyMeasurement: []interface{}{"mem_used_percent"}},
+			},
+		},
+	}
+	rate, warnings = EstimatePutMetricDataRate(m)
+	assert.InDelta(t, 1.0/60, rate, 1e-9)
+	assert.Empty(t, warnings)
+
+	measurements := make([]interface{}, 100)
+	for i := range measurements {
+		measurements[i] = "metric"
+	}
+	m["metrics"].(map[string]interface{})["metrics_collected"] = map[string]interface{}{
+		"cpu": map[string]interface{}{
+			MapKeyMeasurement:               measurements,
+			MapKeyInstances:                 []interface{}{"cpu0", "cpu1", "cpu2", "cpu3", "cpu4", "cpu5", "cpu6", "cpu7", "cpu8", "cpu9"},
+			MapKeyMetricsCollectionInterval: 1,
+		},
+	}
+	rate, warnings = EstimatePutMetricDataRate(m)
+	assert.InDelta(t, 1.0, rate, 1e-9)
+	assert.Len(t, warnings, 1)
+}
+
+func TestValidateMetricsPerRequest(t *testing.T) {
+	datapoints, warnings := ValidateMetricsPerRequest(map[string]interface{}{})
+	assert.Zero(t, datapoints)
+	assert.Empty(t, warnings)
+
+	measurements := make([]interface{}, 50)
+	for i := range measurements {
+		measurements[i] = "metric"
+	}
+	m := map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{
+					MapKeyMeasurement:               measurements,
+					MapKeyMetricsCollectionInterval: 10,
+				},
+				"mem": map[string]interface{}{
+					MapKeyMeasurement:               []interface{}{"mem_used_percent"},
+					MapKeyMetricsCollectionInterval: 300,
+				},
+			},
+		},
+	}
+	datapoints, warnings = ValidateMetricsPerRequest(m)
+	assert.Equal(t, 301, datapoints)
+	assert.Empty(t, warnings)
+
+	m["metrics"].(map[string]interface{})["force_flush_interval"] = 30
+	m["metrics"].(map[string]interface{})["metrics_collected"].(map[string]interface{})["cpu"].(map[string]interface{})[MapKeyInstances] = []interface{}{"*", "cpu0", "cpu1", "cpu2"}
+	m["metrics"].(map[string]interface{})["metrics_collected"].(map[string]interface{})["cpu"].(map[string]interface{})[MapKeyMetricsCollectionInterval] = 1
+	datapoints, warnings = ValidateMetricsPerRequest(m)
+	assert.Equal(t, 6001, datapoints)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "an estimated 6001 datapoints every 30 seconds")
+	assert.Contains(t, warnings[0], "at least 7 calls, more for every resource matched by *")
+}
@@ -0,0 +1,155 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// deprecatedKey moves the value at the dotted path from to the dotted path to.
+type deprecatedKey struct {
+	from string
+	to   string
+}
+
+// credentialDeprecatedKeys are the older ways of giving the role to assume: role_arn directly on a
+// section, or a credentials object at the top level of the config, which applies to the whole agent.
+var credentialDeprecatedKeys = []deprecatedKey{
+	{from: "credentials", to: "agent.credentials"},
+	{from: "agent.role_arn", to: "agent.credentials.role_arn"},
+	{from: "metrics.role_arn", to: "metrics.credentials.role_arn"},
+	{from: "logs.role_arn", to: "logs.credentials.role_arn"},
+	{from: "traces.role_arn", to: "traces.credentials.role_arn"},
+}
+
+// deprecatedKeys are migrated by MigrateDeprecatedKeys, in order.
+var deprecatedKeys = credentialDeprecatedKeys
+
+// MigrateDeprecatedKeys moves the keys of older configs to where the current agent reads them and
+// returns a description of each move. A key whose new location already holds a different value, or
+// cannot be created, is an error, and m is left unchanged in that case.
+func MigrateDeprecatedKeys(m map[string]interface{}) ([]string, error) {
+	return migrateKeys(m, deprecatedKeys)
+}
+
+func migrateKeys(m map[string]interface{}, keys []deprecatedKey) ([]string, error) {
+	// the keys are moved on a copy, so that a failing move does not leave m half migrated
+	result := copyConfigValue(m).(map[string]interface{})
+	var migrated []string
+	for _, key := range keys {
+		value, ok := lookupPath(result, key.from)
+		if !ok {
+			continue
+		}
+		if existing, ok := lookupPath(result, key.to); ok && !reflect.DeepEqual(existing, value) {
+			return nil, fmt.Errorf("%s cannot be moved to %s, which is already set to a different value", key.from, key.to)
+		}
+		if blocked := blockingPath(result, key.to); blocked != "" {
+			return nil, fmt.Errorf("%s cannot be moved to %s, because %s is not an object", key.from, key.to, blocked)
+		}
+		deletePath(result, key.from)
+		setPath(result, key.to, value)
+		migrated = append(migrated, fmt.Sprintf("moved %s to %s", key.from, key.to))
+	}
+	for key := range m {
+		delete(m, key)
+	}
+	for key, value := range result {
+		m[key] = value
+	}
+	return migrated, nil
+}
+
+// copyConfigValue deep copies the objects and lists of a parsed json config.
+func copyConfigValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			copied[key] = copyConfigValue(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, child := range v {
+			copied[i] = copyConfigValue(child)
+		}
+		return copied
+	}
+	return value
+}
+
+// MigrateCredentialBlock rewrites the legacy credential settings of an imported config into
+// credentials objects holding role_arn, and reports whether anything was changed. Shared credential
+// files and profiles cannot be expressed in the json config at all, so a credentials object with them
+// is an error telling to move them to common-config.toml.
+func MigrateCredentialBlock(m map[string]interface{}) (bool, error) {
+	migrated, err := migrateKeys(m, credentialDeprecatedKeys)
+	if err != nil {
+		return false, err
+	}
+	for _, section := range credentialsRule.sections {
+		credentials := getMap(m, strings.Split(section, ".")...)
+		for _, key := range credentialsRule.groups[1] {
+			if _, ok := credentials[key]; ok {
+				return len(migrated) > 0, fmt.Errorf("%s.%s is not supported in the json config, set %s in the [credentials] section of common-config.toml instead",
+					section, key, key)
+			}
+		}
+	}
+	return len(migrated) > 0, nil
+}
+
+func lookupPath(m map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, ".")
+	parent := getMap(m, keys[:len(keys)-1]...)
+	if parent == nil {
+		return nil, false
+	}
+	value, ok := parent[keys[len(keys)-1]]
+	return value, ok
+}
+
+// blockingPath returns the part of path that exists but is not an object, so that nothing can be stored
+// at path.
+func blockingPath(m map[string]interface{}, path string) string {
+	keys := strings.Split(path, ".")
+	current := m
+	for i, key := range keys[:len(keys)-1] {
+		next, ok := current[key]
+		if !ok {
+			return ""
+		}
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			return strings.Join(keys[:i+1], ".")
+		}
+		current = child
+	}
+	return ""
+}
+
+// setPath stores value at path, creating the missing objects on the way. The path must not be blocked.
+func setPath(m map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	current := m
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := current[key].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			current[key] = child
+		}
+		current = child
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+func deletePath(m map[string]interface{}, path string) {
+	keys := strings.Split(path, ".")
+	if parent := getMap(m, keys[:len(keys)-1]...); parent != nil {
+		delete(parent, keys[len(keys)-1])
+	}
+}
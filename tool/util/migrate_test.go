@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateCredentialBlock(t *testing.T) {
+	legacy, err := ReadConfigMap(filepath.Join("testdata", "legacy_credentials.json"))
+	require.NoError(t, err)
+	expected, err := ReadConfigMap(filepath.Join("testdata", "migrated_credentials.json"))
+	require.NoError(t, err)
+
+	migrated, err := MigrateCredentialBlock(legacy)
+	assert.NoError(t, err)
+	assert.True(t, migrated)
+	assert.Equal(t, expected, legacy)
+	assert.Empty(t, ValidateConfig(legacy).Errors())
+
+	migrated, err = MigrateCredentialBlock(legacy)
+	assert.NoError(t, err)
+	assert.False(t, migrated)
+	assert.Equal(t, expected, legacy)
+}
+
+func TestMigrateCredentialBlockErrors(t *testing.T) {
+	testCases := map[string]string{
+		"Conflict":      `{"agent":{"role_arn":"arn:aws:iam::123456789012:role/A","credentials":{"role_arn":"arn:aws:iam::123456789012:role/B"}}}`,
+		"SharedProfile": `{"agent":{"credentials":{"shared_credential_profile":"default"}}}`,
+		"Sequential":    `{"credentials":{"role_arn":"arn:aws:iam::123456789012:role/A"},"agent":{"role_arn":"arn:aws:iam::123456789012:role/B"}}`,
+		"NotAnObject":   `{"agent":"cwagent","credentials":{"role_arn":"arn:aws:iam::123456789012:role/A"}}`,
+	}
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var m map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(config), &m))
+			original, err := json.Marshal(m)
+			require.NoError(t, err)
+			_, err = MigrateCredentialBlock(m)
+			assert.Error(t, err)
+			unchanged, err := json.Marshal(m)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(original), string(unchanged))
+		})
+	}
+}
+
+func TestMigrateDeprecatedKeys(t *testing.T) {
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"role_arn":    "arn:aws:iam::123456789012:role/A",
+			"credentials": map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/A"},
+		},
+		"traces": map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/T"},
+	}
+	migrated, err := MigrateDeprecatedKeys(m)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"moved agent.role_arn to agent.credentials.role_arn", "moved traces.role_arn to traces.credentials.role_arn"}, migrated)
+	assert.Equal(t, map[string]interface{}{
+		"agent":  map[string]interface{}{"credentials": map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/A"}},
+		"traces": map[string]interface{}{"credentials": map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/T"}},
+	}, m)
+
+	m = map[string]interface{}{"agent": map[string]interface{}{"debug": true}}
+	migrated, err = MigrateDeprecatedKeys(m)
+	assert.NoError(t, err)
+	assert.Empty(t, migrated)
+}
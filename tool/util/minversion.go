@@ -0,0 +1,141 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/version"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+// agentVersionFileName is written next to the agent binary by the installer.
+const agentVersionFileName = "CWAGENT_VERSION"
+
+// featureVersionData lists the config fields that need a newer agent than the first release, with the
+// release notes version that introduced them. A path segment ending in [*] matches every entry of a list.
+//
+//go:embed feature_versions.json
+var featureVersionData []byte
+
+type featureVersion struct {
+	Feature string `json:"feature"`
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+var agentVersionFile = filepath.Join(filepath.Dir(paths.AgentBinaryPath), agentVersionFileName)
+
+// InstalledAgentVersion returns the version of the agent installed on this host, read from the version
+// file next to the agent binary. When the agent is not installed there, the version the wizard was
+// built with is used, since both ship in the same package.
+func InstalledAgentVersion() (string, error) {
+	content, err := os.ReadFile(agentVersionFile)
+	if err == nil {
+		if installed := strings.TrimSpace(string(content)); installed != "" {
+			return installed, nil
+		}
+	}
+	if _, parseErr := parseAgentVersion(version.Number()); parseErr == nil {
+		return version.Number(), nil
+	}
+	if err == nil {
+		err = fmt.Errorf("%s is empty", agentVersionFile)
+	}
+	return "", fmt.Errorf("unable to determine the installed agent version: %w", err)
+}
+
+// ValidateMinVersion returns a message for every feature used in m that was introduced after
+// installedVersion, naming the version it needs. Nothing is reported when installedVersion cannot be
+// parsed, since there is then nothing to compare against.
+func ValidateMinVersion(m map[string]interface{}, installedVersion string) []string {
+	installed, err := parseAgentVersion(installedVersion)
+	if err != nil {
+		return nil
+	}
+	var features []featureVersion
+	if err = json.Unmarshal(featureVersionData, &features); err != nil {
+		return []string{fmt.Sprintf("invalid bundled feature versions: %v", err)}
+	}
+	var messages []string
+	for _, feature := range features {
+		required, err := parseAgentVersion(feature.Version)
+		if err != nil || compareAgentVersions(installed, required) >= 0 || !configPathExists(m, feature.Path) {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s (%s) requires agent version %s or later, but %s is installed",
+			feature.Feature, feature.Path, feature.Version, installedVersion))
+	}
+	return messages
+}
+
+// parseAgentVersion reads the numeric parts of a version such as 1.300032.2b361, where the suffix after
+// the patch number is the build.
+func parseAgentVersion(v string) ([3]int, error) {
+	var parsed [3]int
+	parts := strings.SplitN(strings.TrimSpace(v), ".", 3)
+	if len(parts) != 3 {
+		return parsed, fmt.Errorf("%q is not an agent version", v)
+	}
+	for i, part := range parts {
+		digits := part
+		if i == 2 {
+			if end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+				digits = part[:end]
+			}
+		}
+		number, err := strconv.Atoi(digits)
+		if err != nil {
+			return parsed, fmt.Errorf("%q is not an agent version", v)
+		}
+		parsed[i] = number
+	}
+	return parsed, nil
+}
+
+func compareAgentVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// configPathExists reports whether the dotted path is set in m. Segments ending in [*] step into every
+// entry of the list under that key.
+func configPathExists(m map[string]interface{}, path string) bool {
+	segments := strings.Split(path, ".")
+	key := segments[0]
+	if strings.HasSuffix(key, "[*]") {
+		entries := getMapList(m, strings.TrimSuffix(key, "[*]"))
+		if len(segments) == 1 {
+			return len(entries) > 0
+		}
+		for _, entry := range entries {
+			if configPathExists(entry, strings.Join(segments[1:], ".")) {
+				return true
+			}
+		}
+		return false
+	}
+	value, ok := m[key]
+	if !ok {
+		return false
+	}
+	if len(segments) == 1 {
+		return true
+	}
+	child, ok := value.(map[string]interface{})
+	return ok && configPathExists(child, strings.Join(segments[1:], "."))
+}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMinVersion(t *testing.T) {
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"metrics": {"metrics_collected": {"statsd": {"drop_original_metrics": ["*"]}, "jmx": {}}},
+		"logs": {"logs_collected": {"files": {"collect_list": [
+			{"file_path": "/var/log/messages"},
+			{"file_path": "/var/log/app.log", "log_group_class": "INFREQUENT_ACCESS"}
+		]}}}
+	}`), &m))
+
+	assert.Equal(t, []string{
+		"log group classes (logs.logs_collected.files.collect_list[*].log_group_class) requires agent version 1.300032.0 or later, but 1.300031.1b317 is installed",
+		"JMX metrics (metrics.metrics_collected.jmx) requires agent version 1.300041.0 or later, but 1.300031.1b317 is installed",
+		"StatsD drop_original_metrics (metrics.metrics_collected.statsd.drop_original_metrics) requires agent version 1.300043.0 or later, but 1.300031.1b317 is installed",
+	}, ValidateMinVersion(m, "1.300031.1b317"))
+	assert.Len(t, ValidateMinVersion(m, "1.300041.0"), 1)
+	assert.Empty(t, ValidateMinVersion(m, "1.300043.0b600"))
+	assert.Empty(t, ValidateMinVersion(m, "Unknown"))
+}
+
+func TestFeatureVersionData(t *testing.T) {
+	var features []featureVersion
+	require.NoError(t, json.Unmarshal(featureVersionData, &features))
+	assert.NotEmpty(t, features)
+	for _, feature := range features {
+		assert.NotEmpty(t, feature.Feature)
+		assert.NotEmpty(t, feature.Path)
+		_, err := parseAgentVersion(feature.Version)
+		assert.NoError(t, err, feature.Version)
+	}
+}
+
+func TestParseAgentVersion(t *testing.T) {
+	v, err := parseAgentVersion("1.300032.2b361")
+	assert.NoError(t, err)
+	assert.Equal(t, [3]int{1, 300032, 2}, v)
+	v, err = parseAgentVersion(" 1.247350.0\n")
+	assert.NoError(t, err)
+	assert.Equal(t, [3]int{1, 247350, 0}, v)
+	for _, invalid := range []string{"", "Unknown", "1.2", "1.x.0", "1.2.b3"} {
+		_, err = parseAgentVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestInstalledAgentVersion(t *testing.T) {
+	original := agentVersionFile
+	defer func() { agentVersionFile = original }()
+
+	agentVersionFile = filepath.Join(t.TempDir(), agentVersionFileName)
+	require.NoError(t, os.WriteFile(agentVersionFile, []byte("1.300043.0b604\n"), 0644))
+	installed, err := InstalledAgentVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.300043.0b604", installed)
+
+	// the test binary has no version file next to it, so there is nothing to fall back to
+	agentVersionFile = filepath.Join(t.TempDir(), agentVersionFileName)
+	_, err = InstalledAgentVersion()
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+var mountTableFile = "/proc/self/mounts"
+
+// ephemeralFileSystemTypes hold data that does not outlive the host or the container.
+var ephemeralFileSystemTypes = map[string]bool{
+	"tmpfs":    true,
+	"devtmpfs": true,
+	"ramfs":    true,
+	"overlay":  true,
+	"squashfs": true,
+}
+
+// MountPoints returns the entries of the live mount table, one "device mount_point fs_type options"
+// line per mount. It is only available on Linux.
+func MountPoints() ([]string, error) {
+	content, err := os.ReadFile(mountTableFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the mount table: %w", err)
+	}
+	var mounts []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) != "" {
+			mounts = append(mounts, line)
+		}
+	}
+	return mounts, nil
+}
+
+// WarnEphemeralDevices returns a warning for every disk resource of the config that is mounted on an
+// ephemeral file system such as tmpfs or overlay according to mounts, as returned by MountPoints.
+// A "*" resource is flagged for every ephemeral mount whose type is not in ignore_file_system_types.
+func WarnEphemeralDevices(m map[string]interface{}, mounts []string) []string {
+	disk := getMap(m, "metrics", "metrics_collected", "disk")
+	if disk == nil {
+		return nil
+	}
+	ignored := make(map[string]bool)
+	for _, fsType := range getStringList(disk, "ignore_file_system_types") {
+		ignored[fsType] = true
+	}
+	resources := getStringList(disk, MapKeyInstances)
+	if len(resources) == 0 {
+		resources = []string{"*"}
+	}
+
+	var warnings []string
+	for _, resource := range resources {
+		for _, mount := range mounts {
+			fields := strings.Fields(mount)
+			if len(fields) < 3 {
+				continue
+			}
+			mountPoint, fsType := fields[1], fields[2]
+			if !ephemeralFileSystemTypes[fsType] || ignored[fsType] {
+				continue
+			}
+			if resource == "*" {
+				warnings = append(warnings, fmt.Sprintf("disk metrics will be collected for %s, which is an ephemeral %s mount. Add %s to ignore_file_system_types to skip it.", mountPoint, fsType, fsType))
+			} else if resource == mountPoint {
+				warnings = append(warnings, fmt.Sprintf("disk resource %s is an ephemeral %s mount, its metrics are unlikely to be useful.", resource, fsType))
+			}
+		}
+	}
+	return warnings
+}
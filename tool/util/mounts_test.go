@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMounts = []string{
+	"/dev/nvme0n1p1 / xfs rw,noatime 0 0",
+	"tmpfs /run tmpfs rw,nosuid,nodev 0 0",
+	"overlay /var/lib/docker/overlay2/abc/merged overlay rw 0 0",
+	"/dev/nvme1n1 /data ext4 rw 0 0",
+}
+
+func diskConfig(disk map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{"disk": disk}}}
+}
+
+func TestMountPoints(t *testing.T) {
+	original := mountTableFile
+	t.Cleanup(func() { mountTableFile = original })
+	mountTableFile = filepath.Join(t.TempDir(), "mounts")
+	require.NoError(t, os.WriteFile(mountTableFile, []byte(testMounts[0]+"\n"+testMounts[1]+"\n\n"), 0644))
+
+	mounts, err := MountPoints()
+	assert.NoError(t, err)
+	assert.Equal(t, testMounts[:2], mounts)
+
+	mountTableFile = filepath.Join(t.TempDir(), "missing")
+	_, err = MountPoints()
+	assert.Error(t, err)
+}
+
+func TestWarnEphemeralDevices(t *testing.T) {
+	assert.Empty(t, WarnEphemeralDevices(map[string]interface{}{}, testMounts))
+
+	warnings := WarnEphemeralDevices(diskConfig(map[string]interface{}{MapKeyInstances: []string{"/", "/run", "/data"}}), testMounts)
+	assert.Equal(t, []string{"disk resource /run is an ephemeral tmpfs mount, its metrics are unlikely to be useful."}, warnings)
+
+	warnings = WarnEphemeralDevices(diskConfig(map[string]interface{}{MapKeyInstances: []interface{}{"*"}}), testMounts)
+	assert.Len(t, warnings, 2)
+
+	warnings = WarnEphemeralDevices(diskConfig(map[string]interface{}{
+		MapKeyInstances:            []interface{}{"*"},
+		"ignore_file_system_types": []interface{}{"tmpfs", "overlay"},
+	}), testMounts)
+	assert.Empty(t, warnings)
+}
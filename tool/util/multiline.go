@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MultiLineTimestampFormat makes a log event start at every line beginning with the timestamp_format
+// of the log entry.
+const MultiLineTimestampFormat = "{timestamp_format}"
+
+// ValidateMultilinePattern checks pattern is either MultiLineTimestampFormat or a regular expression.
+func ValidateMultilinePattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("the pattern must not be empty")
+	}
+	if pattern == MultiLineTimestampFormat {
+		return nil
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("%q is not a valid regular expression: %w", pattern, err)
+	}
+	return nil
+}
+
+// AskMultilinePattern prompts for the multi_line_start_pattern of a log entry, i.e. the regular
+// expression matching the first line of every log event, and lets the user check it against sample
+// lines until satisfied.
+func AskMultilinePattern() (string, error) {
+	PrintHint("Lines that do not match the start pattern are appended to the previous event, e.g. the lines of a stack trace. Enter %s to start events at lines beginning with the timestamp format.", MultiLineTimestampFormat)
+	for {
+		pattern, err := askValidated("Multi line start pattern:", MultiLineTimestampFormat, ValidateMultilinePattern)
+		if err != nil {
+			return "", err
+		}
+		if pattern == MultiLineTimestampFormat || !No("Do you want to test the pattern against a sample log line?") {
+			return pattern, nil
+		}
+		fmt.Printf("Paste a log line:\n\r")
+		sample, err := readAnswer("Paste a log line:")
+		if err != nil {
+			return "", err
+		}
+		if regexp.MustCompile(pattern).MatchString(sample) {
+			fmt.Println("The line matches, it starts a new log event.")
+		} else {
+			fmt.Println("The line does not match, it is appended to the previous log event.")
+		}
+		if Yes("Do you want to keep this pattern?") {
+			return pattern, nil
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestValidateMultilinePattern(t *testing.T) {
+	for _, pattern := range []string{MultiLineTimestampFormat, "^\\d{4}-\\d{2}-\\d{2}", "^[A-Z]"} {
+		assert.NoError(t, ValidateMultilinePattern(pattern), pattern)
+	}
+	for _, pattern := range []string{"", "^(\\d{4}", "[a-"} {
+		assert.Error(t, ValidateMultilinePattern(pattern), pattern)
+	}
+}
+
+func TestAskMultilinePattern(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	pattern, err := AskMultilinePattern()
+	assert.NoError(t, err)
+	assert.Equal(t, MultiLineTimestampFormat, pattern)
+
+	testutil.Type(inputChan, "^(\\d", "^\\d", "")
+	pattern, err = AskMultilinePattern()
+	assert.NoError(t, err)
+	assert.Equal(t, "^\\d", pattern)
+
+	testutil.Type(inputChan, "^\\s", "1", "2024-01-01 ERROR", "2", "^\\d", "1", "2024-01-01 ERROR", "1")
+	pattern, err = AskMultilinePattern()
+	assert.NoError(t, err)
+	assert.Equal(t, "^\\d", pattern)
+}
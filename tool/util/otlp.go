@@ -0,0 +1,86 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// headerNameRegex matches the RFC 7230 token production: one or more tchar.
+	headerNameRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+	// tokenValueRegex matches values that look like credentials, e.g. api keys or bearer tokens.
+	tokenValueRegex = regexp.MustCompile(`^(?i:(bearer|basic)\s+)?[A-Za-z0-9+/=._\-]{16,}$`)
+
+	sensitiveHeaderNames = []string{"authorization", "token", "key", "secret", "password"}
+)
+
+// ValidateHeaderName checks name is a valid http header field name per RFC 7230.
+func ValidateHeaderName(name string) error {
+	if !headerNameRegex.MatchString(name) {
+		return fmt.Errorf("%q is not a valid header name, it may only contain letters, digits and !#$%%&'*+-.^_`|~", name)
+	}
+	return nil
+}
+
+// AskOTLPHeaders collects the headers the OTLP exporter sends with every request, e.g. the
+// authentication header a third party backend requires. Values of headers that carry credentials are
+// read without echo and masked when the collected headers are printed back.
+func AskOTLPHeaders() (map[string]string, error) {
+	PrintHint("Headers are sent with every OTLP export request, e.g. an authorization header for the backend.")
+	headers := make(map[string]string)
+	for {
+		name, err := askValidated("Header name:", "", ValidateHeaderName)
+		if err != nil {
+			return nil, err
+		}
+		var value string
+		if isSensitiveHeader(name) {
+			value, err = AskSecret(fmt.Sprintf("Value of header %s:", name))
+		} else {
+			value, err = askValidated(fmt.Sprintf("Value of header %s:", name), "", validateHeaderValue)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := headers[name]; ok {
+			fmt.Printf("Header %s was already set, replacing its value.\n", name)
+		}
+		headers[name] = value
+		fmt.Printf("Added header %s: %s\n", name, maskHeaderValue(name, value))
+		if !Yes("Do you want to add another header?") {
+			return headers, nil
+		}
+	}
+}
+
+func validateHeaderValue(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("the value must not be empty")
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("the value must not contain line breaks")
+	}
+	return nil
+}
+
+func isSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	for _, sensitive := range sensitiveHeaderNames {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskHeaderValue hides values of sensitive headers and values that look like tokens.
+func maskHeaderValue(name, value string) string {
+	if isSensitiveHeader(name) || tokenValueRegex.MatchString(value) {
+		return maskedSecret
+	}
+	return value
+}
@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestValidateHeaderName(t *testing.T) {
+	for _, name := range []string{"Authorization", "x-api-key", "X_Custom.Header~1"} {
+		assert.NoError(t, ValidateHeaderName(name), name)
+	}
+	for _, name := range []string{"", "bad header", "colon:", "quote\"", "brace{", "tab\t"} {
+		assert.Error(t, ValidateHeaderName(name), name)
+	}
+}
+
+func TestAskOTLPHeaders(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "bad header", "Authorization", "Bearer abc", "1", "x-tenant", "", "team-a", "2")
+	headers, err := AskOTLPHeaders()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc", "x-tenant": "team-a"}, headers)
+}
+
+func TestMaskHeaderValue(t *testing.T) {
+	assert.Equal(t, maskedSecret, maskHeaderValue("X-Api-Key", "short"))
+	assert.Equal(t, maskedSecret, maskHeaderValue("x-custom", "Bearer eyJhbGciOiJIUzI1NiJ9"))
+	assert.Equal(t, maskedSecret, maskHeaderValue("x-custom", "0123456789abcdef0123"))
+	assert.Equal(t, "team-a", maskHeaderValue("x-tenant", "team-a"))
+}
@@ -0,0 +1,45 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"strings"
+)
+
+// NormalizeConfigPath canonicalizes a file path before it is written into the json config.
+//
+// The agent accepts forward slashes on every OS, so on Windows backslashes are converted to forward
+// slashes and repeated separators (e.g. "C:\\logs\\\\app.log" typed with JSON escaping in mind) are
+// collapsed into one. A leading UNC prefix ("\\server\share") is kept as "//server/share".
+// On other OSes a backslash is a legal file name character, so only surrounding whitespace is trimmed.
+func NormalizeConfigPath(path string, os string) string {
+	path = strings.TrimSpace(path)
+	if os != OsTypeWindows {
+		return path
+	}
+	path = strings.ReplaceAll(path, "\\", "/")
+	prefix := ""
+	if strings.HasPrefix(path, "//") {
+		prefix = "/"
+	}
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return prefix + path
+}
+
+// AskPath prompts for a non-empty file path and returns it normalized with NormalizeConfigPath.
+func AskPath(question string, os string) (string, error) {
+	answer, err := askValidated(question, "", func(answer string) error {
+		if strings.TrimSpace(answer) == "" {
+			return errors.New("path must not be empty")
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return NormalizeConfigPath(answer, os), nil
+}
@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestNormalizeConfigPath(t *testing.T) {
+	testCases := map[string]struct {
+		path string
+		os   string
+		want string
+	}{
+		"WindowsBackslash":        {path: `C:\ProgramData\logs\app.log`, os: OsTypeWindows, want: "C:/ProgramData/logs/app.log"},
+		"WindowsEscapedBackslash": {path: `C:\\ProgramData\\logs\\app.log`, os: OsTypeWindows, want: "C:/ProgramData/logs/app.log"},
+		"WindowsMixed":            {path: `C:/ProgramData\logs//app.log`, os: OsTypeWindows, want: "C:/ProgramData/logs/app.log"},
+		"WindowsUNC":              {path: `\\server\share\app.log`, os: OsTypeWindows, want: "//server/share/app.log"},
+		"WindowsForwardSlash":     {path: "C:/logs/app.log", os: OsTypeWindows, want: "C:/logs/app.log"},
+		"LinuxUnchanged":          {path: `/var/log/odd\name.log`, os: OsTypeLinux, want: `/var/log/odd\name.log`},
+		"LinuxTrimmed":            {path: " /var/log/messages ", os: OsTypeLinux, want: "/var/log/messages"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, NormalizeConfigPath(testCase.path, testCase.os))
+		})
+	}
+}
+
+func TestAskPath(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "", `C:\logs\app.log`)
+	path, err := AskPath("Log file path:", OsTypeWindows)
+	assert.NoError(t, err)
+	assert.Equal(t, "C:/logs/app.log", path)
+}
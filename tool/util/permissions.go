@@ -0,0 +1,137 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+// ErrPermissionCheckUnavailable is returned by CheckPermissions when the resolved credentials are not
+// allowed to look up their role or simulate their own policies, so the permissions cannot be verified
+// either way.
+var ErrPermissionCheckUnavailable = errors.New("not allowed to run iam:GetRole or iam:SimulatePrincipalPolicy")
+
+// newPermissionClients builds the clients used by CheckPermissions. It is replaced in tests.
+var newPermissionClients = func(region string) (stsiface.STSAPI, iamiface.IAMAPI, error) {
+	ses, err := session.NewSession(aws.NewConfig().WithRegion(region).WithSTSRegionalEndpoint(stsRegionalEndpoint))
+	if err != nil {
+		return nil, nil, err
+	}
+	return sts.New(ses), iam.New(ses), nil
+}
+
+// RequiredActions returns the API actions the agent calls for the sections present in the json config.
+func RequiredActions(m map[string]interface{}) []string {
+	var actions []string
+	if metrics, ok := m["metrics"].(map[string]interface{}); ok {
+		actions = append(actions, "cloudwatch:PutMetricData")
+		if _, ok := metrics["append_dimensions"]; ok {
+			actions = append(actions, "ec2:DescribeTags")
+		}
+	}
+	if _, ok := m["logs"]; ok {
+		actions = append(actions, "logs:CreateLogGroup", "logs:CreateLogStream", "logs:DescribeLogStreams", "logs:PutLogEvents", "logs:PutRetentionPolicy")
+	}
+	if _, ok := m["traces"]; ok {
+		actions = append(actions, "xray:PutTraceSegments", "xray:PutTelemetryRecords", "xray:GetSamplingRules", "xray:GetSamplingTargets")
+	}
+	return actions
+}
+
+// CheckPermissions reports, for each action, whether the identity behind the default credentials is
+// allowed to call it according to iam:SimulatePrincipalPolicy. If the identity may not run the simulation
+// itself, ErrPermissionCheckUnavailable is returned and callers should continue without the check.
+func CheckPermissions(region string, actions []string) (map[string]bool, error) {
+	if len(actions) == 0 {
+		return map[string]bool{}, nil
+	}
+	stsClient, iamClient, err := newPermissionClients(region)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve the caller identity: %w", err)
+	}
+	principal, err := principalARN(iamClient, aws.StringValue(identity.Arn))
+	if err != nil {
+		if isAccessDenied(err) {
+			return nil, ErrPermissionCheckUnavailable
+		}
+		return nil, err
+	}
+
+	permitted := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		permitted[action] = false
+	}
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal),
+		ActionNames:     aws.StringSlice(actions),
+	}
+	err = iamClient.SimulatePrincipalPolicyPages(input, func(page *iam.SimulatePolicyResponse, lastPage bool) bool {
+		for _, result := range page.EvaluationResults {
+			permitted[aws.StringValue(result.EvalActionName)] = aws.StringValue(result.EvalDecision) == iam.PolicyEvaluationDecisionTypeAllowed
+		}
+		return true
+	})
+	if err != nil {
+		if isAccessDenied(err) {
+			return nil, ErrPermissionCheckUnavailable
+		}
+		return nil, err
+	}
+	return permitted, nil
+}
+
+func isAccessDenied(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && (awsErr.Code() == "AccessDenied" || awsErr.Code() == "AccessDeniedException")
+}
+
+// DeniedActions returns the sorted actions that CheckPermissions reported as not permitted.
+func DeniedActions(permitted map[string]bool) []string {
+	var denied []string
+	for action, allowed := range permitted {
+		if !allowed {
+			denied = append(denied, action)
+		}
+	}
+	sort.Strings(denied)
+	return denied
+}
+
+// principalARN converts the caller identity into an ARN accepted by SimulatePrincipalPolicy. Assumed role
+// sessions are mapped back to the role they were assumed from, which is looked up with iam:GetRole as the
+// session ARN leaves out the path of the role.
+func principalARN(iamClient iamiface.IAMAPI, callerARN string) (string, error) {
+	parsed, err := arn.Parse(callerARN)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Service != sts.ServiceName || !strings.HasPrefix(parsed.Resource, "assumed-role/") {
+		return callerARN, nil
+	}
+	parts := strings.Split(parsed.Resource, "/")
+	if len(parts) < 2 || parts[1] == "" {
+		return "", fmt.Errorf("unexpected assumed role arn %s", callerARN)
+	}
+	role, err := iamClient.GetRole(&iam.GetRoleInput{RoleName: aws.String(parts[1])})
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve the role %s: %w", parts[1], err)
+	}
+	return aws.StringValue(role.Role.Arn), nil
+}
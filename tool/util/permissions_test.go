@@ -0,0 +1,119 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSTS struct {
+	stsiface.STSAPI
+	arn string
+}
+
+func (m *mockSTS) GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Arn: aws.String(m.arn)}, nil
+}
+
+type mockIAM struct {
+	iamiface.IAMAPI
+	allowed      map[string]bool
+	err          error
+	roles        map[string]string
+	roleErr      error
+	policySource string
+}
+
+func (m *mockIAM) GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	if m.roleErr != nil {
+		return nil, m.roleErr
+	}
+	roleARN, ok := m.roles[aws.StringValue(input.RoleName)]
+	if !ok {
+		return nil, awserr.New(iam.ErrCodeNoSuchEntityException, "role not found", nil)
+	}
+	return &iam.GetRoleOutput{Role: &iam.Role{Arn: aws.String(roleARN), RoleName: input.RoleName}}, nil
+}
+
+func (m *mockIAM) SimulatePrincipalPolicyPages(input *iam.SimulatePrincipalPolicyInput, fn func(*iam.SimulatePolicyResponse, bool) bool) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.policySource = aws.StringValue(input.PolicySourceArn)
+	page := &iam.SimulatePolicyResponse{}
+	for _, action := range aws.StringValueSlice(input.ActionNames) {
+		decision := iam.PolicyEvaluationDecisionTypeImplicitDeny
+		if m.allowed[action] {
+			decision = iam.PolicyEvaluationDecisionTypeAllowed
+		}
+		page.EvaluationResults = append(page.EvaluationResults, &iam.EvaluationResult{
+			EvalActionName: aws.String(action),
+			EvalDecision:   aws.String(decision),
+		})
+	}
+	fn(page, true)
+	return nil
+}
+
+func setUpPermissionClients(t *testing.T, stsClient stsiface.STSAPI, iamClient iamiface.IAMAPI) {
+	original := newPermissionClients
+	t.Cleanup(func() { newPermissionClients = original })
+	newPermissionClients = func(string) (stsiface.STSAPI, iamiface.IAMAPI, error) {
+		return stsClient, iamClient, nil
+	}
+}
+
+func TestCheckPermissions(t *testing.T) {
+	iamClient := &mockIAM{
+		allowed: map[string]bool{"cloudwatch:PutMetricData": true},
+		roles:   map[string]string{"CloudWatchAgentServerRole": "arn:aws:iam::123456789012:role/CloudWatchAgentServerRole"},
+	}
+	setUpPermissionClients(t, &mockSTS{arn: "arn:aws:sts::123456789012:assumed-role/CloudWatchAgentServerRole/i-0123456789"}, iamClient)
+
+	permitted, err := CheckPermissions("us-west-2", []string{"cloudwatch:PutMetricData", "logs:CreateLogGroup"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"cloudwatch:PutMetricData": true, "logs:CreateLogGroup": false}, permitted)
+	assert.Equal(t, []string{"logs:CreateLogGroup"}, DeniedActions(permitted))
+	assert.Equal(t, "arn:aws:iam::123456789012:role/CloudWatchAgentServerRole", iamClient.policySource)
+}
+
+func TestCheckPermissionsRoleWithPath(t *testing.T) {
+	iamClient := &mockIAM{roles: map[string]string{"CloudWatchAgentServerRole": "arn:aws:iam::123456789012:role/service-role/agents/CloudWatchAgentServerRole"}}
+	setUpPermissionClients(t, &mockSTS{arn: "arn:aws:sts::123456789012:assumed-role/CloudWatchAgentServerRole/i-0123456789"}, iamClient)
+
+	_, err := CheckPermissions("us-west-2", []string{"cloudwatch:PutMetricData"})
+	assert.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/service-role/agents/CloudWatchAgentServerRole", iamClient.policySource)
+}
+
+func TestCheckPermissionsGetRoleDenied(t *testing.T) {
+	iamClient := &mockIAM{roleErr: awserr.New("AccessDenied", "not authorized to perform iam:GetRole", nil)}
+	setUpPermissionClients(t, &mockSTS{arn: "arn:aws:sts::123456789012:assumed-role/CloudWatchAgentServerRole/i-0123456789"}, iamClient)
+
+	_, err := CheckPermissions("us-west-2", []string{"cloudwatch:PutMetricData"})
+	assert.ErrorIs(t, err, ErrPermissionCheckUnavailable)
+}
+
+func TestCheckPermissionsSimulationDenied(t *testing.T) {
+	iamClient := &mockIAM{err: awserr.New("AccessDenied", "not authorized to perform iam:SimulatePrincipalPolicy", nil)}
+	setUpPermissionClients(t, &mockSTS{arn: "arn:aws:iam::123456789012:user/agent"}, iamClient)
+
+	_, err := CheckPermissions("us-west-2", []string{"cloudwatch:PutMetricData"})
+	assert.ErrorIs(t, err, ErrPermissionCheckUnavailable)
+}
+
+func TestRequiredActions(t *testing.T) {
+	assert.Empty(t, RequiredActions(map[string]interface{}{"agent": map[string]interface{}{}}))
+	assert.Equal(t, []string{"cloudwatch:PutMetricData", "ec2:DescribeTags"},
+		RequiredActions(map[string]interface{}{"metrics": map[string]interface{}{"append_dimensions": map[string]interface{}{}}}))
+	assert.Contains(t, RequiredActions(map[string]interface{}{"logs": map[string]interface{}{}}), "logs:PutLogEvents")
+}
@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The recommended maximum number of instances of the plugins the agent starts once per config entry.
+// Every procstat and jmx entry runs its own input gathering on every interval, and every log file or
+// event log is tailed by its own reader, so past these the agent uses noticeably more CPU and memory.
+const (
+	maxProcstatInstances     = 30
+	maxJMXInstances          = 10
+	maxLogFileInstances      = 200
+	maxWindowsEventInstances = 50
+)
+
+// pluginInstanceLimit is a list of the config holding one plugin instance per entry.
+type pluginInstanceLimit struct {
+	path      string
+	threshold int
+}
+
+var pluginInstanceLimits = []pluginInstanceLimit{
+	{path: "metrics.metrics_collected.procstat", threshold: maxProcstatInstances},
+	{path: "metrics.metrics_collected.jmx", threshold: maxJMXInstances},
+	{path: "logs.logs_collected.files.collect_list", threshold: maxLogFileInstances},
+	{path: "logs.logs_collected.windows_events.collect_list", threshold: maxWindowsEventInstances},
+}
+
+// pluginInstanceCount is the number of entries found at a path of pluginInstanceLimits.
+type pluginInstanceCount struct {
+	path      string
+	count     int
+	threshold int
+}
+
+// WarnPluginInstanceCounts counts the instances of every plugin m configures once per entry, such as
+// procstat, and warns for each one configured more times than recommended. Every warning starts with
+// the json path of the list and includes the count and the threshold.
+func WarnPluginInstanceCounts(m map[string]interface{}) []string {
+	var warnings []string
+	for _, c := range pluginInstanceCounts(m) {
+		if c.count > c.threshold {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", c.path, pluginInstanceMessage(c)))
+		}
+	}
+	return warnings
+}
+
+// pluginInstanceCounts returns the number of entries of every list of pluginInstanceLimits set in m.
+func pluginInstanceCounts(m map[string]interface{}) []pluginInstanceCount {
+	var counts []pluginInstanceCount
+	for _, limit := range pluginInstanceLimits {
+		keys := strings.Split(limit.path, ".")
+		parent := getMap(m, keys[:len(keys)-1]...)
+		if parent == nil {
+			continue
+		}
+		if entries := getMapList(parent, keys[len(keys)-1]); len(entries) > 0 {
+			counts = append(counts, pluginInstanceCount{path: limit.path, count: len(entries), threshold: limit.threshold})
+		}
+	}
+	return counts
+}
+
+func pluginInstanceMessage(c pluginInstanceCount) string {
+	return fmt.Sprintf("%d entries, more than the recommended %d; every entry runs its own plugin instance. Merge entries or narrow what they match.", c.count, c.threshold)
+}
+
+func validatePluginInstanceCounts(m map[string]interface{}, report *ValidationReport) {
+	for _, c := range pluginInstanceCounts(m) {
+		if c.count > c.threshold {
+			report.addWarning(c.path, "%s", pluginInstanceMessage(c))
+		}
+	}
+}
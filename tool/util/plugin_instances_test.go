@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarnPluginInstanceCounts(t *testing.T) {
+	entries := func(n int) []interface{} {
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = map[string]interface{}{"file_path": "/var/log/app.log"}
+		}
+		return list
+	}
+	m := map[string]interface{}{
+		"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{
+			"procstat": entries(maxProcstatInstances + 1),
+			"jmx":      entries(maxJMXInstances),
+		}},
+		"logs": map[string]interface{}{"logs_collected": map[string]interface{}{
+			"files": map[string]interface{}{"collect_list": entries(maxLogFileInstances + 5)},
+		}},
+	}
+
+	warnings := WarnPluginInstanceCounts(m)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "metrics.metrics_collected.procstat: 31 entries, more than the recommended 30")
+	assert.Contains(t, warnings[1], "logs.logs_collected.files.collect_list: 205 entries, more than the recommended 200")
+
+	report := ValidateConfig(m)
+	assert.True(t, report.Valid())
+	require.Len(t, report.Warnings(), 2)
+	assert.Equal(t, "metrics.metrics_collected.procstat", report.Warnings()[0].Path)
+	assert.Equal(t, "logs.logs_collected.files.collect_list", report.Warnings()[1].Path)
+
+	assert.Empty(t, WarnPluginInstanceCounts(map[string]interface{}{}))
+	assert.Empty(t, WarnPluginInstanceCounts(map[string]interface{}{
+		"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{
+			"procstat": []map[string]interface{}{{"exe": "nginx"}},
+			"jmx":      map[string]interface{}{"endpoint": "localhost:1314"},
+		}},
+	}))
+}
@@ -0,0 +1,171 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Policy is a deny-list of config patterns an organization does not allow, e.g. because they are known
+// to be expensive. It is plain data so that it can be kept in a json file, see LoadPolicy.
+type Policy struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule is violated when every one of its patterns matches the config.
+type PolicyRule struct {
+	Name     string          `json:"name"`
+	Severity Severity        `json:"severity"`
+	Message  string          `json:"message"`
+	Match    []PolicyPattern `json:"match"`
+}
+
+// PolicyPattern matches the fields at Path, a dotted json path where * stands for any key and [*] for
+// any list entry, e.g. "metrics.metrics_collected.*.metrics_collection_interval". With no condition the
+// field only has to be set. Below and Above compare numbers, Equals compares the value, and Contains
+// looks for a string in a list or a key in an object.
+type PolicyPattern struct {
+	Path     string      `json:"path"`
+	Below    *float64    `json:"below,omitempty"`
+	Above    *float64    `json:"above,omitempty"`
+	Equals   interface{} `json:"equals,omitempty"`
+	Contains string      `json:"contains,omitempty"`
+}
+
+// PolicyFileEnv names the policy file the wizard checks the config against before saving it.
+const PolicyFileEnv = "CWAGENT_POLICY_FILE"
+
+var listIndexRegex = regexp.MustCompile(`\[\d+\]`)
+
+// LoadPolicy reads a Policy from a json file and checks its rules are usable.
+func LoadPolicy(filePath string) (Policy, error) {
+	var policy Policy
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return policy, err
+	}
+	if err = json.Unmarshal(content, &policy); err != nil {
+		return policy, fmt.Errorf("error in parsing policy %s: %w", filePath, err)
+	}
+	for i, rule := range policy.Rules {
+		if rule.Severity != SeverityError && rule.Severity != SeverityWarning {
+			return policy, fmt.Errorf("rule %d of policy %s has severity %q, it must be %s or %s", i, filePath, rule.Severity, SeverityError, SeverityWarning)
+		}
+		if len(rule.Match) == 0 {
+			return policy, fmt.Errorf("rule %d of policy %s has no patterns", i, filePath)
+		}
+	}
+	return policy, nil
+}
+
+// CheckPolicyViolations returns a message for every rule of policy that m violates, starting with the
+// severity of the rule and listing the paths matched by its first pattern.
+func CheckPolicyViolations(m map[string]interface{}, policy Policy) []string {
+	var violations []string
+	for _, rule := range policy.Rules {
+		var paths []string
+		violated := len(rule.Match) > 0
+		for i, pattern := range rule.Match {
+			matched := pattern.matches(m)
+			if len(matched) == 0 {
+				violated = false
+				break
+			}
+			if i == 0 {
+				paths = matched
+			}
+		}
+		if !violated {
+			continue
+		}
+		name := rule.Name
+		if name == "" {
+			name = "unnamed rule"
+		}
+		violations = append(violations, fmt.Sprintf("%s: %s: %s (%s)", rule.Severity, name, rule.Message, strings.Join(paths, ", ")))
+	}
+	return violations
+}
+
+// HasPolicyErrors is true when one of the violations comes from a rule with SeverityError.
+func HasPolicyErrors(violations []string) bool {
+	for _, violation := range violations {
+		if strings.HasPrefix(violation, string(SeverityError)+": ") {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns the paths of the fields of m that match the pattern.
+func (p PolicyPattern) matches(m map[string]interface{}) []string {
+	want := strings.Split(p.Path, ".")
+	var matched []string
+	walkConfig(m, "", func(path, _ string, value interface{}) {
+		if matchPolicyPath(want, strings.Split(listIndexRegex.ReplaceAllString(path, "[*]"), ".")) && p.holds(value) {
+			matched = append(matched, path)
+		}
+	})
+	return matched
+}
+
+func matchPolicyPath(want, got []string) bool {
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if want[i] == got[i] || want[i] == "*" {
+			continue
+		}
+		if strings.HasPrefix(want[i], "*[") && strings.HasSuffix(got[i], strings.TrimPrefix(want[i], "*")) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func (p PolicyPattern) holds(value interface{}) bool {
+	if p.Below != nil || p.Above != nil {
+		n, ok := toNumber(value)
+		if !ok || (p.Below != nil && n >= *p.Below) || (p.Above != nil && n <= *p.Above) {
+			return false
+		}
+	}
+	if p.Equals != nil && !policyValuesEqual(p.Equals, value) {
+		return false
+	}
+	if p.Contains != "" {
+		switch v := value.(type) {
+		case []interface{}:
+			return containsValue(v, p.Contains)
+		case []string:
+			for _, s := range v {
+				if s == p.Contains {
+					return true
+				}
+			}
+			return false
+		case map[string]interface{}:
+			_, ok := v[p.Contains]
+			return ok
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func policyValuesEqual(want, got interface{}) bool {
+	if w, ok := toNumber(want); ok {
+		g, ok := toNumber(got)
+		return ok && w == g
+	}
+	return reflect.DeepEqual(want, got)
+}
@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPolicyViolations(t *testing.T) {
+	policy, err := LoadPolicy(filepath.Join("testdata", "policy.json"))
+	require.NoError(t, err)
+	require.Len(t, policy.Rules, 3)
+
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{"debug": false},
+		"metrics": map[string]interface{}{
+			"append_dimensions": map[string]interface{}{"InstanceId": "${aws:InstanceId}"},
+			"metrics_collected": map[string]interface{}{
+				"cpu":  map[string]interface{}{"metrics_collection_interval": float64(1)},
+				"disk": map[string]interface{}{"metrics_collection_interval": float64(60)},
+				"mem":  map[string]interface{}{"metrics_collection_interval": 5},
+			},
+		},
+		"logs": map[string]interface{}{
+			"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{
+					"collect_list": []interface{}{
+						map[string]interface{}{"retention_in_days": float64(30)},
+						map[string]interface{}{"retention_in_days": float64(731)},
+					},
+				},
+			},
+		},
+	}
+	violations := CheckPolicyViolations(m, policy)
+	assert.Equal(t, []string{
+		"error: high-resolution-per-instance: 1 second metrics with an InstanceId dimension are not allowed " +
+			"(metrics.metrics_collected.cpu.metrics_collection_interval, metrics.metrics_collected.mem.metrics_collection_interval)",
+		"warning: long-retention: log groups should not be kept for more than a year (logs.logs_collected.files.collect_list[1].retention_in_days)",
+	}, violations)
+	assert.True(t, HasPolicyErrors(violations))
+
+	delete(m["metrics"].(map[string]interface{}), "append_dimensions")
+	m["agent"] = map[string]interface{}{"debug": true}
+	violations = CheckPolicyViolations(m, policy)
+	assert.Len(t, violations, 2)
+	assert.Contains(t, violations[1], "debug-logging")
+	assert.False(t, HasPolicyErrors(violations))
+
+	assert.Empty(t, CheckPolicyViolations(m, Policy{}))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadPolicy(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	for name, content := range map[string]string{
+		"invalid.json":  `{"rules":`,
+		"severity.json": `{"rules":[{"severity":"fatal","match":[{"path":"agent"}]}]}`,
+		"empty.json":    `{"rules":[{"severity":"error"}]}`,
+	} {
+		filePath := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0600))
+		_, err = LoadPolicy(filePath)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	procstatExe     = "exe"
+	procstatPattern = "pattern"
+	procstatPidFile = "pid_file"
+)
+
+// defaultProcstatMeasurement is collected for every process selected by the wizard.
+var defaultProcstatMeasurement = []string{"cpu_usage", "memory_rss"}
+
+// AskProcstatSelector prompts for how to select the processes to monitor and returns the procstat
+// entry. exe and pattern are regular expressions matched against the executable name and the
+// command line, pid_file is the path of a file holding the process id.
+func AskProcstatSelector() (map[string]interface{}, error) {
+	PrintHint("exe matches the executable name, pattern matches the full command line and pid_file reads the process id from a file.")
+	selector := Choice("How do you want to select the process to monitor?", 1, []string{procstatExe, procstatPattern, procstatPidFile})
+	var validate func(answer string) error
+	var question string
+	switch selector {
+	case procstatExe:
+		question, validate = "Executable name (regular expression):", validateProcstatRegex
+	case procstatPattern:
+		question, validate = "Command line pattern (regular expression):", validateProcstatRegex
+	case procstatPidFile:
+		question, validate = "Pid file path:", validatePidFile
+	default:
+		return nil, fmt.Errorf("unknown procstat selector %s", selector)
+	}
+	value, err := askValidated(question, "", validate)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		selector:          strings.TrimSpace(value),
+		MapKeyMeasurement: defaultProcstatMeasurement,
+	}, nil
+}
+
+// AskProcstatSelectors calls AskProcstatSelector until no additional process should be monitored.
+func AskProcstatSelectors() ([]map[string]interface{}, error) {
+	var entries []map[string]interface{}
+	for {
+		entry, err := AskProcstatSelector()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+		if !Yes("Do you want to monitor any additional processes?") {
+			return entries, nil
+		}
+	}
+}
+
+func validateProcstatRegex(answer string) error {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return fmt.Errorf("the value must not be empty")
+	}
+	if _, err := regexp.Compile(answer); err != nil {
+		return fmt.Errorf("not a valid regular expression: %w", err)
+	}
+	return nil
+}
+
+func validatePidFile(answer string) error {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return fmt.Errorf("the value must not be empty")
+	}
+	info, err := os.Stat(answer)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", answer)
+	}
+	return nil
+}
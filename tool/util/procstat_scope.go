@@ -0,0 +1,163 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	ProcstatScopeAll  = "all matching"
+	ProcstatScopeTopN = "top N by CPU"
+	ProcstatScopePIDs = "specific PIDs"
+
+	defaultProcstatTopN = 5
+)
+
+// runningProcess is a process found on the host when the wizard runs.
+type runningProcess struct {
+	pid  int32
+	name string
+	cpu  float64
+}
+
+// runningProcesses lists the processes of the host with their CPU usage. It is replaced in tests.
+var runningProcesses = func() ([]runningProcess, error) {
+	processList, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	processes := make([]runningProcess, 0, len(processList))
+	for _, p := range processList {
+		name, err := p.Name()
+		if err != nil || name == "" {
+			continue
+		}
+		cpu, _ := p.CPUPercent()
+		processes = append(processes, runningProcess{pid: p.Pid, name: name, cpu: cpu})
+	}
+	return processes, nil
+}
+
+// AskProcstatScope prompts for which processes procstat collects metrics for, since every process
+// matched adds its own set of metrics: all processes matching a selector, the N processes using the
+// most CPU, or specific PIDs. It returns the scope with the procstat entries. procstat selects
+// processes when metrics are collected and has no top N or PID list setting, so the top N processes
+// and the PIDs are resolved now, into an exe entry matching the executable name of each. Those keep
+// matching after the processes restart with new PIDs.
+func AskProcstatScope() (string, []map[string]interface{}, error) {
+	PrintHint("Every process procstat matches publishes its own metrics, so a narrow scope keeps the number of metrics down.")
+	scope := Choice("Which processes do you want to collect metrics for?", 1, []string{ProcstatScopeAll, ProcstatScopeTopN, ProcstatScopePIDs})
+	switch scope {
+	case ProcstatScopeAll:
+		entries, err := AskProcstatSelectors()
+		return scope, entries, err
+	case ProcstatScopeTopN:
+		answer, err := askValidated("How many processes do you want to monitor?", strconv.Itoa(defaultProcstatTopN), validatePositiveInteger)
+		if err != nil {
+			return "", nil, err
+		}
+		n, _ := strconv.Atoi(answer)
+		names, err := topProcessNames(n)
+		if err != nil {
+			return "", nil, err
+		}
+		return scope, procstatExeEntries(names), nil
+	case ProcstatScopePIDs:
+		processes, err := runningProcesses()
+		if err != nil {
+			return "", nil, err
+		}
+		byPID := make(map[int32]string, len(processes))
+		for _, p := range processes {
+			byPID[p.pid] = p.name
+		}
+		answer, err := askValidated("Process ids, separated by commas:", "", func(answer string) error {
+			_, err := parsePIDs(answer, byPID)
+			return err
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		names, _ := parsePIDs(answer, byPID)
+		return scope, procstatExeEntries(names), nil
+	}
+	return "", nil, fmt.Errorf("unknown procstat scope %s", scope)
+}
+
+func validatePositiveInteger(answer string) error {
+	if n, err := strconv.Atoi(answer); err != nil || n < 1 {
+		return fmt.Errorf("%s is not a positive whole number", answer)
+	}
+	return nil
+}
+
+// topProcessNames returns the names of the n processes using the most CPU, without duplicates.
+func topProcessNames(n int) ([]string, error) {
+	processes, err := runningProcesses()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(processes, func(i, j int) bool { return processes[i].cpu > processes[j].cpu })
+	var names []string
+	seen := make(map[string]bool)
+	for _, p := range processes {
+		if len(names) == n {
+			break
+		}
+		if !seen[p.name] {
+			seen[p.name] = true
+			names = append(names, p.name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no running processes were found")
+	}
+	return names, nil
+}
+
+// parsePIDs returns the names of the processes of the comma separated PIDs, which must all be running.
+func parsePIDs(answer string, byPID map[int32]string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(answer, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		pid, err := strconv.ParseInt(field, 10, 32)
+		if err != nil || pid < 1 {
+			return nil, fmt.Errorf("%s is not a process id", field)
+		}
+		name, ok := byPID[int32(pid)]
+		if !ok {
+			return nil, fmt.Errorf("no process with id %d is running", pid)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("enter at least one process id")
+	}
+	return names, nil
+}
+
+func procstatExeEntries(names []string) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, map[string]interface{}{
+			procstatExe:       "^" + regexp.QuoteMeta(name) + "$",
+			MapKeyMeasurement: defaultProcstatMeasurement,
+		})
+	}
+	return entries
+}
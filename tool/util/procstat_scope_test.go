@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskProcstatScope(t *testing.T) {
+	original := runningProcesses
+	defer func() { runningProcesses = original }()
+	runningProcesses = func() ([]runningProcess, error) {
+		return []runningProcess{
+			{pid: 1, name: "systemd", cpu: 0.1},
+			{pid: 200, name: "java", cpu: 80},
+			{pid: 201, name: "java", cpu: 40},
+			{pid: 300, name: "nginx", cpu: 10},
+			{pid: 400, name: "php-fpm7.4", cpu: 20},
+		}, nil
+	}
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "", "", "nginx", "2")
+	scope, entries, err := AskProcstatScope()
+	assert.NoError(t, err)
+	assert.Equal(t, ProcstatScopeAll, scope)
+	assert.Equal(t, []map[string]interface{}{{"exe": "nginx", "measurement": defaultProcstatMeasurement}}, entries)
+
+	testutil.Type(inputChan, "2", "0", "-1", "2")
+	scope, entries, err = AskProcstatScope()
+	assert.NoError(t, err)
+	assert.Equal(t, ProcstatScopeTopN, scope)
+	assert.Equal(t, []map[string]interface{}{
+		{"exe": "^java$", "measurement": defaultProcstatMeasurement},
+		{"exe": `^php-fpm7\.4$`, "measurement": defaultProcstatMeasurement},
+	}, entries)
+
+	testutil.Type(inputChan, "3", "", "200,abc", "999", "200, 201,300")
+	scope, entries, err = AskProcstatScope()
+	assert.NoError(t, err)
+	assert.Equal(t, ProcstatScopePIDs, scope)
+	assert.Equal(t, []map[string]interface{}{
+		{"exe": "^java$", "measurement": defaultProcstatMeasurement},
+		{"exe": "^nginx$", "measurement": defaultProcstatMeasurement},
+	}, entries)
+}
@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskProcstatSelector(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "", "", "nginx")
+	entry, err := AskProcstatSelector()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"exe": "nginx", "measurement": defaultProcstatMeasurement}, entry)
+
+	testutil.Type(inputChan, "2", "java(", "java .*-jar")
+	entry, err = AskProcstatSelector()
+	assert.NoError(t, err)
+	assert.Equal(t, "java .*-jar", entry["pattern"])
+
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "app.pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte("123"), 0644))
+	testutil.Type(inputChan, "3", filepath.Join(dir, "missing.pid"), dir, pidFile)
+	entry, err = AskProcstatSelector()
+	assert.NoError(t, err)
+	assert.Equal(t, pidFile, entry["pid_file"])
+}
+
+func TestAskProcstatSelectors(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "1", "nginx", "1", "2", "sshd", "2")
+	entries, err := AskProcstatSelectors()
+	assert.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "nginx", entries[0]["exe"])
+	assert.Equal(t, "sshd", entries[1]["pattern"])
+}
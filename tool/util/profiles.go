@@ -0,0 +1,235 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
+)
+
+const noAgentProfileOption = "None (keep the credentials of common-config.toml)"
+
+// profileCredentials returns the credentials of profile as the SDK resolves them from the shared files,
+// following source_profile, sso_session and credential_process. It is replaced in tests.
+var profileCredentials = func(profile string) (*credentials.Credentials, error) {
+	ses, err := session.NewSessionWithOptions(session.Options{Profile: profile, SharedConfigState: session.SharedConfigEnable})
+	if err != nil {
+		return nil, err
+	}
+	return ses.Config.Credentials, nil
+}
+
+// callerIdentity returns the ARN STS GetCallerIdentity answers for creds. It is replaced in tests.
+var callerIdentity = func(creds *credentials.Credentials, region string) (string, error) {
+	ses, err := session.NewSession(aws.NewConfig().WithCredentials(creds).WithRegion(region).WithSTSRegionalEndpoint(stsRegionalEndpoint))
+	if err != nil {
+		return "", err
+	}
+	output, err := sts.New(ses).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(output.Arn), nil
+}
+
+// ProfileChoices returns the profiles of the shared credentials and config files described by where
+// their credentials come from, e.g. "dev (AWS SSO session corp)", and the profile of each description.
+func ProfileChoices() ([]string, map[string]string) {
+	credentialsFile, configFile := SharedConfigFiles()
+	profiles := readSharedProfiles(credentialsFile, configFile)
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var choices []string
+	byChoice := make(map[string]string, len(names))
+	for _, name := range names {
+		choice := describeProfile(name, profiles[name])
+		choices = append(choices, choice)
+		byChoice[choice] = name
+	}
+	return choices, byChoice
+}
+
+// describeProfile returns the name of a profile followed by where its credentials come from.
+func describeProfile(name string, settings map[string]string) string {
+	var source string
+	switch {
+	case settings["role_arn"] != "" && settings["source_profile"] != "":
+		source = fmt.Sprintf("assumes %s with the profile %s", settings["role_arn"], settings["source_profile"])
+	case settings["role_arn"] != "" && settings["credential_source"] != "":
+		source = fmt.Sprintf("assumes %s with the %s credentials", settings["role_arn"], settings["credential_source"])
+	case settings["sso_session"] != "":
+		source = "AWS SSO session " + settings["sso_session"]
+	case settings["sso_start_url"] != "":
+		source = "AWS SSO " + settings["sso_start_url"]
+	case settings["credential_process"] != "":
+		source = "credential process"
+	case settings["web_identity_token_file"] != "":
+		source = "web identity token"
+	case settings["aws_access_key_id"] != "":
+		source = "access key " + settings["aws_access_key_id"]
+	default:
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, source)
+}
+
+// VerifyProfile resolves the credentials of profile and makes sure they authenticate with STS
+// GetCallerIdentity, which any credentials may call. It returns the credentials and the ARN they
+// authenticate as. The credentials of an AWS SSO profile need a session started with aws sso login.
+func VerifyProfile(profile string) (*credentials.Credentials, string, error) {
+	creds, err := profileCredentials(profile)
+	if err == nil {
+		region := SDKRegionWithProfile(profile)
+		if region == "" {
+			region = "us-east-1"
+		}
+		var arn string
+		if arn, err = callerIdentity(creds, region); err == nil {
+			return creds, arn, nil
+		}
+	}
+	credentialsFile, configFile := SharedConfigFiles()
+	settings := readSharedProfiles(credentialsFile, configFile)[profile]
+	if settings["sso_session"] != "" || settings["sso_start_url"] != "" {
+		return nil, "", fmt.Errorf("the credentials of the profile %s cannot be verified, run aws sso login --profile %s to start an AWS SSO session: %w", profile, profile, err)
+	}
+	return nil, "", fmt.Errorf("the credentials of the profile %s cannot be verified: %w", profile, err)
+}
+
+// AskAgentProfile prompts for the profile of the shared files the agent reads its credentials with on
+// premises, and verifies it with STS. The agent only reads static keys from the shared credentials
+// file, so a profile assuming a role with the keys of a source profile in it returns the source profile
+// with the role, for the agent to assume itself with agent.credentials.role_arn. It returns "" when the
+// credentials of common-config.toml are kept.
+func AskAgentProfile() (profile, roleARN string, err error) {
+	credentialsFile, configFile := SharedConfigFiles()
+	choices, byChoice := ProfileChoices()
+	if len(choices) == 0 {
+		PrintDetail("There is no profile in %s or %s for the agent to use.", credentialsFile, configFile)
+		return "", "", nil
+	}
+	PrintHint("The profile is written to common-config.toml, so the agent reads the keys of the profile instead of having them copied into its config.")
+	for {
+		answer := Choice("Which profile should the agent use to send to AWS?", len(choices)+1, append(choices, noAgentProfileOption))
+		name, ok := byChoice[answer]
+		if !ok {
+			return "", "", nil
+		}
+		_, arn, verifyErr := VerifyProfile(name)
+		if verifyErr != nil {
+			fmt.Printf("W! %v\n", verifyErr)
+			if !No(fmt.Sprintf("Do you still want the agent to use the profile %s?", name)) {
+				continue
+			}
+		} else {
+			fmt.Printf("The profile %s authenticates as %s.\n", name, arn)
+		}
+		fileProfiles := readProfileSettings(credentialsFile, false)
+		profile, roleARN = agentProfile(name, readSharedProfiles(credentialsFile, configFile), fileProfiles)
+		if profile != name {
+			fmt.Printf("The agent assumes %s itself with the keys of the profile %s.\n", roleARN, profile)
+		} else if fileProfiles[name]["aws_access_key_id"] == "" {
+			fmt.Printf("W! The agent only reads access keys from %s, which has none for the profile %s. "+
+				"Credentials from AWS SSO, a credential process or a role the profile assumes are not used by the agent.\n", credentialsFile, name)
+		}
+		return profile, roleARN, nil
+	}
+}
+
+// agentProfile returns the profile the agent reads its keys with to get the credentials of name: name
+// itself, or the source profile holding the keys in the credentials file together with the role name
+// assumes with them.
+func agentProfile(name string, profiles, fileProfiles map[string]map[string]string) (string, string) {
+	settings := profiles[name]
+	source := settings["source_profile"]
+	if settings["role_arn"] == "" || source == "" || source == name || fileProfiles[source]["aws_access_key_id"] == "" {
+		return name, ""
+	}
+	return source, settings["role_arn"]
+}
+
+// SetCommonConfigCredentials sets the [credentials] section of the common-config.toml at filePath to
+// read the profile from credentialsFile, creating the file when it does not exist. The other sections and
+// the comments are kept as they are.
+func SetCommonConfigCredentials(filePath, profile, credentialsFile string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error in reading %s: %w", filePath, err)
+	}
+	section := []string{"[" + commonconfig.CredentialSection + "]", fmt.Sprintf("   %s = %q", commonconfig.CredentialProfile, profile)}
+	if credentialsFile != "" {
+		section = append(section, fmt.Sprintf("   %s = %q", commonconfig.CredentialFile, credentialsFile))
+	}
+	var lines []string
+	inSection, written := false, false
+	for _, line := range strings.Split(strings.TrimRight(string(content), "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inSection = trimmed == "["+commonconfig.CredentialSection+"]"
+			if inSection {
+				lines = append(lines, section...)
+				written = true
+				continue
+			}
+		}
+		// the keys of the section are the ones written, the comments are kept
+		if inSection && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if !written {
+		if len(lines) > 0 && lines[len(lines)-1] != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, section...)
+	}
+	result := strings.TrimLeft(strings.Join(lines, "\n"), "\n") + "\n"
+	if _, err = commonconfig.Parse(strings.NewReader(result)); err != nil {
+		return fmt.Errorf("the credentials cannot be written to %s: %w", filePath, err)
+	}
+	if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("error in creating the directory of %s: %w", filePath, err)
+	}
+	if err = os.WriteFile(filePath, []byte(result), 0644); err != nil {
+		return fmt.Errorf("error in writing file to %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// CommonConfigCredentials returns the settings of the [credentials] section of the common-config.toml at
+// filePath, which is empty when the file or the section does not exist.
+func CommonConfigCredentials(filePath string) (map[string]string, error) {
+	file, err := os.Open(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error in reading %s: %w", filePath, err)
+	}
+	defer file.Close()
+	commonConfig, err := commonconfig.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("error in parsing %s: %w", filePath, err)
+	}
+	return commonConfig.CredentialsMap(), nil
+}
+
+// CommonConfigPath returns where the agent reads common-config.toml from.
+func CommonConfigPath() string {
+	return commonConfigPath
+}
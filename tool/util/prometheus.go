@@ -0,0 +1,231 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultPrometheusJobName     = "prometheus"
+	defaultPrometheusMetricsPath = "/metrics"
+	defaultPrometheusLogGroup    = "prometheus"
+	prometheusScrapeTimeout      = "10s"
+	defaultRelabelSourceLabel    = "__name__"
+	defaultRelabelRegex          = "(.*)"
+	defaultRelabelReplacement    = "$1"
+
+	relabelActionReplace   = "replace"
+	relabelActionKeep      = "keep"
+	relabelActionDrop      = "drop"
+	relabelActionLabelDrop = "labeldrop"
+)
+
+var (
+	// PrometheusScrapeIntervals are the scrape intervals the wizard offers, the longest being the default.
+	PrometheusScrapeIntervals = []string{"10s", "30s", "60s"}
+
+	relabelActions = []string{relabelActionReplace, relabelActionKeep, relabelActionDrop, relabelActionLabelDrop}
+
+	// prometheusLabelRegex matches the label names Prometheus allows.
+	prometheusLabelRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// DefaultPrometheusConfigPath returns where the wizard saves the Prometheus scrape config on the OS: next
+// to the config of the agent, where the agent itself downloads it when the config points at an env
+// variable.
+func DefaultPrometheusConfigPath(osType string) string {
+	if osType == OsTypeWindows {
+		return os.Getenv("ProgramData") + `\Amazon\AmazonCloudWatchAgent\prometheus.yaml`
+	}
+	return "/opt/aws/amazon-cloudwatch-agent/etc/prometheus.yaml"
+}
+
+// AskPrometheusLogGroup prompts for the log group the scraped metrics are published to as embedded
+// metric format logs. The agent has no default for it on EC2.
+func AskPrometheusLogGroup() (string, error) {
+	return askValidated("Which log group do you want the Prometheus metrics published to?", defaultPrometheusLogGroup, func(answer string) error {
+		if strings.TrimSpace(answer) == "" {
+			return errors.New("the log group name must not be empty")
+		}
+		return nil
+	})
+}
+
+// AskPrometheusScrapeConfigs prompts for the jobs the agent scrapes, each with its targets, the path
+// the metrics are served on and the rules relabeling the scraped metrics, and returns the scrape_configs
+// of a Prometheus scrape config. Job names must be unique.
+func AskPrometheusScrapeConfigs() ([]interface{}, error) {
+	PrintHint("The agent scrapes the targets of each job like a Prometheus server, and publishes the metrics to CloudWatch in the embedded metric format.")
+	var scrapeConfigs []interface{}
+	jobNames := make(map[string]bool)
+	for {
+		defaultJobName := ""
+		if len(scrapeConfigs) == 0 {
+			defaultJobName = defaultPrometheusJobName
+		}
+		jobName, err := askValidated("Prometheus job name:", defaultJobName, func(answer string) error {
+			if strings.TrimSpace(answer) == "" {
+				return errors.New("the job name must not be empty")
+			}
+			if jobNames[answer] {
+				return fmt.Errorf("the job %s is already scraped", answer)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		answer, err := askValidated("Which targets (host:port separated by commas) do you want the job to scrape?", "", validatePrometheusTargets)
+		if err != nil {
+			return nil, err
+		}
+		metricsPath, err := askValidated("Which path are the metrics of the targets served on?", defaultPrometheusMetricsPath, func(answer string) error {
+			if !strings.HasPrefix(answer, "/") {
+				return errors.New("the path must start with /")
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		scrapeConfig := map[string]interface{}{
+			"job_name":       jobName,
+			"metrics_path":   metricsPath,
+			"static_configs": []interface{}{map[string]interface{}{"targets": splitList(answer)}},
+		}
+		if No("Do you want to relabel the metrics scraped by the job?") {
+			relabelConfigs, err := askRelabelConfigs()
+			if err != nil {
+				return nil, err
+			}
+			scrapeConfig["metric_relabel_configs"] = relabelConfigs
+		}
+		jobNames[jobName] = true
+		scrapeConfigs = append(scrapeConfigs, scrapeConfig)
+		if !No("Do you want to scrape another Prometheus job?") {
+			return scrapeConfigs, nil
+		}
+	}
+}
+
+// askRelabelConfigs prompts for the metric_relabel_configs of a job. replace sets the target label to
+// the replacement of the source labels matching the regex, keep and drop keep or drop the metrics whose
+// source labels match it, and labeldrop removes the labels whose name matches it.
+func askRelabelConfigs() ([]interface{}, error) {
+	PrintDetail("The source labels of a rule are joined with ; before the regular expression is matched against them. " +
+		"The metric name is the label __name__.")
+	var relabelConfigs []interface{}
+	for {
+		action := Choice("Which relabel action do you want to apply?", 1, relabelActions)
+		relabelConfig := map[string]interface{}{"action": action}
+		if action != relabelActionLabelDrop {
+			answer, err := askValidated("Which labels (separated by commas) does the rule match?", defaultRelabelSourceLabel, validateLabelList)
+			if err != nil {
+				return nil, err
+			}
+			relabelConfig["source_labels"] = splitList(answer)
+		}
+		regex, err := askValidated("Which regular expression does the rule match?", defaultRelabelRegex, func(answer string) error {
+			_, err := regexp.Compile(answer)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		relabelConfig["regex"] = regex
+		if action == relabelActionReplace {
+			targetLabel, err := askValidated("Which label does the rule set?", "", validateLabelName)
+			if err != nil {
+				return nil, err
+			}
+			relabelConfig["target_label"] = targetLabel
+			relabelConfig["replacement"] = AskWithDefault("What value does the rule set the label to?", defaultRelabelReplacement)
+		}
+		relabelConfigs = append(relabelConfigs, relabelConfig)
+		if !No("Do you want to add another relabel rule?") {
+			return relabelConfigs, nil
+		}
+	}
+}
+
+// PrometheusScrapeConfigYAML returns the Prometheus scrape config scraping the scrape configs at
+// scrapeInterval.
+func PrometheusScrapeConfigYAML(scrapeInterval string, scrapeConfigs []interface{}) ([]byte, error) {
+	return yaml.Marshal(map[string]interface{}{
+		"global": map[string]interface{}{
+			"scrape_interval": scrapeInterval,
+			"scrape_timeout":  prometheusScrapeTimeout,
+		},
+		"scrape_configs": scrapeConfigs,
+	})
+}
+
+// SavePrometheusScrapeConfig writes the Prometheus scrape config to filePath, creating its directory.
+func SavePrometheusScrapeConfig(content []byte, filePath string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("error in creating the directory of %s: %w", filePath, err)
+	}
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return fmt.Errorf("error in writing file to %s: %w", filePath, err)
+	}
+	return nil
+}
+
+func validatePrometheusTargets(answer string) error {
+	targets := splitList(answer)
+	if len(targets) == 0 {
+		return errors.New("at least one target is required")
+	}
+	for _, target := range targets {
+		host, port, err := net.SplitHostPort(target)
+		if err != nil || host == "" {
+			return fmt.Errorf("%q is not a host:port target", target)
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("%q does not have a port between 1 and 65535", target)
+		}
+	}
+	return nil
+}
+
+func validateLabelList(answer string) error {
+	labels := splitList(answer)
+	if len(labels) == 0 {
+		return errors.New("at least one label is required")
+	}
+	for _, label := range labels {
+		if err := validateLabelName(label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLabelName(label string) error {
+	if !prometheusLabelRegex.MatchString(label) {
+		return fmt.Errorf("%q is not a valid label name, it may only contain letters, digits and _ and must not start with a digit", label)
+	}
+	return nil
+}
+
+// splitList splits a comma separated answer, dropping the empty items.
+func splitList(answer string) []string {
+	var items []string
+	for _, item := range strings.Split(answer, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,86 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskPrometheusScrapeConfigs(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan,
+		"", "localhost", "localhost:9100, 10.0.0.1:9200", "", "1",
+		"", "", "node_(.*)", "1bad", "family", "", "1",
+		"3", "__name__", "go_.*", "",
+		"1", "prometheus", "app", "app.local:8080", "stats", "/stats/prometheus", "", "")
+	scrapeConfigs, err := AskPrometheusScrapeConfigs()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"job_name":       "prometheus",
+			"metrics_path":   "/metrics",
+			"static_configs": []interface{}{map[string]interface{}{"targets": []string{"localhost:9100", "10.0.0.1:9200"}}},
+			"metric_relabel_configs": []interface{}{
+				map[string]interface{}{"action": "replace", "source_labels": []string{"__name__"}, "regex": "node_(.*)", "target_label": "family", "replacement": "$1"},
+				map[string]interface{}{"action": "drop", "source_labels": []string{"__name__"}, "regex": "go_.*"},
+			},
+		},
+		map[string]interface{}{
+			"job_name":       "app",
+			"metrics_path":   "/stats/prometheus",
+			"static_configs": []interface{}{map[string]interface{}{"targets": []string{"app.local:8080"}}},
+		},
+	}, scrapeConfigs)
+}
+
+func TestValidatePrometheusTargets(t *testing.T) {
+	for _, answer := range []string{"localhost:9090", "10.0.0.1:1, [::1]:65535", "host.example.com:8080,"} {
+		assert.NoError(t, validatePrometheusTargets(answer), answer)
+	}
+	for _, answer := range []string{"", " , ", "localhost", ":9090", "localhost:0", "localhost:65536", "localhost:http"} {
+		assert.Error(t, validatePrometheusTargets(answer), answer)
+	}
+	assert.NoError(t, validateLabelList("__name__, job"))
+	assert.Error(t, validateLabelList("job,instance-id"))
+	assert.Error(t, validateLabelList(""))
+}
+
+func TestSavePrometheusScrapeConfig(t *testing.T) {
+	content, err := PrometheusScrapeConfigYAML("60s", []interface{}{
+		map[string]interface{}{
+			"job_name":       "prometheus",
+			"metrics_path":   "/metrics",
+			"static_configs": []interface{}{map[string]interface{}{"targets": []string{"localhost:9100"}}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `global:
+    scrape_interval: 60s
+    scrape_timeout: 10s
+scrape_configs:
+    - job_name: prometheus
+      metrics_path: /metrics
+      static_configs:
+        - targets:
+            - localhost:9100
+`, string(content))
+
+	filePath := filepath.Join(t.TempDir(), "etc", "prometheus.yaml")
+	require.NoError(t, SavePrometheusScrapeConfig(content, filePath))
+	saved, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, content, saved)
+
+	assert.Equal(t, "/opt/aws/amazon-cloudwatch-agent/etc/prometheus.yaml", DefaultPrometheusConfigPath(OsTypeLinux))
+	t.Setenv("ProgramData", `C:\ProgramData`)
+	assert.Equal(t, `C:\ProgramData\Amazon\AmazonCloudWatchAgent\prometheus.yaml`, DefaultPrometheusConfigPath(OsTypeWindows))
+}
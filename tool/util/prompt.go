@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/stdin"
+)
+
+// readAnswer reads a single line of input for the question currently displayed, from the answer source
+// when one is set. ErrNoAnswer is returned once the answer source is exhausted, and AnswerErr once it
+// had no answer for a question.
+func readAnswer(question string) (string, error) {
+	if answerSource != nil {
+		if answerErr != nil {
+			fmt.Println()
+			return "", answerErr
+		}
+		answer, ok := answerSource.Next(question)
+		if answerErr != nil {
+			fmt.Println()
+			return "", answerErr
+		}
+		if !ok {
+			fmt.Println()
+			return "", ErrNoAnswer
+		}
+		fmt.Println(answer)
+		return answer, nil
+	}
+	var answer string
+	_, err := stdin.Scanln(&answer)
+	return answer, err
+}
+
+// askValidated prompts until validate accepts the answer. An empty answer selects defaultValue.
+// An error is only returned when the input itself cannot be read, or when the answer source is exhausted
+// and the default is not valid.
+func askValidated(question, defaultValue string, validate func(answer string) error) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Printf("%s\ndefault choice: [%s]\n\r", question, defaultValue)
+		} else {
+			fmt.Printf("%s\n\r", question)
+		}
+
+		answer, err := readAnswer(question)
+		exhausted := errors.Is(err, ErrNoAnswer)
+		if err != nil && (!exhausted || answerErr != nil) {
+			return "", err
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+		if err = validate(answer); err != nil {
+			if exhausted {
+				reportMissingAnswer(question)
+				return "", fmt.Errorf("%s: %w", question, ErrNoAnswer)
+			}
+			fmt.Printf("The value %s is not valid to this question: %v\nPlease retry to answer:\n", answer, err)
+			continue
+		}
+		return answer, nil
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// WarnCrossRegion returns advisories for publishing telemetry from an instance in instanceRegion to
+// destinationRegion. No warnings are returned when the regions match or either one is unknown.
+func WarnCrossRegion(instanceRegion, destinationRegion string) []string {
+	if instanceRegion == "" || destinationRegion == "" || instanceRegion == destinationRegion {
+		return nil
+	}
+	instancePartition, instanceOk := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), instanceRegion)
+	destinationPartition, destinationOk := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), destinationRegion)
+	if instanceOk && destinationOk && instancePartition.ID() != destinationPartition.ID() {
+		return []string{fmt.Sprintf("Region %s is in the %s partition but the instance region %s is in the %s partition. "+
+			"Credentials from one partition cannot be used in the other.",
+			destinationRegion, destinationPartition.ID(), instanceRegion, instancePartition.ID())}
+	}
+	return []string{
+		fmt.Sprintf("Telemetry will be sent from %s to %s. Cross-region data transfer charges apply to the published data.", instanceRegion, destinationRegion),
+		fmt.Sprintf("Requests to %s travel outside of %s, which adds latency and makes delivery depend on the availability of both regions.", destinationRegion, instanceRegion),
+	}
+}
+
+// IsValidRegion is true when region is a region of one of the partitions the agent knows about.
+func IsValidRegion(region string) bool {
+	for _, p := range endpoints.DefaultPartitions() {
+		if _, ok := p.Regions()[region]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AskRegionFallback prompts for the regions the agent tries, in order, when the endpoints of primary
+// cannot be reached, one at a time until an empty answer. Every region is valid, listed once, not
+// primary itself, and in the same partition as primary since its credentials are not accepted in
+// another one. An empty list is returned when no fallback is wanted.
+func AskRegionFallback(primary string) ([]string, error) {
+	fallback := []string{}
+	primaryPartition, primaryOk := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), primary)
+	for {
+		region, err := askValidated(fmt.Sprintf("Which region do you want to fall back to if %s cannot be reached? Enter nothing to finish.", primary), "", func(answer string) error {
+			if answer == "" {
+				return nil
+			}
+			if !IsValidRegion(answer) {
+				return fmt.Errorf("%s is not a known region", answer)
+			}
+			if answer == primary {
+				return fmt.Errorf("%s is the primary region", answer)
+			}
+			for _, r := range fallback {
+				if r == answer {
+					return fmt.Errorf("%s is already a fallback region", answer)
+				}
+			}
+			if partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), answer); primaryOk && ok && partition.ID() != primaryPartition.ID() {
+				return fmt.Errorf("%s is in the %s partition but %s is in the %s partition", answer, partition.ID(), primary, primaryPartition.ID())
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if region == "" {
+			return fallback, nil
+		}
+		fallback = append(fallback, region)
+	}
+}
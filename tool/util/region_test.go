@@ -0,0 +1,45 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestWarnCrossRegion(t *testing.T) {
+	assert.Empty(t, WarnCrossRegion("us-east-1", "us-east-1"))
+	assert.Empty(t, WarnCrossRegion("", "us-east-1"))
+	assert.Len(t, WarnCrossRegion("us-east-1", "eu-west-1"), 2)
+
+	warnings := WarnCrossRegion("us-east-1", "cn-north-1")
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "aws-cn")
+}
+
+func TestIsValidRegion(t *testing.T) {
+	for _, region := range []string{"us-east-1", "eu-west-1", "cn-north-1", "us-gov-west-1"} {
+		assert.True(t, IsValidRegion(region), region)
+	}
+	for _, region := range []string{"", "us-east", "us-east-9", "US-EAST-1", "mars-1"} {
+		assert.False(t, IsValidRegion(region), region)
+	}
+}
+
+func TestAskRegionFallback(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	fallback, err := AskRegionFallback("us-east-1")
+	assert.NoError(t, err)
+	assert.Empty(t, fallback)
+
+	testutil.Type(inputChan, "us-east-1", "us-west-2", "us-west-2", "mars-1", "cn-north-1", "eu-west-1", "")
+	fallback, err = AskRegionFallback("us-east-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"us-west-2", "eu-west-1"}, fallback)
+}
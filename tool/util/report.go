@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The formats the wizard reports its result in.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+var outputFormat = OutputText
+
+// SetOutputFormat selects how Report prints the result of the wizard, OutputText or OutputJSON.
+func SetOutputFormat(format string) error {
+	if format != OutputText && format != OutputJSON {
+		return fmt.Errorf("%q is not a valid output format, use %s or %s", format, OutputText, OutputJSON)
+	}
+	outputFormat = format
+	return nil
+}
+
+// JSONOutput reports whether the result of the wizard is printed as json.
+func JSONOutput() bool {
+	return outputFormat == OutputJSON
+}
+
+// savedPath and savedContent are the config the wizard saved last, which the json output includes.
+var (
+	savedPath    string
+	savedContent []byte
+)
+
+// Result is the json output of the wizard.
+type Result struct {
+	Success    bool            `json:"success"`
+	ConfigPath string          `json:"config_path,omitempty"`
+	Config     json.RawMessage `json:"config,omitempty"`
+	Error      *ResultError    `json:"error,omitempty"`
+}
+
+// ResultError is the error of the json output, with the code of the WizardError it comes from.
+type ResultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
+}
+
+// NewResult returns the result of a wizard run that ended with err, nil for a success, and saved the
+// config last recorded.
+func NewResult(err error) Result {
+	result := Result{Success: err == nil, ConfigPath: savedPath}
+	if json.Valid(savedContent) {
+		result.Config = savedContent
+	}
+	if err != nil {
+		result.Error = &ResultError{Code: ErrorCode(err), Message: err.Error(), Path: ErrorPath(err)}
+	}
+	return result
+}
+
+// Report prints the result of a wizard run that ended with err to w in the output format, and returns
+// the exit code of the run: 2 for an invalid argument, as for the flags the wizard cannot parse, and 1
+// for the other errors. The text output only prints the error, the rest is printed by the wizard as it
+// runs.
+func Report(w io.Writer, err error) int {
+	if JSONOutput() {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "\t")
+		if encodeErr := encoder.Encode(NewResult(err)); encodeErr != nil {
+			fmt.Fprintf(w, "Unable to print the result: %v\n", encodeErr)
+		}
+	} else if err != nil {
+		fmt.Fprintln(w, err)
+	}
+	if ErrorCode(err) == ErrCodeInvalidArgument {
+		return 2
+	}
+	if err != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCode(t *testing.T) {
+	failed := &WizardError{Code: ErrCodeConfigWrite, Path: "/etc/config.json", Err: os.ErrPermission}
+	err := fmt.Errorf("saving: %w", failed)
+	assert.ErrorIs(t, err, os.ErrPermission)
+	assert.Equal(t, ErrCodeConfigWrite, ErrorCode(err))
+	assert.Equal(t, "/etc/config.json", ErrorPath(err))
+	assert.Equal(t, ErrCodeInternal, ErrorCode(errors.New("unexpected")))
+}
+
+func TestReport(t *testing.T) {
+	t.Cleanup(func() { outputFormat = OutputText })
+	assert.Error(t, SetOutputFormat("yaml"))
+
+	var out bytes.Buffer
+	assert.Equal(t, 0, Report(&out, nil))
+	assert.Empty(t, out.String())
+	assert.Equal(t, 1, Report(&out, errors.New("unable to finalize the config")))
+	assert.Equal(t, "unable to finalize the config\n", out.String())
+	out.Reset()
+	assert.Equal(t, 2, Report(&out, &WizardError{Code: ErrCodeInvalidArgument, Err: errors.New(`unknown verbosity "loud"`)}))
+	assert.Equal(t, "unknown verbosity \"loud\"\n", out.String())
+
+	require.NoError(t, SetOutputFormat(OutputJSON))
+	SetConfigFilePath(filepath.Join(t.TempDir(), configJsonFileName))
+	defer SetConfigFilePath("")
+	_, err := SaveResultByteArrayToJsonFileE([]byte(`{"agent":{"debug":true}}`), "")
+	require.NoError(t, err)
+
+	out.Reset()
+	assert.Equal(t, 0, Report(&out, nil))
+	var result Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.True(t, result.Success)
+	assert.Equal(t, ConfigFilePath(), result.ConfigPath)
+	assert.JSONEq(t, `{"agent":{"debug":true}}`, string(result.Config))
+	assert.Nil(t, result.Error)
+
+	out.Reset()
+	assert.Equal(t, 1, Report(&out, &WizardError{Code: ErrCodeMissingAnswer, Path: "answers.yaml", Err: errors.New("no answer left")}))
+	result = Result{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.False(t, result.Success)
+	assert.Equal(t, &ResultError{Code: ErrCodeMissingAnswer, Message: "no answer left", Path: "answers.yaml"}, result.Error)
+}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+// roleARNRegex matches the ARN of an IAM role in any partition.
+var roleARNRegex = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/[\w+=,.@/-]+$`)
+
+// commonConfigPath is where the agent reads the credentials it assumes role_arn with.
+var commonConfigPath = paths.CommonConfigPath
+
+// profileCredentialKeys are the settings that make a profile a source of credentials by itself.
+var profileCredentialKeys = []string{"aws_access_key_id", "credential_process", "sso_session", "sso_start_url", "web_identity_token_file"}
+
+// ValidateRoleChain checks that the role_arn settings of m can be assumed with the credentials the agent
+// starts from, and returns a warning for every break found. The agent assumes role_arn with the shared
+// credential profile of common-config.toml when one is set, and that profile may itself assume a role
+// through source_profile, so the whole chain is followed in the shared credentials and config files:
+// missing profiles, loops, roles without a source, and a chain that already ends in the role the config
+// assumes again.
+func ValidateRoleChain(m map[string]interface{}) []string {
+	var warnings []string
+	var roles []string
+	for _, section := range credentialsRule.sections {
+		roleARN := getString(getMap(m, strings.Split(section, ".")...), "role_arn")
+		if roleARN == "" {
+			continue
+		}
+		if !roleARNRegex.MatchString(roleARN) {
+			warnings = append(warnings, fmt.Sprintf("%s.role_arn %q is not the ARN of an IAM role", section, roleARN))
+			continue
+		}
+		roles = append(roles, section)
+	}
+	if len(roles) == 0 {
+		return warnings
+	}
+	profile, credentialsFile := roleSourceProfile()
+	if profile == "" {
+		return warnings
+	}
+	_, configFile := SharedConfigFiles()
+	profiles := readSharedProfiles(credentialsFile, configFile)
+	if profiles[profile] == nil {
+		return append(warnings, fmt.Sprintf("the profile %q the agent assumes role_arn with is not defined in %s or %s", profile, credentialsFile, configFile))
+	}
+	lastRole, chainWarnings := followProfileChain(profile, profiles)
+	warnings = append(warnings, chainWarnings...)
+	for _, section := range roles {
+		if roleARN := getString(getMap(m, strings.Split(section, ".")...), "role_arn"); roleARN == lastRole {
+			warnings = append(warnings, fmt.Sprintf("%s.role_arn %s is already assumed by the profile %q, so the agent would assume it from itself", section, roleARN, profile))
+		}
+	}
+	return warnings
+}
+
+// roleSourceProfile returns the profile and credentials file of common-config.toml, with the
+// AWS_PROFILE environment variable as the fallback profile.
+func roleSourceProfile() (string, string) {
+	credentialsFile, _ := SharedConfigFiles()
+	profile := os.Getenv("AWS_PROFILE")
+	if f, err := os.Open(commonConfigPath); err == nil {
+		defer f.Close()
+		if config, err := commonconfig.Parse(f); err == nil {
+			credentials := config.CredentialsMap()
+			if name := credentials[commonconfig.CredentialProfile]; name != "" {
+				profile = name
+			}
+			if file := credentials[commonconfig.CredentialFile]; file != "" {
+				credentialsFile = file
+			}
+		}
+	}
+	return profile, credentialsFile
+}
+
+// followProfileChain walks source_profile from profile and returns the role the chain ends up in, if
+// any, with the problems found on the way.
+func followProfileChain(profile string, profiles map[string]map[string]string) (string, []string) {
+	var warnings []string
+	lastRole := ""
+	visited := map[string]bool{}
+	for name := profile; ; {
+		visited[name] = true
+		settings := profiles[name]
+		roleARN := settings["role_arn"]
+		if roleARN == "" {
+			if !hasProfileCredentials(settings) {
+				warnings = append(warnings, fmt.Sprintf("the profile %q has neither credentials nor a role to assume", name))
+			}
+			return lastRole, warnings
+		}
+		lastRole = roleARN
+		source, credentialSource := settings["source_profile"], settings["credential_source"]
+		switch {
+		case source != "" && credentialSource != "":
+			return lastRole, append(warnings, fmt.Sprintf("the profile %q sets both source_profile and credential_source", name))
+		case credentialSource != "":
+			return lastRole, warnings
+		case source == "":
+			return lastRole, append(warnings, fmt.Sprintf("the profile %q assumes %s without a source_profile or credential_source", name, roleARN))
+		case source == name:
+			// a profile may assume a role with its own static keys
+			return lastRole, warnings
+		case visited[source]:
+			return lastRole, append(warnings, fmt.Sprintf("the source_profile chain of %q loops back to %q", profile, source))
+		case profiles[source] == nil:
+			return lastRole, append(warnings, fmt.Sprintf("the profile %q uses the source_profile %q, which is not defined", name, source))
+		}
+		name = source
+	}
+}
+
+func hasProfileCredentials(settings map[string]string) bool {
+	for _, key := range profileCredentialKeys {
+		if settings[key] != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setUpCommonConfig(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "common-config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	original := commonConfigPath
+	commonConfigPath = path
+	t.Cleanup(func() { commonConfigPath = original })
+}
+
+func TestValidateRoleChain(t *testing.T) {
+	t.Setenv("AWS_PROFILE", "")
+	role := "arn:aws:iam::123456789012:role/CloudWatchAgent"
+	config := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"credentials": map[string]interface{}{"role_arn": role},
+		},
+	}
+	testCases := map[string]struct {
+		credentials string
+		config      string
+		profile     string
+		want        []string
+	}{
+		"StaticKeys": {
+			credentials: "[agent]\naws_access_key_id = AKIA\naws_secret_access_key = secret\n",
+			profile:     "agent",
+		},
+		"Chain": {
+			credentials: "[base]\naws_access_key_id = AKIA\n",
+			config:      "[profile agent]\nrole_arn = arn:aws:iam::123456789012:role/Hop\nsource_profile = base\n",
+			profile:     "agent",
+		},
+		"CredentialSource": {
+			config:  "[profile agent]\nrole_arn = arn:aws:iam::123456789012:role/Hop\ncredential_source = Ec2InstanceMetadata\n",
+			profile: "agent",
+		},
+		"MissingProfile": {
+			credentials: "[default]\naws_access_key_id = AKIA\n",
+			profile:     "agent",
+			want:        []string{`the profile "agent" the agent assumes role_arn with is not defined`},
+		},
+		"MissingSourceProfile": {
+			config:  "[profile agent]\nrole_arn = arn:aws:iam::123456789012:role/Hop\nsource_profile = base\n",
+			profile: "agent",
+			want:    []string{`the profile "agent" uses the source_profile "base", which is not defined`},
+		},
+		"NoSource": {
+			config:  "[profile agent]\nrole_arn = arn:aws:iam::123456789012:role/Hop\n",
+			profile: "agent",
+			want:    []string{`the profile "agent" assumes arn:aws:iam::123456789012:role/Hop without a source_profile or credential_source`},
+		},
+		"BothSources": {
+			config:  "[profile agent]\nrole_arn = arn:aws:iam::123456789012:role/Hop\nsource_profile = agent\ncredential_source = Environment\n",
+			profile: "agent",
+			want:    []string{`the profile "agent" sets both source_profile and credential_source`},
+		},
+		"Loop": {
+			config:  "[profile agent]\nrole_arn = arn:aws:iam::123456789012:role/A\nsource_profile = b\n[profile b]\nrole_arn = arn:aws:iam::123456789012:role/B\nsource_profile = agent\n",
+			profile: "agent",
+			want:    []string{`the source_profile chain of "agent" loops back to "agent"`},
+		},
+		"NoCredentials": {
+			config:  "[profile agent]\nregion = us-east-1\n",
+			profile: "agent",
+			want:    []string{`the profile "agent" has neither credentials nor a role to assume`},
+		},
+		"SameRole": {
+			credentials: "[base]\naws_access_key_id = AKIA\n",
+			config:      "[profile agent]\nrole_arn = " + role + "\nsource_profile = base\n",
+			profile:     "agent",
+			want:        []string{`agent.credentials.role_arn ` + role + ` is already assumed by the profile "agent"`},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			setUpSharedConfigFiles(t, testCase.credentials, testCase.config)
+			setUpCommonConfig(t, "[credentials]\n  shared_credential_profile = \""+testCase.profile+"\"\n")
+			warnings := ValidateRoleChain(config)
+			require.Len(t, warnings, len(testCase.want), warnings)
+			for i, want := range testCase.want {
+				assert.Contains(t, warnings[i], want)
+			}
+		})
+	}
+}
+
+func TestValidateRoleChainSources(t *testing.T) {
+	setUpSharedConfigFiles(t, "", "")
+	setUpCommonConfig(t, "")
+	t.Setenv("AWS_PROFILE", "")
+
+	assert.Empty(t, ValidateRoleChain(map[string]interface{}{}))
+
+	warnings := ValidateRoleChain(map[string]interface{}{
+		"logs": map[string]interface{}{
+			"credentials": map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:user/agent"},
+		},
+	})
+	assert.Equal(t, []string{`logs.credentials.role_arn "arn:aws:iam::123456789012:user/agent" is not the ARN of an IAM role`}, warnings)
+
+	// with no profile the agent starts from the default credential chain, which is not checked
+	config := map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"credentials": map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/Metrics"},
+		},
+	}
+	assert.Empty(t, ValidateRoleChain(config))
+
+	t.Setenv("AWS_PROFILE", "agent")
+	warnings = ValidateRoleChain(config)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], `the profile "agent"`)
+
+	file := filepath.Join(t.TempDir(), "agent-credentials")
+	require.NoError(t, os.WriteFile(file, []byte("[agent]\naws_access_key_id = AKIA\n"), 0600))
+	setUpCommonConfig(t, "[credentials]\n  shared_credential_file = \""+filepath.ToSlash(file)+"\"\n")
+	assert.Empty(t, ValidateRoleChain(config))
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// agentConfigDocument mirrors the sections of the agent json config as defined by its schema. The
+// agent section allows additional properties, the others do not.
+type agentConfigDocument struct {
+	Agent   map[string]interface{} `json:"agent,omitempty"`
+	Metrics *metricsSection        `json:"metrics,omitempty"`
+	Logs    *logsSection           `json:"logs,omitempty"`
+	Traces  *tracesSection         `json:"traces,omitempty"`
+}
+
+type metricsSection struct {
+	Namespace             *string     `json:"namespace,omitempty"`
+	AggregationDimensions *[][]string `json:"aggregation_dimensions,omitempty"`
+	AppendDimensions      interface{} `json:"append_dimensions,omitempty"`
+	MetricsCollected      interface{} `json:"metrics_collected,omitempty"`
+	ForceFlushInterval    interface{} `json:"force_flush_interval,omitempty"`
+	Credentials           interface{} `json:"credentials,omitempty"`
+	EndpointOverride      interface{} `json:"endpoint_override,omitempty"`
+}
+
+type logsSection struct {
+	LogsCollected      interface{} `json:"logs_collected,omitempty"`
+	MetricsCollected   interface{} `json:"metrics_collected,omitempty"`
+	LogStreamName      interface{} `json:"log_stream_name,omitempty"`
+	ForceFlushInterval interface{} `json:"force_flush_interval,omitempty"`
+	Credentials        interface{} `json:"credentials,omitempty"`
+	EndpointOverride   interface{} `json:"endpoint_override,omitempty"`
+}
+
+type tracesSection struct {
+	TracesCollected  interface{} `json:"traces_collected,omitempty"`
+	Concurrency      *int        `json:"concurrency,omitempty"`
+	BufferSizeMb     *int        `json:"buffer_size_mb,omitempty"`
+	ResourceArn      *string     `json:"resource_arn,omitempty"`
+	LocalMode        *bool       `json:"local_mode,omitempty"`
+	Insecure         *bool       `json:"insecure,omitempty"`
+	Credentials      interface{} `json:"credentials,omitempty"`
+	ProxyOverride    interface{} `json:"proxy_override,omitempty"`
+	EndpointOverride interface{} `json:"endpoint_override,omitempty"`
+	RegionOverride   *string     `json:"region_override,omitempty"`
+}
+
+// RoundTripError lists the json paths that did not survive a round trip through the config structs.
+type RoundTripError struct {
+	Lost    []string
+	Changed []string
+}
+
+func (e *RoundTripError) Error() string {
+	var parts []string
+	if len(e.Lost) > 0 {
+		parts = append(parts, "lost keys: "+strings.Join(e.Lost, ", "))
+	}
+	if len(e.Changed) > 0 {
+		parts = append(parts, "changed keys: "+strings.Join(e.Changed, ", "))
+	}
+	return "config did not round trip, " + strings.Join(parts, "; ")
+}
+
+// RoundTripValidate strictly decodes the json config into the config structs, so unknown fields and
+// values of the wrong type are rejected, then marshals it back and returns the canonical result. When
+// some keys were dropped or altered on the way, the canonical result is returned together with a
+// *RoundTripError listing them.
+func RoundTripValidate(b []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	var document agentConfigDocument
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("config does not match the agent config structure: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("config has trailing data after the json object")
+	}
+	canonical, err := json.MarshalIndent(document, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	var original, result map[string]interface{}
+	if err = json.Unmarshal(b, &original); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(canonical, &result); err != nil {
+		return nil, err
+	}
+	var roundTripErr RoundTripError
+	compareRoundTrip(original, result, "", &roundTripErr)
+	if len(roundTripErr.Lost) > 0 || len(roundTripErr.Changed) > 0 {
+		return canonical, &roundTripErr
+	}
+	return canonical, nil
+}
+
+func compareRoundTrip(original, result map[string]interface{}, path string, roundTripErr *RoundTripError) {
+	keys := make([]string, 0, len(original))
+	for key := range original {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		resultValue, ok := result[key]
+		if !ok {
+			roundTripErr.Lost = append(roundTripErr.Lost, childPath)
+			continue
+		}
+		originalMap, isMap := original[key].(map[string]interface{})
+		resultMap, isResultMap := resultValue.(map[string]interface{})
+		if isMap && isResultMap {
+			compareRoundTrip(originalMap, resultMap, childPath, roundTripErr)
+			continue
+		}
+		if !reflect.DeepEqual(original[key], resultValue) {
+			roundTripErr.Changed = append(roundTripErr.Changed, childPath)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTripValidate(t *testing.T) {
+	canonical, err := RoundTripValidate([]byte(`{"agent":{"debug":true},"metrics":{"namespace":"CWAgent","metrics_collected":{"mem":{"measurement":["mem_used_percent"]}}},"traces":{"concurrency":8}}`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"agent":{"debug":true},"metrics":{"namespace":"CWAgent","metrics_collected":{"mem":{"measurement":["mem_used_percent"]}}},"traces":{"concurrency":8}}`, string(canonical))
+}
+
+func TestRoundTripValidateRejectsUnknownFields(t *testing.T) {
+	_, err := RoundTripValidate([]byte(`{"metrics":{"metric_collected":{}}}`))
+	assert.ErrorContains(t, err, "metric_collected")
+
+	_, err = RoundTripValidate([]byte(`{"traces":{"concurrency":"8"}}`))
+	assert.Error(t, err)
+
+	_, err = RoundTripValidate([]byte(`{"agent":{}}{}`))
+	assert.Error(t, err)
+}
+
+func TestRoundTripValidateReportsLostKeys(t *testing.T) {
+	canonical, err := RoundTripValidate([]byte(`{"agent":{},"metrics":{"namespace":"","append_dimensions":null}}`))
+	var roundTripErr *RoundTripError
+	require.True(t, errors.As(err, &roundTripErr))
+	assert.Equal(t, []string{"agent", "metrics.append_dimensions"}, roundTripErr.Lost)
+	assert.Empty(t, roundTripErr.Changed)
+	assert.JSONEq(t, `{"metrics":{"namespace":""}}`, string(canonical))
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"os"
+	sysruntime "runtime"
+	"strings"
+)
+
+var (
+	selinuxEnforceFile  = "/sys/fs/selinux/enforce"
+	apparmorEnabledFile = "/sys/module/apparmor/parameters/enabled"
+)
+
+// SecurityModuleStatus reports whether SELinux is enforcing or AppArmor is enabled on this host, in
+// which case the agent may be denied access to the log files it is configured to read. The returned
+// bool is true when the description is worth a warning. Detection is best effort and only done on
+// Linux; everywhere else nothing is reported.
+func SecurityModuleStatus() (string, bool, error) {
+	if sysruntime.GOOS != OsTypeLinux {
+		return "", false, nil
+	}
+	enforce, err := readModuleParameter(selinuxEnforceFile)
+	if err != nil {
+		return "", false, err
+	}
+	if enforce == "1" {
+		return "SELinux is enforcing; ensure the agent context can read the configured log files", true, nil
+	}
+	enabled, err := readModuleParameter(apparmorEnabledFile)
+	if err != nil {
+		return "", false, err
+	}
+	if enabled == "Y" {
+		return "AppArmor is enabled; ensure no profile confined to the agent prevents it from reading the configured log files", true, nil
+	}
+	if enforce == "0" {
+		return "SELinux is permissive", false, nil
+	}
+	return "", false, nil
+}
+
+// readModuleParameter returns the trimmed content of a kernel parameter file, or "" when the file
+// does not exist because the module is not loaded.
+func readModuleParameter(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	sysruntime "runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setUpSecurityModuleFiles(t *testing.T, selinux, apparmor string) {
+	dir := t.TempDir()
+	originalSelinux, originalApparmor := selinuxEnforceFile, apparmorEnabledFile
+	selinuxEnforceFile = filepath.Join(dir, "enforce")
+	apparmorEnabledFile = filepath.Join(dir, "enabled")
+	t.Cleanup(func() {
+		selinuxEnforceFile, apparmorEnabledFile = originalSelinux, originalApparmor
+	})
+	if selinux != "" {
+		require.NoError(t, os.WriteFile(selinuxEnforceFile, []byte(selinux), 0644))
+	}
+	if apparmor != "" {
+		require.NoError(t, os.WriteFile(apparmorEnabledFile, []byte(apparmor), 0644))
+	}
+}
+
+func TestSecurityModuleStatus(t *testing.T) {
+	if sysruntime.GOOS != OsTypeLinux {
+		t.Skip("security modules are only detected on linux")
+	}
+	testCases := map[string]struct {
+		selinux, apparmor string
+		wantWarning       bool
+		wantStatus        string
+	}{
+		"None":              {},
+		"SELinuxEnforcing":  {selinux: "1\n", wantWarning: true, wantStatus: "SELinux is enforcing; ensure the agent context can read the configured log files"},
+		"SELinuxPermissive": {selinux: "0\n", wantStatus: "SELinux is permissive"},
+		"AppArmorEnabled":   {apparmor: "Y\n", wantWarning: true, wantStatus: "AppArmor is enabled; ensure no profile confined to the agent prevents it from reading the configured log files"},
+		"AppArmorDisabled":  {apparmor: "N\n"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			setUpSecurityModuleFiles(t, testCase.selinux, testCase.apparmor)
+			status, warn, err := SecurityModuleStatus()
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantWarning, warn)
+			assert.Equal(t, testCase.wantStatus, status)
+		})
+	}
+}
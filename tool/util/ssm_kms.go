@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const noKMSKey = "none"
+
+// kmsKeyRegex matches the key IDs, key ARNs, aliases and alias ARNs PutParameter accepts as KeyId.
+var kmsKeyRegex = regexp.MustCompile(`^(?:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|alias/[a-zA-Z0-9/_-]+|arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:(?:key/[0-9a-fA-F-]{36}|alias/[a-zA-Z0-9/_-]+))$`)
+
+// AskSSMKMSKey prompts for the KMS key the parameter holding the config is encrypted with, and returns it,
+// or "" to store the config in a plain String parameter. defaultKeyID is the key of the parameter written
+// over, if any, and none clears it.
+func AskSSMKMSKey(defaultKeyID string) (string, error) {
+	PrintDetail("A parameter encrypted with a KMS key is a SecureString, which the agent can only read with kms:Decrypt on the key. " +
+		"alias/aws/ssm is the key SSM manages for the account.")
+	defaultValue := defaultKeyID
+	if defaultValue == "" {
+		defaultValue = noKMSKey
+	}
+	answer, err := askValidated("Which KMS key (ID, ARN or alias) do you want to encrypt the parameter with? Enter none to store the config unencrypted.", defaultValue, func(answer string) error {
+		if strings.EqualFold(answer, noKMSKey) || kmsKeyRegex.MatchString(answer) {
+			return nil
+		}
+		return fmt.Errorf("%q is not a KMS key ID, key ARN or alias", answer)
+	})
+	if err != nil || strings.EqualFold(answer, noKMSKey) {
+		return "", err
+	}
+	return answer, nil
+}
@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskSSMKMSKey(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	keyID, err := AskSSMKMSKey("")
+	assert.NoError(t, err)
+	assert.Equal(t, "", keyID)
+
+	testutil.Type(inputChan, "")
+	keyID, err = AskSSMKMSKey("alias/aws/ssm")
+	assert.NoError(t, err)
+	assert.Equal(t, "alias/aws/ssm", keyID)
+
+	testutil.Type(inputChan, "None")
+	keyID, err = AskSSMKMSKey("alias/aws/ssm")
+	assert.NoError(t, err)
+	assert.Equal(t, "", keyID)
+
+	testutil.Type(inputChan, "my-key", "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab")
+	keyID, err = AskSSMKMSKey("")
+	assert.NoError(t, err)
+	assert.Equal(t, "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab", keyID)
+
+	for _, key := range []string{"1234abcd-12ab-34cd-56ef-1234567890ab", "alias/config-key", "arn:aws-cn:kms:cn-north-1:123456789012:alias/config-key"} {
+		assert.True(t, kmsKeyRegex.MatchString(key), key)
+	}
+	for _, key := range []string{"alias/", "1234abcd", "arn:aws:kms:us-west-2:123:key/1234"} {
+		assert.False(t, kmsKeyRegex.MatchString(key), key)
+	}
+}
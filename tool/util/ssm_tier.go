@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// Value size limits of the SSM parameter tiers, in bytes.
+const (
+	ssmStandardTierLimit = 4 * 1024
+	ssmAdvancedTierLimit = 8 * 1024
+)
+
+// AskSSMTier prompts for the tier of the parameter the config is stored in and returns it for
+// PutParameter. Standard parameters are free but hold at most 4 KB, Advanced ones hold 8 KB and are
+// charged per parameter, and Intelligent-Tiering picks Advanced only when the value needs it. Advanced is
+// suggested for configs over 4 KB, a Standard answer is refused for them, and configs over 8 KB cannot be
+// stored at all.
+func AskSSMTier(configSize int) (string, error) {
+	if configSize > ssmAdvancedTierLimit {
+		return "", fmt.Errorf("the config is %d bytes, which is over the %d byte limit of SSM parameters", configSize, ssmAdvancedTierLimit)
+	}
+	tiers := []string{ssm.ParameterTierStandard, ssm.ParameterTierAdvanced, ssm.ParameterTierIntelligentTiering}
+	defaultOption := 1
+	if configSize > ssmStandardTierLimit {
+		defaultOption = 2
+		PrintHint("The config is %d bytes, which is over the %d byte limit of Standard parameters.", configSize, ssmStandardTierLimit)
+	}
+	PrintDetail("Standard parameters are free. Advanced parameters are charged per parameter per month. " +
+		"Intelligent-Tiering stores the parameter as Advanced only when it does not fit in Standard.")
+	for {
+		tier := Choice("Which parameter tier do you want to use?", defaultOption, tiers)
+		if tier == ssm.ParameterTierStandard && configSize > ssmStandardTierLimit {
+			fmt.Printf("A %d byte config does not fit in a Standard parameter. Please choose another tier.\n", configSize)
+			continue
+		}
+		return tier, nil
+	}
+}
+
+// SSMTierFits returns whether a config of configSize bytes fits in a parameter of tier.
+func SSMTierFits(tier string, configSize int) bool {
+	if tier == ssm.ParameterTierStandard {
+		return configSize <= ssmStandardTierLimit
+	}
+	return configSize <= ssmAdvancedTierLimit
+}
@@ -0,0 +1,47 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskSSMTier(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	tier, err := AskSSMTier(1024)
+	assert.NoError(t, err)
+	assert.Equal(t, ssm.ParameterTierStandard, tier)
+
+	testutil.Type(inputChan, "3")
+	tier, err = AskSSMTier(4096)
+	assert.NoError(t, err)
+	assert.Equal(t, ssm.ParameterTierIntelligentTiering, tier)
+
+	testutil.Type(inputChan, "")
+	tier, err = AskSSMTier(5000)
+	assert.NoError(t, err)
+	assert.Equal(t, ssm.ParameterTierAdvanced, tier)
+
+	testutil.Type(inputChan, "1", "3")
+	tier, err = AskSSMTier(5000)
+	assert.NoError(t, err)
+	assert.Equal(t, ssm.ParameterTierIntelligentTiering, tier)
+
+	_, err = AskSSMTier(9000)
+	assert.Error(t, err)
+}
+
+func TestSSMTierFits(t *testing.T) {
+	assert.True(t, SSMTierFits(ssm.ParameterTierStandard, 4*1024))
+	assert.False(t, SSMTierFits(ssm.ParameterTierStandard, 4*1024+1))
+	assert.True(t, SSMTierFits(ssm.ParameterTierAdvanced, 8*1024))
+	assert.False(t, SSMTierFits(ssm.ParameterTierAdvanced, 8*1024+1))
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+const (
+	STSEndpointModeRegional = "regional"
+	STSEndpointModeLegacy   = "legacy"
+)
+
+// stsRegionalEndpoint is used by the sessions the wizard builds to call STS.
+var stsRegionalEndpoint = endpoints.RegionalSTSEndpoint
+
+// SetSTSEndpointMode selects whether the sessions the wizard builds call the regional STS endpoints or
+// the legacy global one.
+func SetSTSEndpointMode(mode string) error {
+	endpoint, err := endpoints.GetSTSRegionalEndpoint(mode)
+	if err != nil {
+		return err
+	}
+	stsRegionalEndpoint = endpoint
+	return nil
+}
+
+// AskSTSEndpointMode prompts for the STS endpoint mode and returns either STSEndpointModeRegional or
+// STSEndpointModeLegacy. Regional endpoints are the AWS recommendation, since they reduce latency and
+// keep working when the global endpoint in us-east-1 is impaired.
+func AskSTSEndpointMode() (string, error) {
+	PrintHint("Regional STS endpoints reduce latency and do not depend on us-east-1. The legacy mode sends the requests of most regions to the global endpoint sts.amazonaws.com.")
+	mode := Choice("Which STS endpoints should be used to assume roles?", 1, []string{STSEndpointModeRegional, STSEndpointModeLegacy})
+	if _, err := endpoints.GetSTSRegionalEndpoint(mode); err != nil {
+		return "", fmt.Errorf("%s is not a valid STS endpoint mode: %w", mode, err)
+	}
+	return mode, nil
+}
@@ -0,0 +1,36 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskSTSEndpointMode(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	mode, err := AskSTSEndpointMode()
+	assert.NoError(t, err)
+	assert.Equal(t, STSEndpointModeRegional, mode)
+
+	testutil.Type(inputChan, "3", "2")
+	mode, err = AskSTSEndpointMode()
+	assert.NoError(t, err)
+	assert.Equal(t, STSEndpointModeLegacy, mode)
+}
+
+func TestSetSTSEndpointMode(t *testing.T) {
+	t.Cleanup(func() { stsRegionalEndpoint = endpoints.RegionalSTSEndpoint })
+
+	assert.NoError(t, SetSTSEndpointMode(STSEndpointModeLegacy))
+	assert.Equal(t, endpoints.LegacySTSEndpoint, stsRegionalEndpoint)
+	assert.Error(t, SetSTSEndpointMode("global"))
+	assert.Equal(t, endpoints.LegacySTSEndpoint, stsRegionalEndpoint)
+}
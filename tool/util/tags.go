@@ -0,0 +1,141 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+)
+
+const maxDimensionNameLength = 255
+
+// instanceMetadata is the metadata client used by InstanceTags. It is replaced in tests.
+var instanceMetadata = func() (ec2MetadataAPI, error) {
+	ses, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			LogLevel: configaws.SDKLogLevel(),
+			Logger:   configaws.SDKLogger{},
+			Retryer:  retryer.NewIMDSRetryer(retryer.GetDefaultRetryNumber()),
+		},
+		EC2IMDSEndpointMode: imdsEndpointMode(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ec2metadata.New(ses), nil
+}
+
+type ec2MetadataAPI interface {
+	GetMetadata(p string) (string, error)
+}
+
+// InstanceTags returns the tags of this instance from the instance metadata, which only serves them
+// when access to tags in instance metadata is enabled.
+func InstanceTags() (map[string]string, error) {
+	md, err := instanceMetadata()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := md.GetMetadata("tags/instance")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the instance tags, make sure access to tags in instance metadata is enabled: %w", err)
+	}
+	tags := make(map[string]string)
+	for _, key := range strings.Fields(keys) {
+		value, err := md.GetMetadata("tags/instance/" + key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the value of instance tag %s: %w", key, err)
+		}
+		tags[key] = value
+	}
+	return tags, nil
+}
+
+// ValidateDimensionName checks name follows the CloudWatch rules for dimension names.
+func ValidateDimensionName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("the dimension name must contain at least one non whitespace character")
+	}
+	if len(name) > maxDimensionNameLength {
+		return fmt.Errorf("the dimension name must be at most %d characters, got %d", maxDimensionNameLength, len(name))
+	}
+	if strings.HasPrefix(name, ":") {
+		return fmt.Errorf("the dimension name must not start with a colon")
+	}
+	for _, r := range name {
+		if r < 0x20 || r > 0x7e {
+			return fmt.Errorf("the dimension name may only contain printable ASCII characters")
+		}
+	}
+	return nil
+}
+
+// AskTagDimensionMapping lets the user pick which of availableTags, as returned by InstanceTags, to add
+// as dimensions and under which name. The result maps dimension names to the tag values and can be used
+// as append_dimensions. The agent only resolves the ${aws:...} placeholders in append_dimensions, so
+// the dimensions keep the values the tags have while the wizard runs.
+func AskTagDimensionMapping(availableTags map[string]string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if len(availableTags) == 0 {
+		fmt.Println("No instance tags are available, make sure the instance has tags and access to tags in instance metadata is enabled.")
+		return mapping, nil
+	}
+	keys := make([]string, 0, len(availableTags))
+	for key := range availableTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	options := ""
+	for i, key := range keys {
+		options = fmt.Sprintf("%s%d. %s=%s\n", options, i+1, key, availableTags[key])
+	}
+	answer, err := askValidated(fmt.Sprintf("Which tags do you want to add as dimensions? Enter their numbers separated by commas.\n%s", options), "", func(answer string) error {
+		_, err := parseSelection(answer, len(keys))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	selection, _ := parseSelection(answer, len(keys))
+	for _, index := range selection {
+		key := keys[index]
+		name, err := askValidated(fmt.Sprintf("Dimension name for tag %s:", key), key, func(answer string) error {
+			if _, ok := mapping[answer]; ok {
+				return fmt.Errorf("the dimension %s was already added", answer)
+			}
+			return ValidateDimensionName(answer)
+		})
+		if err != nil {
+			return nil, err
+		}
+		mapping[name] = availableTags[key]
+	}
+	return mapping, nil
+}
+
+// parseSelection parses a comma separated list of 1 based option numbers into unique 0 based indexes.
+func parseSelection(answer string, count int) ([]int, error) {
+	var indexes []int
+	seen := make(map[int]bool)
+	for _, field := range strings.Split(answer, ",") {
+		option, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || option < 1 || option > count {
+			return nil, fmt.Errorf("%q is not an option between 1 and %d", strings.TrimSpace(field), count)
+		}
+		if !seen[option-1] {
+			seen[option-1] = true
+			indexes = append(indexes, option-1)
+		}
+	}
+	return indexes, nil
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+type mockMetadata map[string]string
+
+func (m mockMetadata) GetMetadata(p string) (string, error) {
+	if value, ok := m[p]; ok {
+		return value, nil
+	}
+	return "", errors.New("not found")
+}
+
+func TestInstanceTags(t *testing.T) {
+	original := instanceMetadata
+	t.Cleanup(func() { instanceMetadata = original })
+	instanceMetadata = func() (ec2MetadataAPI, error) {
+		return mockMetadata{"tags/instance": "Name\nteam", "tags/instance/Name": "web-1", "tags/instance/team": "payments"}, nil
+	}
+	tags, err := InstanceTags()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Name": "web-1", "team": "payments"}, tags)
+
+	instanceMetadata = func() (ec2MetadataAPI, error) { return mockMetadata{}, nil }
+	_, err = InstanceTags()
+	assert.Error(t, err)
+}
+
+func TestValidateDimensionName(t *testing.T) {
+	for _, name := range []string{"Team", "cost-center", "a b"} {
+		assert.NoError(t, ValidateDimensionName(name), name)
+	}
+	for _, name := range []string{"", "   ", ":Team", "tëam", strings.Repeat("a", maxDimensionNameLength+1)} {
+		assert.Error(t, ValidateDimensionName(name), name)
+	}
+}
+
+func TestAskTagDimensionMapping(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	mapping, err := AskTagDimensionMapping(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, mapping)
+
+	tags := map[string]string{"Name": "web-1", "team": "payments", "env": "prod"}
+	testutil.Type(inputChan, "4", "3, 1,3", ":Team", "Team", "Team", "")
+	mapping, err = AskTagDimensionMapping(tags)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Team": "payments", "Name": "web-1"}, mapping)
+}
@@ -0,0 +1,138 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// timestampTokenRegex maps the tokens the agent supports in timestamp_format to the regular expression
+// it matches them with, as the collect_list translator does.
+var timestampTokenRegex = map[string]string{
+	"%B":  `\w{7}`,
+	"%b":  `\w{3}`,
+	"%-m": `\s{0,1}\d{1,2}`,
+	"%m":  `\s{0,1}\d{1,2}`,
+	"%A":  `\w{6,9}`,
+	"%a":  `\w{3}`,
+	"%-d": `\s{0,1}\d{1,2}`,
+	"%d":  `\s{0,1}\d{1,2}`,
+	"%H":  `\d{2}`,
+	"%-I": `\d{1,2}`,
+	"%I":  `\d{2}`,
+	"%-M": `\d{1,2}`,
+	"%M":  `\d{2}`,
+	"%-S": `\d{1,2}`,
+	"%S":  `\d{2}`,
+	"%Y":  `\d{4}`,
+	"%y":  `\d{2}`,
+	"%p":  `\w{2}`,
+	"%Z":  `\w{3}`,
+	"%z":  `[\+-]\d{4}`,
+	"%f":  `(\d{1,9})`,
+}
+
+// ValidateTimestampFormat checks format only uses the strftime like tokens the agent supports in the
+// timestamp_format of a log entry, such as %Y-%m-%d %H:%M:%S, and includes at least one of them. A log
+// event whose timestamp cannot be parsed with the format gets the time it was read at instead.
+func ValidateTimestampFormat(format string) error {
+	if strings.TrimSpace(format) == "" {
+		return fmt.Errorf("the timestamp format must not be empty")
+	}
+	tokens, err := timestampTokens(format)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("%q contains no timestamp tokens, e.g. %%Y-%%m-%%d %%H:%%M:%%S", format)
+	}
+	return nil
+}
+
+// timestampTokens returns the tokens of format in order, or an error naming the first unsupported one.
+func timestampTokens(format string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		end := i + 2
+		if i+1 < len(format) && format[i+1] == '-' {
+			end++
+		}
+		if end > len(format) {
+			return nil, fmt.Errorf("the timestamp format %q ends with an incomplete token %q", format, format[i:])
+		}
+		token := format[i:end]
+		if _, ok := timestampTokenRegex[token]; !ok {
+			return nil, fmt.Errorf("%s is not a timestamp token the agent supports, use one of %s", token, strings.Join(supportedTimestampTokens(), " "))
+		}
+		tokens = append(tokens, token)
+		i = end - 1
+	}
+	return tokens, nil
+}
+
+func supportedTimestampTokens() []string {
+	tokens := make([]string, 0, len(timestampTokenRegex))
+	for token := range timestampTokenRegex {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// timestampFormatRegex builds the regular expression finding timestamps written in a valid format.
+func timestampFormatRegex(format string) *regexp.Regexp {
+	var expr strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			expr.WriteString(regexp.QuoteMeta(format[i : i+1]))
+			continue
+		}
+		end := i + 2
+		if format[i+1] == '-' {
+			end++
+		}
+		expr.WriteString(timestampTokenRegex[format[i:end]])
+		i = end - 1
+	}
+	return regexp.MustCompile(strings.TrimPrefix(expr.String(), `\s{0,1}`))
+}
+
+// AskTimestampFormat prompts for the timestamp_format of a log entry and lets the user check it against
+// sample lines until satisfied. An empty answer keeps the time the events are read at.
+func AskTimestampFormat() (string, error) {
+	PrintHint("Without a timestamp format, or when a line does not match it, log events get the time the agent reads them at.")
+	for {
+		format, err := askValidated("What is the timestamp format of the log file? e.g. %Y-%m-%d %H:%M:%S (Optional)", "", func(answer string) error {
+			if answer == "" {
+				return nil
+			}
+			return ValidateTimestampFormat(answer)
+		})
+		if err != nil {
+			return "", err
+		}
+		if format == "" || !No("Do you want to test the format against a sample log line?") {
+			return format, nil
+		}
+		fmt.Printf("Paste a log line:\n\r")
+		sample, err := readAnswer("Paste a log line:")
+		if err != nil {
+			return "", err
+		}
+		if timestamp := timestampFormatRegex(format).FindString(sample); timestamp != "" {
+			fmt.Printf("The timestamp %q of the line matches the format.\n", timestamp)
+		} else {
+			fmt.Println("The line has no timestamp in this format, its event gets the time it is read at.")
+		}
+		if Yes("Do you want to keep this format?") {
+			return format, nil
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestValidateTimestampFormat(t *testing.T) {
+	for _, format := range []string{"%Y-%m-%d %H:%M:%S", "%b %-d %H:%M:%S", "[%d/%b/%Y:%H:%M:%S %z]", "%H:%M:%S.%f", "%A, %B %-d %Y %-I:%M %p %Z", "%y%m%d"} {
+		assert.NoError(t, ValidateTimestampFormat(format), format)
+	}
+	testCases := map[string]string{
+		"":                  "must not be empty",
+		"yyyy-MM-dd":        "contains no timestamp tokens",
+		"%Y-%m-%d %T":       "%T is not a timestamp token the agent supports",
+		"%Y-%m-%d %H:%M:%":  "ends with an incomplete token",
+		"%Y-%-j":            "%-j is not a timestamp token",
+		"%Y-%m-%d %H:%M:%-": "ends with an incomplete token \"%-\"",
+		"100%% %Y":          "%% is not a timestamp token",
+		"%s":                "%s is not a timestamp token",
+	}
+	for format, message := range testCases {
+		err := ValidateTimestampFormat(format)
+		if assert.Error(t, err, format) {
+			assert.Contains(t, err.Error(), message, format)
+		}
+	}
+}
+
+func TestAskTimestampFormat(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	format, err := AskTimestampFormat()
+	assert.NoError(t, err)
+	assert.Empty(t, format)
+
+	testutil.Type(inputChan, "%Y-%m-%d %T", "%Y-%m-%d %H:%M:%S", "")
+	format, err = AskTimestampFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, "%Y-%m-%d %H:%M:%S", format)
+
+	testutil.Type(inputChan, "%d/%m/%Y", "1", "2024-01-31 12:00:00 ERROR", "2",
+		"%Y-%m-%d %H:%M:%S", "1", "2024-01-31 12:00:00 ERROR", "1")
+	format, err = AskTimestampFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, "%Y-%m-%d %H:%M:%S", format)
+}
+
+func TestTimestampFormatRegex(t *testing.T) {
+	assert.Equal(t, "2024-01-31 12:00:00", timestampFormatRegex("%Y-%m-%d %H:%M:%S").FindString("I 2024-01-31 12:00:00 started"))
+	assert.Equal(t, "[31/Jan/2024:12:00:00 +0000]", timestampFormatRegex("[%d/%b/%Y:%H:%M:%S %z]").FindString(`127.0.0.1 - - [31/Jan/2024:12:00:00 +0000] "GET /"`))
+	assert.Equal(t, "Jan  5 12:00:00", timestampFormatRegex("%b %-d %H:%M:%S").FindString("Jan  5 12:00:00 host sshd[1]"))
+	assert.Empty(t, timestampFormatRegex("%d/%m/%Y").FindString("2024-01-31 12:00:00"))
+}
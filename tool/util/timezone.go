@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	TimezoneLocal = "Local"
+	TimezoneUTC   = "UTC"
+
+	timezoneOther = "Other (enter an IANA time zone, e.g. America/New_York)"
+)
+
+// AskTimezone prompts for the time zone the timestamps of a log file are written in and returns the
+// value for the timezone field of the log entry. The agent parses timestamps either in the local time
+// zone of the host or in UTC, so a specific IANA zone is resolved to whichever of the two it matches
+// and rejected when it matches neither.
+func AskTimezone() (string, error) {
+	PrintHint("The time zone is only used to parse timestamps that do not include an offset.")
+	switch Choice("Which time zone are the timestamps of this log file in?", 1, []string{TimezoneLocal, TimezoneUTC, timezoneOther}) {
+	case TimezoneLocal:
+		return TimezoneLocal, nil
+	case TimezoneUTC:
+		return TimezoneUTC, nil
+	}
+	var timezone string
+	_, err := askValidated("Time zone:", "", func(answer string) error {
+		var err error
+		timezone, err = resolveTimezone(answer, time.Local)
+		return err
+	})
+	return timezone, err
+}
+
+// resolveTimezone maps the IANA zone name to TimezoneUTC or TimezoneLocal when the zone has the same
+// offsets as UTC or as local all year round.
+func resolveTimezone(name string, local *time.Location) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("the time zone must not be empty")
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return "", fmt.Errorf("unknown time zone: %w", err)
+	}
+	switch {
+	case sameOffsets(location, time.UTC):
+		return TimezoneUTC, nil
+	case sameOffsets(location, local):
+		return TimezoneLocal, nil
+	}
+	return "", fmt.Errorf("the agent parses timestamps in %s or %s only, and %s matches neither. Set the host time zone to %s or write the logs in UTC", TimezoneLocal, TimezoneUTC, name, name)
+}
+
+// sameOffsets compares the offsets of both locations in winter and in summer of the current year.
+func sameOffsets(a, b *time.Location) bool {
+	year := time.Now().Year()
+	for _, month := range []time.Month{time.January, time.July} {
+		instant := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		_, offsetA := instant.In(a).Zone()
+		_, offsetB := instant.In(b).Zone()
+		if offsetA != offsetB {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskTimezone(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	timezone, err := AskTimezone()
+	assert.NoError(t, err)
+	assert.Equal(t, TimezoneLocal, timezone)
+
+	testutil.Type(inputChan, "2")
+	timezone, err = AskTimezone()
+	assert.NoError(t, err)
+	assert.Equal(t, TimezoneUTC, timezone)
+
+	testutil.Type(inputChan, "3", "Mars/Olympus_Mons", "Etc/UTC")
+	timezone, err = AskTimezone()
+	assert.NoError(t, err)
+	assert.Equal(t, TimezoneUTC, timezone)
+}
+
+func TestResolveTimezone(t *testing.T) {
+	local, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	timezone, err := resolveTimezone("Etc/GMT", local)
+	assert.NoError(t, err)
+	assert.Equal(t, TimezoneUTC, timezone)
+
+	timezone, err = resolveTimezone("America/Toronto", local)
+	assert.NoError(t, err)
+	assert.Equal(t, TimezoneLocal, timezone)
+
+	_, err = resolveTimezone("Asia/Tokyo", local)
+	assert.Error(t, err)
+	_, err = resolveTimezone("", local)
+	assert.Error(t, err)
+	_, err = resolveTimezone("Not/AZone", local)
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	tlsKey         = "tls"
+	tlsCAFileKey   = "ca_file"
+	tlsCertFileKey = "cert_file"
+	tlsKeyFileKey  = "key_file"
+)
+
+// ValidateTLSSettings checks the files referenced by every tls object of m, such as the one of the otlp
+// receiver: ca_file and cert_file must be readable PEM certificates and key_file a readable PEM private
+// key matching cert_file. The agent fails to start its TLS endpoints otherwise. Certificates that have
+// expired or are not valid yet are reported too. Each problem starts with the json path of the field.
+func ValidateTLSSettings(m map[string]interface{}) []string {
+	var report ValidationReport
+	validateTLSSettings(m, &report)
+	problems := make([]string, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		problems = append(problems, issue.Path+": "+issue.Message)
+	}
+	return problems
+}
+
+func validateTLSSettings(m map[string]interface{}, report *ValidationReport) {
+	walkConfig(m, "", func(path, key string, value interface{}) {
+		settings, ok := value.(map[string]interface{})
+		if key != tlsKey || !ok {
+			return
+		}
+		if caFile := getString(settings, tlsCAFileKey); caFile != "" {
+			validateCertificateFile(path+"."+tlsCAFileKey, caFile, report)
+		}
+		certFile := getString(settings, tlsCertFileKey)
+		keyFile := getString(settings, tlsKeyFileKey)
+		certOk := certFile == "" || validateCertificateFile(path+"."+tlsCertFileKey, certFile, report)
+		keyOk := keyFile == "" || validatePrivateKeyFile(path+"."+tlsKeyFileKey, keyFile, report)
+		switch {
+		case certFile != "" && keyFile == "":
+			report.addError(path+"."+tlsKeyFileKey, "cert_file is set without the key_file of its private key")
+		case certFile == "" && keyFile != "":
+			report.addError(path+"."+tlsCertFileKey, "key_file is set without the cert_file of its certificate")
+		case certFile != "" && certOk && keyOk:
+			if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+				report.addError(path+"."+tlsKeyFileKey, "the private key %s does not match the certificate %s: %v", keyFile, certFile, err)
+			}
+		}
+	})
+}
+
+// validateCertificateFile reports why fileName is not a PEM file of certificates, and returns true when
+// it is one. Certificates outside of their validity period only get a warning.
+func validateCertificateFile(path, fileName string, report *ValidationReport) bool {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		report.addError(path, "cannot read %s: %v", fileName, err)
+		return false
+	}
+	count := 0
+	for block, rest := pem.Decode(content); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		count++
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			report.addError(path, "%s has a certificate that cannot be parsed: %v", fileName, err)
+			return false
+		}
+		now := time.Now()
+		if now.After(cert.NotAfter) {
+			report.addWarning(path, "the certificate %q of %s expired on %s", cert.Subject.CommonName, fileName, cert.NotAfter.Format(time.RFC3339))
+		} else if now.Before(cert.NotBefore) {
+			report.addWarning(path, "the certificate %q of %s is not valid before %s", cert.Subject.CommonName, fileName, cert.NotBefore.Format(time.RFC3339))
+		}
+	}
+	if count == 0 {
+		report.addError(path, "%s has no PEM encoded certificate", fileName)
+		return false
+	}
+	return true
+}
+
+// validatePrivateKeyFile reports why fileName is not a PEM file of a private key, and returns true when
+// it is one.
+func validatePrivateKeyFile(path, fileName string, report *ValidationReport) bool {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		report.addError(path, "cannot read %s: %v", fileName, err)
+		return false
+	}
+	for block, rest := pem.Decode(content); block != nil; block, rest = pem.Decode(rest) {
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			continue
+		}
+		if _, err = x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			return true
+		}
+		if _, err = x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			return true
+		}
+		if _, err = x509.ParseECPrivateKey(block.Bytes); err == nil {
+			return true
+		}
+		report.addError(path, "%s has a private key that cannot be parsed", fileName)
+		return false
+	}
+	report.addError(path, "%s has no PEM encoded private key", fileName)
+	return false
+}
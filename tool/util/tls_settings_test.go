@@ -0,0 +1,115 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate writes a self signed certificate valid until notAfter and its private key to dir.
+func writeTestCertificate(t *testing.T, dir, name string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    notAfter.Add(-48 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func otlpTLSConfig(tls map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"traces": map[string]interface{}{"traces_collected": map[string]interface{}{
+			"otlp": map[string]interface{}{"tls": tls},
+		}},
+	}
+}
+
+func TestValidateTLSSettings(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir, "server", time.Now().Add(24*time.Hour))
+	otherCertFile, _ := writeTestCertificate(t, dir, "other", time.Now().Add(24*time.Hour))
+	expiredCertFile, _ := writeTestCertificate(t, dir, "expired", time.Now().Add(-time.Hour))
+	malformedFile := filepath.Join(dir, "malformed.crt")
+	require.NoError(t, os.WriteFile(malformedFile, []byte("-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydA==\n-----END CERTIFICATE-----\n"), 0600))
+	notPEMFile := filepath.Join(dir, "notpem.key")
+	require.NoError(t, os.WriteFile(notPEMFile, []byte("not a key"), 0600))
+	missingFile := filepath.Join(dir, "missing.crt")
+
+	assert.Empty(t, ValidateTLSSettings(otlpTLSConfig(map[string]interface{}{"cert_file": certFile, "key_file": keyFile, "ca_file": certFile})))
+	assert.Empty(t, ValidateTLSSettings(map[string]interface{}{}))
+
+	const path = "traces.traces_collected.otlp.tls"
+	testCases := map[string]struct {
+		tls      map[string]interface{}
+		expected string
+	}{
+		"missing": {
+			tls:      map[string]interface{}{"cert_file": missingFile, "key_file": keyFile},
+			expected: path + ".cert_file: cannot read " + missingFile,
+		},
+		"malformed certificate": {
+			tls:      map[string]interface{}{"ca_file": malformedFile},
+			expected: path + ".ca_file: " + malformedFile + " has a certificate that cannot be parsed",
+		},
+		"not a certificate": {
+			tls:      map[string]interface{}{"ca_file": notPEMFile},
+			expected: path + ".ca_file: " + notPEMFile + " has no PEM encoded certificate",
+		},
+		"not a key": {
+			tls:      map[string]interface{}{"cert_file": certFile, "key_file": notPEMFile},
+			expected: path + ".key_file: " + notPEMFile + " has no PEM encoded private key",
+		},
+		"mismatched key": {
+			tls:      map[string]interface{}{"cert_file": otherCertFile, "key_file": keyFile},
+			expected: path + ".key_file: the private key " + keyFile + " does not match the certificate " + otherCertFile,
+		},
+		"key without certificate": {
+			tls:      map[string]interface{}{"key_file": keyFile},
+			expected: path + ".cert_file: key_file is set without the cert_file of its certificate",
+		},
+		"expired": {
+			tls:      map[string]interface{}{"ca_file": expiredCertFile},
+			expected: path + ".ca_file: the certificate \"expired\" of " + expiredCertFile + " expired on",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			problems := ValidateTLSSettings(otlpTLSConfig(testCase.tls))
+			require.Len(t, problems, 1)
+			assert.Contains(t, problems[0], testCase.expected)
+		})
+	}
+
+	report := ValidateConfig(otlpTLSConfig(map[string]interface{}{"ca_file": missingFile}))
+	require.Len(t, report.Errors(), 1)
+	assert.Equal(t, path+".ca_file", report.Errors()[0].Path)
+	report = ValidateConfig(otlpTLSConfig(map[string]interface{}{"ca_file": expiredCertFile}))
+	assert.True(t, report.Valid())
+	assert.Len(t, report.Warnings(), 1)
+}
@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// DefaultSamplingRate matches the fixed rate of the default X-Ray sampling rule.
+const DefaultSamplingRate = 0.05
+
+// defaultXrayDaemonAddress is where the X-Ray SDKs send segments and sampling requests by default.
+const defaultXrayDaemonAddress = "127.0.0.1:2000"
+
+var (
+	regionNameRegex = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+	hostnameRegex   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+)
+
+// AskTraceSettings asks whether to collect X-Ray traces and returns the traces section of the json
+// config: the address the agent listens on for segments, which the TCP sampling proxy uses as well,
+// and the region the segments are sent to when it differs from the instance region. An empty map is
+// returned when traces are not collected. The sampling rate is asked too, but the agent forwards the
+// sampling decisions of the SDKs, so it is printed for the sampling rule rather than written to the
+// section.
+func AskTraceSettings() (map[string]interface{}, error) {
+	if !No("Do you want the CloudWatch agent to collect X-Ray traces?") {
+		return map[string]interface{}{}, nil
+	}
+	address, err := askValidated("Which address do you want the agent to listen to for X-Ray segments?", defaultXrayDaemonAddress, validateDaemonAddress)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := AskSamplingRate()
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Set the fixed rate of the X-Ray sampling rule your applications use to %v.\n", rate)
+	region, err := askValidated("Which region do you want to send the traces to? (Optional, defaults to the instance region)", "", validateOptionalRegion)
+	if err != nil {
+		return nil, err
+	}
+	section := map[string]interface{}{
+		"traces_collected": map[string]interface{}{
+			"xray": map[string]interface{}{
+				"bind_address": address,
+				"tcp_proxy":    map[string]interface{}{"bind_address": address},
+			},
+		},
+	}
+	if region != "" {
+		section["region_override"] = region
+	}
+	return section, nil
+}
+
+// validateDaemonAddress checks address is a host:port the agent can listen on.
+func validateDaemonAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%q must be in the form host:port, e.g. %s", address, defaultXrayDaemonAddress)
+	}
+	if host != "" && net.ParseIP(host) == nil && !hostnameRegex.MatchString(host) {
+		return fmt.Errorf("%q is not a valid IP address or host name", host)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("port %s must be a number between 1 and 65535", port)
+	}
+	return nil
+}
+
+// validateOptionalRegion accepts an empty answer or the name of a region of a known partition.
+func validateOptionalRegion(region string) error {
+	if region == "" {
+		return nil
+	}
+	if !regionNameRegex.MatchString(region) {
+		return fmt.Errorf("%q is not a region name, e.g. us-east-1", region)
+	}
+	if _, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); !ok {
+		return fmt.Errorf("%s is not a known region", region)
+	}
+	return nil
+}
+
+// AskSamplingRate prompts for the fraction of requests to trace, between 0 and 1. The agent forwards
+// whatever the SDKs send, so the rate is meant for the fixed rate of the X-Ray sampling rule the
+// instrumented applications use, which is what the tracing cost scales with.
+func AskSamplingRate() (float64, error) {
+	PrintHint("X-Ray charges per trace recorded, so a lower sampling rate reduces cost.")
+	answer, err := askValidated("What sampling rate (0 to 1) do you want for traces?", strconv.FormatFloat(DefaultSamplingRate, 'f', -1, 64), validateSamplingRate)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(answer, 64)
+}
+
+func validateSamplingRate(answer string) error {
+	rate, err := strconv.ParseFloat(answer, 64)
+	if err != nil {
+		return fmt.Errorf("%s is not a number", answer)
+	}
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("sampling rate must be between 0 and 1, got %v", rate)
+	}
+	return nil
+}
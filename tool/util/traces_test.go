@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskSamplingRate(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	rate, err := AskSamplingRate()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultSamplingRate, rate)
+
+	testutil.Type(inputChan, "1.5", "-0.1", "ten", "0.25")
+	rate, err = AskSamplingRate()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, rate)
+}
+
+func TestAskTraceSettings(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	section, err := AskTraceSettings()
+	assert.NoError(t, err)
+	assert.Empty(t, section)
+
+	testutil.Type(inputChan, "1", "", "", "")
+	section, err = AskTraceSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"traces_collected": map[string]interface{}{
+			"xray": map[string]interface{}{
+				"bind_address": "127.0.0.1:2000",
+				"tcp_proxy":    map[string]interface{}{"bind_address": "127.0.0.1:2000"},
+			},
+		},
+	}, section)
+
+	testutil.Type(inputChan, "1", "2000", "0.0.0.0:70000", "0.0.0.0:2000", "0.1", "us-east", "mars-north-1x", "eu-west-1")
+	section, err = AskTraceSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", section["region_override"])
+	assert.Equal(t, "0.0.0.0:2000", section["traces_collected"].(map[string]interface{})["xray"].(map[string]interface{})["bind_address"])
+}
+
+func TestValidateDaemonAddress(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:2000", "[::1]:2000", "xray-daemon.local:2000", ":2000"} {
+		assert.NoError(t, validateDaemonAddress(address), address)
+	}
+	for _, address := range []string{"2000", "127.0.0.1", "127.0.0.1:0", "127.0.0.1:port", "bad_host:2000"} {
+		assert.Error(t, validateDaemonAddress(address), address)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+)
+
+const (
+	metricGranularityKey         = "metric_granularity"
+	enhancedContainerInsightsKey = "enhanced_container_insights"
+	// baseMetricGranularity is the metric_granularity of the Container Insights metrics without the
+	// enhanced ones.
+	baseMetricGranularity = 1
+)
+
+// removedSections are the top level sections older agents read that the current one ignores.
+var removedSections = []string{"csm"}
+
+// UpgradeConfig migrates the config of an older agent to the current one and describes every change
+// made, in order: the config is finalized with FinalizeConfig, the settings replaced by others are
+// converted and the sections the agent no longer supports are removed. Defaults are not added, the agent
+// applies them itself. m is left unchanged; the upgraded config is a copy of it.
+func UpgradeConfig(m map[string]interface{}) (map[string]interface{}, []string, error) {
+	result, changes, err := FinalizeConfig(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	if change := upgradeMetricGranularity(result); change != "" {
+		changes = append(changes, change)
+	}
+	for _, section := range removedSections {
+		if _, ok := result[section]; ok {
+			delete(result, section)
+			changes = append(changes, fmt.Sprintf("removed the %s section, which the agent no longer supports", section))
+		}
+	}
+	return result, changes, nil
+}
+
+// upgradeMetricGranularity replaces the metric_granularity of the kubernetes section, where a level
+// above the base one turned on the enhanced metrics, with enhanced_container_insights.
+func upgradeMetricGranularity(m map[string]interface{}) string {
+	kubernetes := getMap(m, "logs", "metrics_collected", "kubernetes")
+	value, ok := kubernetes[metricGranularityKey]
+	if !ok {
+		return ""
+	}
+	delete(kubernetes, metricGranularityKey)
+	if _, ok = kubernetes[enhancedContainerInsightsKey]; ok {
+		return fmt.Sprintf("removed logs.metrics_collected.kubernetes.%s, which %s replaces", metricGranularityKey, enhancedContainerInsightsKey)
+	}
+	level, _ := toNumber(value)
+	kubernetes[enhancedContainerInsightsKey] = level > baseMetricGranularity
+	return fmt.Sprintf("replaced logs.metrics_collected.kubernetes.%s %v with %s %t", metricGranularityKey, value, enhancedContainerInsightsKey, level > baseMetricGranularity)
+}
@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeConfig(t *testing.T) {
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{"debug": "true"},
+		"csm":   map[string]interface{}{"port": float64(31000)},
+		"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{
+			"cpu": map[string]interface{}{"measurement": []interface{}{"usage_idle"}},
+		}},
+		"logs": map[string]interface{}{"metrics_collected": map[string]interface{}{
+			"kubernetes": map[string]interface{}{"cluster_name": "prod", "metric_granularity": float64(2)},
+		}},
+	}
+
+	upgraded, changes, err := UpgradeConfig(m)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"converted 1 boolean strings to json booleans",
+		"replaced logs.metrics_collected.kubernetes.metric_granularity 2 with enhanced_container_insights true",
+		"removed the csm section, which the agent no longer supports",
+	}, changes)
+	assert.Equal(t, map[string]interface{}{"debug": true}, upgraded["agent"])
+	assert.NotContains(t, upgraded, "csm")
+	assert.Equal(t, map[string]interface{}{"cluster_name": "prod", "enhanced_container_insights": true},
+		getMap(upgraded, "logs", "metrics_collected", "kubernetes"))
+	assert.Contains(t, m, "csm", "the config upgraded is left unchanged")
+
+	upgraded, changes, err = UpgradeConfig(upgraded)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Contains(t, upgraded, "metrics")
+}
+
+func TestUpgradeConfig_KeepsEnhancedContainerInsights(t *testing.T) {
+	m := map[string]interface{}{
+		"logs": map[string]interface{}{"metrics_collected": map[string]interface{}{
+			"kubernetes": map[string]interface{}{"enhanced_container_insights": false, "metric_granularity": float64(2)},
+		}},
+	}
+
+	upgraded, changes, err := UpgradeConfig(m)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"removed logs.metrics_collected.kubernetes.metric_granularity, which enhanced_container_insights replaces"}, changes)
+	assert.Equal(t, map[string]interface{}{"enhanced_container_insights": false},
+		getMap(upgraded, "logs", "metrics_collected", "kubernetes"))
+}
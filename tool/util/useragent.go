@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const maxUserAgentSuffixLength = 128
+
+// userAgentSuffixRegex allows the product tokens, versions and comments of a User-Agent header,
+// e.g. "my-org/1.0 (team-a)".
+var userAgentSuffixRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z/() ]+$")
+
+// ValidateUserAgentSuffix checks the suffix can be put in a User-Agent header as is.
+func ValidateUserAgentSuffix(suffix string) error {
+	if suffix == "" {
+		return fmt.Errorf("the user agent suffix must not be empty")
+	}
+	if len(suffix) > maxUserAgentSuffixLength {
+		return fmt.Errorf("the user agent suffix must be at most %d characters, got %d", maxUserAgentSuffixLength, len(suffix))
+	}
+	if !userAgentSuffixRegex.MatchString(suffix) {
+		return fmt.Errorf("%q may only contain letters, digits, spaces and !#$%%&'*+-.^_`|~/()", suffix)
+	}
+	return nil
+}
+
+// AskUserAgentSuffix prompts for the string set as user_agent in the agent section, which the agent
+// sends on its AWS requests so the calls can be told apart, e.g. in CloudTrail.
+func AskUserAgentSuffix() (string, error) {
+	PrintHint("The user agent is sent with every AWS request the agent makes, e.g. my-org/1.0.")
+	answer, err := askValidated("What user agent do you want the agent to send?", "", func(answer string) error {
+		return ValidateUserAgentSuffix(strings.TrimSpace(answer))
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(answer), nil
+}
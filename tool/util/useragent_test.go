@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestValidateUserAgentSuffix(t *testing.T) {
+	for _, suffix := range []string{"my-org", "my-org/1.0", "my-org/1.0 (team-a)"} {
+		assert.NoError(t, ValidateUserAgentSuffix(suffix), suffix)
+	}
+	for _, suffix := range []string{"", "new\nline", "quote\"", "tab\t", "héllo", strings.Repeat("a", maxUserAgentSuffixLength+1)} {
+		assert.Error(t, ValidateUserAgentSuffix(suffix), suffix)
+	}
+}
+
+func TestAskUserAgentSuffix(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "", "bad\"value", " my-org/1.0 ")
+	suffix, err := AskUserAgentSuffix()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-org/1.0", suffix)
+}
@@ -5,20 +5,22 @@ package util
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	sysruntime "runtime"
-	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
@@ -42,6 +44,12 @@ const (
 	InfrequentAccessLogGroupClass = "INFREQUENT_ACCESS"
 )
 
+// ConfigPathEnv overrides the full path of the config file, ConfigDirEnv only its directory.
+const (
+	ConfigPathEnv = "CWAGENT_CONFIG_PATH"
+	ConfigDirEnv  = "CWAGENT_CONFIG_DIR"
+)
+
 func CurOS() string {
 	return sysruntime.GOOS
 }
@@ -70,41 +78,121 @@ func CurPath() string {
 	return path.Dir(ex)
 }
 
+// configFilePath is the config file location set with SetConfigFilePath.
+var configFilePath string
+
+// SetConfigFilePath overrides where the wizard reads and writes the config file. An empty path restores
+// the lookup of ConfigFilePath.
+func SetConfigFilePath(filePath string) {
+	configFilePath = filePath
+}
+
+// ConfigFilePath returns the path set with SetConfigFilePath, else the ConfigPathEnv path, else
+// config.json in the ConfigDirEnv directory, else config.json next to the wizard executable.
 func ConfigFilePath() string {
+	if configFilePath != "" {
+		return configFilePath
+	}
+	if filePath := os.Getenv(ConfigPathEnv); filePath != "" {
+		return filePath
+	}
+	if dir := os.Getenv(ConfigDirEnv); dir != "" {
+		return filepath.Join(dir, configJsonFileName)
+	}
 	return filepath.Join(CurPath(), configJsonFileName)
 }
 
+// PermissionCheck exits with the error of PermissionCheckE, if any.
+//
+// Deprecated: use PermissionCheckE, which returns the error.
 func PermissionCheck() {
-	filePath := ConfigFilePath()
+	if err := PermissionCheckE(); err != nil {
+		exitWithError(err)
+	}
+}
+
+// PermissionCheckE makes sure the wizard can write the config to ConfigFilePath. The error is a
+// *WizardError with ErrCodePermission, or ErrCodeConfigWrite when the directory does not exist.
+func PermissionCheckE() error {
+	return checkConfigFileWritable(ConfigFilePath())
+}
+
+// checkConfigFileWritable tells a missing directory, e.g. a mistyped override, apart from missing write
+// permission.
+func checkConfigFileWritable(filePath string) error {
+	dir := filepath.Dir(filePath)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return &WizardError{Code: ErrCodeConfigWrite, Path: filePath,
+			Err: fmt.Errorf("the directory %s of the config file does not exist, create it or point %s or %s at an existing location", dir, ConfigPathEnv, ConfigDirEnv)}
+	}
 	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE, 0666)
 	if err != nil {
-		fmt.Printf("Make sure that you have write permission to %s\n", filePath)
-		os.Exit(1)
+		return &WizardError{Code: ErrCodePermission, Path: filePath, Err: fmt.Errorf("make sure that you have write permission to %s: %w", filePath, err)}
 	}
-	defer f.Close()
-	return
+	return f.Close()
 }
 
+// ReadConfigFromJsonFile exits with the error of ReadConfigFromJsonFileE, if any.
+//
+// Deprecated: use ReadConfigFromJsonFileE, which returns the error.
 func ReadConfigFromJsonFile() string {
+	content, err := ReadConfigFromJsonFileE()
+	if err != nil {
+		exitWithError(err)
+	}
+	return content
+}
+
+// ReadConfigFromJsonFileE reads the config at ConfigFilePath. The error is a *WizardError with
+// ErrCodeConfigRead wrapping the os error, e.g. os.ErrNotExist or os.ErrPermission.
+func ReadConfigFromJsonFileE() (string, error) {
 	filePath := ConfigFilePath()
 	byteArray, err := os.ReadFile(filePath)
 	if err != nil {
-		fmt.Printf("Error in reading config from file %s: %v\n", filePath, err)
-		os.Exit(1)
+		return "", &WizardError{Code: ErrCodeConfigRead, Path: filePath, Err: fmt.Errorf("error in reading config from file %s: %w", filePath, err)}
 	}
-	return string(byteArray)
+	return string(byteArray), nil
 }
 
+// SerializeResultMapToJsonByteArray exits with the error of SerializeResultMapToJsonByteArrayE, if any.
+//
+// Deprecated: use SerializeResultMapToJsonByteArrayE, which returns the error.
 func SerializeResultMapToJsonByteArray(resultMap map[string]interface{}) []byte {
-	resultByteArray, err := json.MarshalIndent(resultMap, "", "\t")
+	resultByteArray, err := SerializeResultMapToJsonByteArrayE(resultMap)
 	if err != nil {
-		fmt.Printf("Result map to byte array json marshal error: %v\n", err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 	return resultByteArray
 }
 
+// SerializeResultMapToJsonByteArrayE marshals the config the way the wizard writes it. The error is a
+// *WizardError with ErrCodeConfigSerialize wrapping the json error, e.g. a *json.UnsupportedValueError.
+func SerializeResultMapToJsonByteArrayE(resultMap map[string]interface{}) ([]byte, error) {
+	resultByteArray, err := json.MarshalIndent(resultMap, "", "\t")
+	if err != nil {
+		return nil, &WizardError{Code: ErrCodeConfigSerialize, Err: fmt.Errorf("result map to byte array json marshal error: %w", err)}
+	}
+	return resultByteArray, nil
+}
+
+// SaveResultByteArrayToJsonFile exits with the error of SaveResultByteArrayToJsonFileE, if any.
+//
+// Deprecated: use SaveResultByteArrayToJsonFileE, which returns the error.
 func SaveResultByteArrayToJsonFile(resultByteArray []byte, filePath string) string {
+	savedPath, err := SaveResultByteArrayToJsonFileE(resultByteArray, filePath)
+	if err != nil {
+		exitWithError(err)
+	}
+	return savedPath
+}
+
+// SaveResultByteArrayToJsonFileE writes the config to filePath, or to ConfigFilePath when filePath is
+// empty, after backing up the existing one, and returns the path written. The error is a *WizardError
+// with ErrCodeConfigWrite wrapping the os error of the write.
+func SaveResultByteArrayToJsonFileE(resultByteArray []byte, filePath string) (string, error) {
+	if filePath == "" {
+		filePath = ConfigFilePath()
+	}
 	//make a backup of file if it exists
 	dirPath := getBackupDir()
 	err := FileBackup(filePath, dirPath)
@@ -115,42 +203,32 @@ func SaveResultByteArrayToJsonFile(resultByteArray []byte, filePath string) stri
 	}
 	err = os.WriteFile(filePath, resultByteArray, 0755)
 	if err != nil {
-		fmt.Printf("Error in writing file to %s: %v\nMake sure that you have write permission to %s.", filePath, err, filePath)
-		os.Exit(1)
+		return filePath, &WizardError{Code: ErrCodeConfigWrite, Path: filePath, Err: fmt.Errorf("error in writing file to %s: %w", filePath, err)}
 	}
+	savedPath, savedContent = filePath, resultByteArray
 	fmt.Printf("Saved config file to %s successfully.\n", filePath)
-	return filePath
+	return filePath, nil
 }
+
+// exitWithError prints err and exits, which is how the helpers without an error result stop.
+func exitWithError(err error) {
+	fmt.Println(err)
+	os.Exit(1)
+}
+
 func backupConfigFile(configFilePath, backupDirPath string) error {
 
 	err := os.MkdirAll(backupDirPath, 0755)
 	if err != nil {
 		return err
 	}
-	files, err := os.ReadDir(backupDirPath)
+	newBackupNumber, err := nextBackupNumber(backupDirPath)
 	if err != nil {
 		return err
 	}
-
-	newBackupNumber := len(files) + 1
-	if len(files) >= 10 {
-		sort.Slice(files, func(i, j int) bool {
-			infoI, _ := files[i].Info()
-			infoJ, _ := files[j].Info()
-			return infoI.ModTime().Before(infoJ.ModTime())
-		})
-
-		removedFileName := files[0].Name()
-		removedNumberStr := strings.TrimSuffix(strings.TrimPrefix(removedFileName, "config-"), ".json")
-		removedNumber, err := strconv.Atoi(removedNumberStr)
-		if err != nil {
-			return err
-		}
-		err = os.Remove(filepath.Join(backupDirPath, files[0].Name()))
-		if err != nil {
-			return err
-		}
-		newBackupNumber = removedNumber + 10
+	// make room for the new backup so that at most BackupRetention are kept
+	if _, err = PruneConfigBackups(backupDirPath, BackupRetention-1); err != nil {
+		return err
 	}
 	backupFilePath := filepath.Join(backupDirPath, fmt.Sprintf("config-%d.json", newBackupNumber))
 
@@ -208,35 +286,49 @@ func SDKCredentials() (accessKey, secretKey string, creds *credentials.Credentia
 	return
 }
 
-func DefaultEC2Region() (region string) {
+// DefaultEC2Region returns the region of the instance from the instance metadata service. IMDSv2 is
+// tried first, so the region is found on instances that require a session token, then IMDSv1. The
+// error is ErrNoEC2Region when the metadata service answers without a region, and wraps the error of
+// the request when it cannot be reached, e.g. when the wizard does not run on EC2.
+func DefaultEC2Region() (string, error) {
 	fmt.Println("Trying to fetch the default region based on ec2 metadata...")
 	// imds should by the time user can run the wizard
-	sesFallBackDisabled, err := session.NewSession(&aws.Config{
-		LogLevel:                  configaws.SDKLogLevel(),
-		Logger:                    configaws.SDKLogger{},
-		EC2MetadataEnableFallback: aws.Bool(false),
-		Retryer:                   retryer.NewIMDSRetryer(retryer.GetDefaultRetryNumber()),
-	})
-	sesFallBackEnabled, err := session.NewSession(&aws.Config{
-		LogLevel: configaws.SDKLogLevel(),
-		Logger:   configaws.SDKLogger{},
+	endpointMode := imdsEndpointMode()
+	region, err := ec2MetadataRegion(endpointMode, false)
+	if err == nil || errors.Is(err, ErrNoEC2Region) {
+		return region, err
+	}
+	log.Printf("D! could not get region from imds v2 thus enable fallback: %v", err)
+	region, err = ec2MetadataRegion(endpointMode, true)
+	if err != nil && !errors.Is(err, ErrNoEC2Region) {
+		return "", fmt.Errorf("could not get region from ec2 metadata: %w", err)
+	}
+	return region, err
+}
+
+func ec2MetadataRegion(endpointMode endpoints.EC2IMDSEndpointModeState, enableFallback bool) (string, error) {
+	ses, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			LogLevel:                  configaws.SDKLogLevel(),
+			Logger:                    configaws.SDKLogger{},
+			HTTPClient:                &http.Client{Timeout: imdsTimeout},
+			EC2MetadataEnableFallback: aws.Bool(enableFallback),
+			Retryer:                   retryer.NewIMDSRetryer(imdsRetryNumber()),
+		},
+		EC2IMDSEndpoint:     imdsEndpoint,
+		EC2IMDSEndpointMode: endpointMode,
 	})
 	if err != nil {
-		return
+		return "", err
 	}
-	md := ec2metadata.New(sesFallBackDisabled)
-	if info, errOuter := md.Region(); errOuter == nil {
-		region = info
-	} else {
-		log.Printf("D! could not get region from imds v2 thus enable fallback")
-		mdInner := ec2metadata.New(sesFallBackEnabled)
-		if infoInner, errInner := mdInner.Region(); errInner == nil {
-			region = infoInner
-		} else {
-			fmt.Printf("W! could not get region from ec2 metadata... %v", errInner)
-		}
+	document, err := ec2metadata.New(ses).GetInstanceIdentityDocument()
+	if err != nil {
+		return "", err
 	}
-	return
+	if document.Region == "" {
+		return "", ErrNoEC2Region
+	}
+	return document.Region, nil
 }
 
 func AddToMap(ctx *runtime.Context, resultMap map[string]interface{}, obj interfaze.ConvertibleToMap) {
@@ -257,27 +349,23 @@ func No(question string) bool {
 }
 
 func AskWithDefault(question, defaultValue string) string {
-	for {
-		var answer string
-		fmt.Printf("%s\ndefault choice: [%s]\n\r", question, defaultValue)
-
-		stdin.Scanln(&answer)
-
-		if answer == "" {
-			return defaultValue
-		}
-		return answer
+	fmt.Printf("%s\ndefault choice: [%s]\n\r", question, defaultValue)
+	answer, _ := readAnswer(question)
+	if answer == "" {
+		return defaultValue
 	}
+	return answer
 }
 
 func Ask(question string) string {
 	return Choice(question, 0, nil)
 }
 
-// defaultOption value starts from 1
+// defaultOption value starts from 1. An option is chosen by its number or its value. Once the answer
+// source is exhausted the default option is chosen, and an empty answer is returned when there is no
+// valid default or once the answer source had no answer for a question, see AnswerErr.
 func Choice(question string, defaultOption int, validValues []string) string {
 	for {
-		var answer string
 		options := ""
 		if validValues != nil {
 			for i := range validValues {
@@ -288,30 +376,28 @@ func Choice(question string, defaultOption int, validValues []string) string {
 			fmt.Printf("%s\n\r", question)
 		}
 
-		stdin.Scanln(&answer)
+		answer, readErr := readAnswer(question)
+		exhausted := errors.Is(readErr, ErrNoAnswer)
 
-		if validValues == nil {
+		if validValues == nil || answerErr != nil {
 			return answer
 		}
 
-		var option int
-		var err error
-		if answer == "" {
-			option = defaultOption
-		} else {
-			option, err = strconv.Atoi(answer)
-		}
-		if err == nil && option > 0 && option <= len(validValues) {
+		if option, ok := choiceOption(answer, defaultOption, validValues); ok {
 			return validValues[option-1]
 		}
+		if exhausted {
+			reportMissingAnswer(question)
+			return ""
+		}
 		fmt.Printf("The value %s is not valid to this question.\nPlease retry to answer:\n", answer)
 	}
 }
 
-// ChoiceIndex returns index of choice chosen
+// ChoiceIndex returns index of choice chosen, or -1 when the answer source is exhausted and there is
+// no valid default or once it had no answer for a question, see AnswerErr.
 func ChoiceIndex(question string, defaultOption int, validValues []string) int {
 	for {
-		var answer string
 		options := ""
 		if validValues != nil {
 			for i := range validValues {
@@ -319,21 +405,44 @@ func ChoiceIndex(question string, defaultOption int, validValues []string) int {
 			}
 			fmt.Printf("%s\n%sdefault choice: [%d]:\n\r", question, options, defaultOption)
 		}
-		stdin.Scanln(&answer)
-		var option int
-		var err error
-		if answer == "" {
-			option = defaultOption
-		} else {
-			option, err = strconv.Atoi(answer)
+		answer, readErr := readAnswer(question)
+		exhausted := errors.Is(readErr, ErrNoAnswer)
+		if answerErr != nil {
+			return -1
 		}
-		if err == nil && option > 0 && option <= len(validValues) {
+		if option, ok := choiceOption(answer, defaultOption, validValues); ok {
 			return option - 1
 		}
+		if exhausted {
+			reportMissingAnswer(question)
+			return -1
+		}
 		fmt.Printf("The value %s is not valid to this question.\nPlease retry to answer:\n", answer)
 	}
 }
+
+// choiceOption returns the option answer selects, starting from 1: defaultOption for an empty answer,
+// the option of that number, or the option spelled like answer ignoring case, e.g. yes.
+func choiceOption(answer string, defaultOption int, validValues []string) (int, bool) {
+	option := defaultOption
+	if answer != "" {
+		var err error
+		if option, err = strconv.Atoi(answer); err != nil {
+			option = 0
+			for i, value := range validValues {
+				if strings.EqualFold(answer, value) {
+					option = i + 1
+					break
+				}
+			}
+		}
+	}
+	return option, option > 0 && option <= len(validValues)
+}
 func EnterToExit() {
+	if answerSource != nil {
+		return
+	}
 	fmt.Println("Please press Enter to exit...")
 	stdin.Scanln()
 }
@@ -4,6 +4,8 @@
 package util
 
 import (
+	"encoding/json"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -166,3 +168,68 @@ func TestBackupConfigFile(t *testing.T) {
 	assert.Equal(t, 10, len(files))
 
 }
+
+func TestConfigFilePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigPathEnv, "")
+	t.Setenv(ConfigDirEnv, "")
+	assert.Equal(t, filepath.Join(CurPath(), configJsonFileName), ConfigFilePath())
+
+	t.Setenv(ConfigDirEnv, dir)
+	assert.Equal(t, filepath.Join(dir, configJsonFileName), ConfigFilePath())
+
+	t.Setenv(ConfigPathEnv, filepath.Join(dir, "agent.json"))
+	assert.Equal(t, filepath.Join(dir, "agent.json"), ConfigFilePath())
+
+	SetConfigFilePath(filepath.Join(dir, "set.json"))
+	defer SetConfigFilePath("")
+	assert.Equal(t, filepath.Join(dir, "set.json"), ConfigFilePath())
+
+	filePath := SaveResultByteArrayToJsonFile([]byte(expectResult), "")
+	assert.Equal(t, filepath.Join(dir, "set.json"), filePath)
+	assert.Equal(t, expectResult, ReadConfigFromJsonFile())
+}
+
+func TestCheckConfigFileWritable(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, checkConfigFileWritable(filepath.Join(dir, configJsonFileName)))
+
+	err := checkConfigFileWritable(filepath.Join(dir, "missing", configJsonFileName))
+	assert.ErrorContains(t, err, "does not exist")
+}
+
+func TestConfigFileHelpersE(t *testing.T) {
+	dir := t.TempDir()
+	SetConfigFilePath(filepath.Join(dir, "missing", configJsonFileName))
+	defer SetConfigFilePath("")
+
+	_, err := ReadConfigFromJsonFileE()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Equal(t, ErrCodeConfigRead, ErrorCode(err))
+	_, err = SaveResultByteArrayToJsonFileE([]byte(expectResult), "")
+	var pathErr *os.PathError
+	assert.ErrorAs(t, err, &pathErr)
+	assert.Equal(t, ErrCodeConfigWrite, ErrorCode(err))
+	assert.Equal(t, ConfigFilePath(), ErrorPath(err))
+	assert.Equal(t, ErrCodeConfigWrite, ErrorCode(PermissionCheckE()))
+
+	_, err = SerializeResultMapToJsonByteArrayE(map[string]interface{}{"interval": math.Inf(1)})
+	var unsupported *json.UnsupportedValueError
+	assert.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, ErrCodeConfigSerialize, ErrorCode(err))
+
+	SetConfigFilePath(filepath.Join(dir, configJsonFileName))
+	content, err := SerializeResultMapToJsonByteArrayE(map[string]interface{}{"agent": map[string]interface{}{"debug": true}})
+	assert.NoError(t, err)
+	filePath, err := SaveResultByteArrayToJsonFileE(content, "")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, configJsonFileName), filePath)
+	actual, err := ReadConfigFromJsonFileE()
+	assert.NoError(t, err)
+	assert.Equal(t, string(content), actual)
+
+	filePath, err = SaveResultByteArrayToJsonFileE(content, filepath.Join(dir, "output.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "output.json"), filePath)
+	assert.FileExists(t, filePath)
+}
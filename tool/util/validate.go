@@ -0,0 +1,181 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationIssue is a single problem found in a config. Path is the dotted json path of the offending
+// field, with list indexes in brackets, e.g. "logs.logs_collected.files.collect_list[0].file_path".
+type ValidationIssue struct {
+	Path     string
+	Message  string
+	Severity Severity
+}
+
+// ValidationReport collects every issue found by ValidateConfig instead of stopping on the first one.
+type ValidationReport struct {
+	Issues []ValidationIssue
+}
+
+func (r *ValidationReport) addError(path, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...), Severity: SeverityError})
+}
+
+func (r *ValidationReport) addWarning(path, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...), Severity: SeverityWarning})
+}
+
+func (r ValidationReport) Errors() []ValidationIssue {
+	return r.filter(SeverityError)
+}
+
+func (r ValidationReport) Warnings() []ValidationIssue {
+	return r.filter(SeverityWarning)
+}
+
+// Valid is true when the report has no errors. Warnings do not make a config invalid.
+func (r ValidationReport) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+func (r ValidationReport) filter(severity Severity) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// configValidators are run in order by ValidateConfig.
+var configValidators = []func(m map[string]interface{}, report *ValidationReport){
+	validateCollectionIntervals,
+	validateLogsDestinations,
+	validateMutualExclusion,
+	validatePluginInstanceCounts,
+	validateReservedNames,
+	validateTimeUnits,
+	validateTLSSettings,
+}
+
+// ValidateConfig runs every registered validator against the json config map.
+func ValidateConfig(m map[string]interface{}) ValidationReport {
+	var report ValidationReport
+	for _, validator := range configValidators {
+		validator(m, &report)
+	}
+	return report
+}
+
+// ReadConfigMap reads and parses the json config at filePath.
+func ReadConfigMap(filePath string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("error in parsing json config %s: %w", filePath, err)
+	}
+	return m, nil
+}
+
+// ValidateConfigDir validates every *.json file in dir and returns a report per file name. Other files
+// and sub directories are skipped. A file that is not valid json gets a report with a single error.
+// Files that cannot be read are left out of the result and their errors are returned joined together.
+func ValidateConfigDir(dir string) (map[string]ValidationReport, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	reports := make(map[string]ValidationReport)
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var m map[string]interface{}
+		if err = json.Unmarshal(content, &m); err != nil {
+			var report ValidationReport
+			report.addError("", "invalid json: %v", err)
+			reports[entry.Name()] = report
+			continue
+		}
+		reports[entry.Name()] = ValidateConfig(m)
+	}
+	return reports, errors.Join(errs...)
+}
+
+func validateCollectionIntervals(m map[string]interface{}, report *ValidationReport) {
+	walkConfig(m, "", func(path, key string, value interface{}) {
+		if key != MapKeyMetricsCollectionInterval {
+			return
+		}
+		interval, ok := toNumber(value)
+		if !ok || interval != float64(int(interval)) {
+			report.addError(path, "%s must be a whole number of seconds", key)
+			return
+		}
+		// the schema accepts any interval, ValidateResolution only flags the ones CloudWatch does not
+		// offer as a period to query the metrics at
+		if err := ValidateResolution(int(interval)); err != nil {
+			report.addWarning(path, "%v", err)
+		}
+	})
+}
+
+// walkConfig calls visit for every field below m in a deterministic order.
+func walkConfig(m map[string]interface{}, path string, visit func(path, key string, value interface{})) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		visit(childPath, key, m[key])
+		walkValue(m[key], childPath, visit)
+	}
+}
+
+// walkValue descends into the objects of value, whose lists are either []interface{} when parsed from a
+// file or []map[string]interface{} when built by the ToMap converters of the wizard.
+func walkValue(value interface{}, path string, visit func(path, key string, value interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		walkConfig(v, path, visit)
+	case []interface{}:
+		for i, item := range v {
+			walkValue(item, fmt.Sprintf("%s[%d]", path, i), visit)
+		}
+	case []map[string]interface{}:
+		for i, item := range v {
+			walkConfig(item, fmt.Sprintf("%s[%d]", path, i), visit)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "strings"
+
+// mutualExclusionRule names groups of keys of which only one may be set within the same object. The
+// objects are found at each of the dotted sections paths.
+type mutualExclusionRule struct {
+	sections []string
+	groups   [][]string
+	reason   string
+}
+
+// credentialsRule covers the credentials objects of the json config. A role_arn is assumed with the
+// credentials from common-config.toml, so a shared credentials file or profile next to it leaves it
+// unclear which credentials the agent should use.
+var credentialsRule = mutualExclusionRule{
+	sections: []string{"agent.credentials", "metrics.credentials", "logs.credentials", "traces.credentials"},
+	groups: [][]string{
+		{"role_arn"},
+		{"shared_credential_file", "shared_credential_profile", "profile"},
+	},
+	reason: "set the shared credentials in common-config.toml and keep only role_arn in the json config",
+}
+
+// mutualExclusionRules are checked by ValidateConfig.
+var mutualExclusionRules = []mutualExclusionRule{
+	credentialsRule,
+}
+
+// exclusionConflict is an object that sets keys from more than one group of a rule.
+type exclusionConflict struct {
+	section string
+	paths   []string
+	reason  string
+}
+
+func (rule mutualExclusionRule) conflicts(m map[string]interface{}) []exclusionConflict {
+	var conflicts []exclusionConflict
+	for _, section := range rule.sections {
+		object := getMap(m, strings.Split(section, ".")...)
+		if object == nil {
+			continue
+		}
+		var paths []string
+		groupsSet := 0
+		for _, group := range rule.groups {
+			set := false
+			for _, key := range group {
+				if _, ok := object[key]; ok {
+					paths = append(paths, section+"."+key)
+					set = true
+				}
+			}
+			if set {
+				groupsSet++
+			}
+		}
+		if groupsSet > 1 {
+			conflicts = append(conflicts, exclusionConflict{section: section, paths: paths, reason: rule.reason})
+		}
+	}
+	return conflicts
+}
+
+// ValidateCredentialSection returns the paths of the credential settings that conflict with each other:
+// a role_arn together with a shared credentials file or profile in the same credentials object.
+func ValidateCredentialSection(m map[string]interface{}) []string {
+	var paths []string
+	for _, conflict := range credentialsRule.conflicts(m) {
+		paths = append(paths, conflict.paths...)
+	}
+	return paths
+}
+
+func validateMutualExclusion(m map[string]interface{}, report *ValidationReport) {
+	for _, rule := range mutualExclusionRules {
+		for _, conflict := range rule.conflicts(m) {
+			report.addError(conflict.section, "%s cannot be set together, %s", strings.Join(conflict.paths, ", "), conflict.reason)
+		}
+	}
+}
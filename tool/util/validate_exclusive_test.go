@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCredentialSection(t *testing.T) {
+	testCases := map[string]struct {
+		config string
+		want   []string
+	}{
+		"RoleOnly": {
+			config: `{"agent":{"credentials":{"role_arn":"arn:aws:iam::123456789012:role/agent"}}}`,
+		},
+		"SharedOnly": {
+			config: `{"agent":{"credentials":{"shared_credential_profile":"AmazonCloudWatchAgent","shared_credential_file":"/root/.aws/credentials"}}}`,
+		},
+		"NoCredentials": {
+			config: `{"agent":{"debug":true}}`,
+		},
+		"RoleAndFile": {
+			config: `{"agent":{"credentials":{"role_arn":"arn:aws:iam::123456789012:role/agent","shared_credential_file":"/root/.aws/credentials"}}}`,
+			want:   []string{"agent.credentials.role_arn", "agent.credentials.shared_credential_file"},
+		},
+		"RoleAndProfile": {
+			config: `{"metrics":{"credentials":{"role_arn":"arn:aws:iam::123456789012:role/agent","shared_credential_profile":"default"}}}`,
+			want:   []string{"metrics.credentials.role_arn", "metrics.credentials.shared_credential_profile"},
+		},
+		"RoleAndTranslatedProfile": {
+			config: `{"logs":{"credentials":{"role_arn":"arn:aws:iam::123456789012:role/agent","profile":"default"}}}`,
+			want:   []string{"logs.credentials.role_arn", "logs.credentials.profile"},
+		},
+		"RoleAndFileAndProfile": {
+			config: `{"traces":{"credentials":{"role_arn":"arn:aws:iam::123456789012:role/agent","shared_credential_file":"/root/.aws/credentials","shared_credential_profile":"default"}}}`,
+			want:   []string{"traces.credentials.role_arn", "traces.credentials.shared_credential_file", "traces.credentials.shared_credential_profile"},
+		},
+		"SeparateSections": {
+			config: `{"agent":{"credentials":{"shared_credential_profile":"default"}},"metrics":{"credentials":{"role_arn":"arn:aws:iam::123456789012:role/agent"}}}`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var m map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.config), &m))
+			assert.Equal(t, testCase.want, ValidateCredentialSection(m))
+		})
+	}
+}
+
+func TestValidateConfigMutualExclusion(t *testing.T) {
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"credentials": map[string]interface{}{
+				"role_arn":                  "arn:aws:iam::123456789012:role/agent",
+				"shared_credential_profile": "default",
+			},
+		},
+	}
+	report := ValidateConfig(m)
+	errs := report.Errors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "agent.credentials", errs[0].Path)
+	assert.Contains(t, errs[0].Message, "agent.credentials.role_arn, agent.credentials.shared_credential_profile cannot be set together")
+}
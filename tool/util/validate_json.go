@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ValidationReportSchemaVersion is bumped whenever a field of the json report is changed or removed.
+// New fields may be added without a version change, so consumers should ignore fields they do not know.
+const ValidationReportSchemaVersion = 1
+
+// validationReportDocument is the json form of a ValidationReport:
+//
+//	{
+//	  "schema_version": 1,
+//	  "valid": false,
+//	  "error_count": 1,
+//	  "warning_count": 0,
+//	  "issues": [
+//	    {"path": "agent.metrics_collection_interval", "severity": "error", "message": "..."}
+//	  ]
+//	}
+//
+// issues is always a list, empty for a clean config, and keeps the order the validators reported the
+// issues in. severity is either "error" or "warning". path is the dotted json path of the field and is
+// empty for issues about the whole file.
+type validationReportDocument struct {
+	SchemaVersion int                    `json:"schema_version"`
+	Valid         bool                   `json:"valid"`
+	ErrorCount    int                    `json:"error_count"`
+	WarningCount  int                    `json:"warning_count"`
+	Issues        []validationIssueEntry `json:"issues"`
+}
+
+type validationIssueEntry struct {
+	Path     string   `json:"path"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// ValidationReportJSON serializes report for tools such as CI pipelines. The format is described on
+// validationReportDocument and versioned by ValidationReportSchemaVersion.
+func ValidationReportJSON(report ValidationReport) ([]byte, error) {
+	document := validationReportDocument{
+		SchemaVersion: ValidationReportSchemaVersion,
+		Valid:         report.Valid(),
+		ErrorCount:    len(report.Errors()),
+		WarningCount:  len(report.Warnings()),
+		Issues:        make([]validationIssueEntry, 0, len(report.Issues)),
+	}
+	for _, issue := range report.Issues {
+		document.Issues = append(document.Issues, validationIssueEntry{Path: issue.Path, Severity: issue.Severity, Message: issue.Message})
+	}
+	return json.MarshalIndent(document, "", "\t")
+}
+
+// ParseValidationReportJSON reads a report written by ValidationReportJSON.
+func ParseValidationReportJSON(b []byte) (ValidationReport, error) {
+	var document validationReportDocument
+	if err := json.Unmarshal(b, &document); err != nil {
+		return ValidationReport{}, err
+	}
+	if document.SchemaVersion != ValidationReportSchemaVersion {
+		return ValidationReport{}, fmt.Errorf("unsupported validation report schema version %d", document.SchemaVersion)
+	}
+	var report ValidationReport
+	for _, entry := range document.Issues {
+		if entry.Severity != SeverityError && entry.Severity != SeverityWarning {
+			return ValidationReport{}, fmt.Errorf("unknown severity %q for %s", entry.Severity, entry.Path)
+		}
+		report.Issues = append(report.Issues, ValidationIssue{Path: entry.Path, Message: entry.Message, Severity: entry.Severity})
+	}
+	return report, nil
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationReportJSON(t *testing.T) {
+	var report ValidationReport
+	report.addError("agent.metrics_collection_interval", "interval %ds must be a multiple of 60 seconds", 90)
+	report.addWarning("metrics.aggregation_dimensions", "duplicate dimension set")
+
+	b, err := ValidationReportJSON(report)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schema_version": 1,
+		"valid": false,
+		"error_count": 1,
+		"warning_count": 1,
+		"issues": [
+			{"path": "agent.metrics_collection_interval", "severity": "error", "message": "interval 90s must be a multiple of 60 seconds"},
+			{"path": "metrics.aggregation_dimensions", "severity": "warning", "message": "duplicate dimension set"}
+		]
+	}`, string(b))
+
+	parsed, err := ParseValidationReportJSON(b)
+	require.NoError(t, err)
+	assert.Equal(t, report, parsed)
+}
+
+func TestValidationReportJSONClean(t *testing.T) {
+	b, err := ValidationReportJSON(ValidationReport{})
+	require.NoError(t, err)
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &document))
+	assert.Equal(t, true, document["valid"])
+	assert.Equal(t, []interface{}{}, document["issues"])
+
+	parsed, err := ParseValidationReportJSON(b)
+	require.NoError(t, err)
+	assert.Equal(t, ValidationReport{}, parsed)
+}
+
+func TestParseValidationReportJSON(t *testing.T) {
+	_, err := ParseValidationReportJSON([]byte(`{"schema_version": 2, "issues": []}`))
+	assert.Error(t, err)
+	_, err = ParseValidationReportJSON([]byte(`{"schema_version": 1, "issues": [{"path": "agent", "severity": "fatal"}]}`))
+	assert.Error(t, err)
+	_, err = ParseValidationReportJSON([]byte(`{`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	collectListKey  = "collect_list"
+	logGroupNameKey = "log_group_name"
+	filePathKey     = "file_path"
+	eventNameKey    = "event_name"
+)
+
+// ValidateLogsDestinations returns the file paths (and event names for windows_events) of the collect_list
+// entries that would not resolve to a log group. A files entry without log_group_name inherits a group
+// derived from its file_path, unless publish_multi_logs is set. Windows event entries have no default.
+func ValidateLogsDestinations(m map[string]interface{}) []string {
+	var offending []string
+	for _, issue := range logsDestinationIssues(m) {
+		offending = append(offending, issue.source)
+	}
+	return offending
+}
+
+type logsDestinationIssue struct {
+	path   string
+	source string
+}
+
+func logsDestinationIssues(m map[string]interface{}) []logsDestinationIssue {
+	var issues []logsDestinationIssue
+	collected := getMap(m, "logs", "logs_collected")
+	if collected == nil {
+		return nil
+	}
+	if files := getMap(collected, "files"); files != nil {
+		for i, entry := range getMapList(files, collectListKey) {
+			if hasLogGroupName(entry) {
+				continue
+			}
+			publishMultiLogs, _ := entry["publish_multi_logs"].(bool)
+			filePath := getString(entry, filePathKey)
+			if strings.TrimSpace(filePath) != "" && !publishMultiLogs {
+				continue
+			}
+			issues = append(issues, logsDestinationIssue{
+				path:   fmt.Sprintf("logs.logs_collected.files.collect_list[%d].%s", i, logGroupNameKey),
+				source: filePath,
+			})
+		}
+	}
+	if events := getMap(collected, "windows_events"); events != nil {
+		for i, entry := range getMapList(events, collectListKey) {
+			if !hasLogGroupName(entry) {
+				issues = append(issues, logsDestinationIssue{
+					path:   fmt.Sprintf("logs.logs_collected.windows_events.collect_list[%d].%s", i, logGroupNameKey),
+					source: getString(entry, eventNameKey),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func hasLogGroupName(entry map[string]interface{}) bool {
+	return strings.TrimSpace(getString(entry, logGroupNameKey)) != ""
+}
+
+func validateLogsDestinations(m map[string]interface{}, report *ValidationReport) {
+	for _, issue := range logsDestinationIssues(m) {
+		report.addError(issue.path, "entry %q has no log group to publish to", issue.source)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLogsDestinations(t *testing.T) {
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"logs": {"logs_collected": {
+			"files": {"collect_list": [
+				{"file_path": "/var/log/messages", "log_group_name": "messages"},
+				{"file_path": "/var/log/secure"},
+				{"file_path": "/var/log/app/*.log", "publish_multi_logs": true},
+				{"file_path": "/var/log/empty.log", "log_group_name": " "},
+				{"log_group_name": "no-file"}
+			]},
+			"windows_events": {"collect_list": [
+				{"event_name": "System", "log_group_name": "System"},
+				{"event_name": "Security"}
+			]}
+		}}
+	}`), &m))
+
+	assert.Equal(t, []string{"/var/log/app/*.log", "Security"}, ValidateLogsDestinations(m))
+
+	report := ValidateConfig(m)
+	require.Len(t, report.Errors(), 2)
+	assert.Equal(t, "logs.logs_collected.files.collect_list[2].log_group_name", report.Errors()[0].Path)
+	assert.Equal(t, "logs.logs_collected.windows_events.collect_list[1].log_group_name", report.Errors()[1].Path)
+}
+
+func TestValidateLogsDestinationsWizardMap(t *testing.T) {
+	m := map[string]interface{}{
+		"logs": map[string]interface{}{"logs_collected": map[string]interface{}{
+			"files": map[string]interface{}{"collect_list": []map[string]interface{}{
+				{"file_path": "/var/log/messages", "log_group_name": "messages"},
+			}},
+		}},
+	}
+	assert.Empty(t, ValidateLogsDestinations(m))
+	assert.Empty(t, ValidateLogsDestinations(map[string]interface{}{}))
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import "fmt"
+
+const (
+	// minForceFlushInterval is the lower bound the schema puts on force_flush_interval, see
+	// maxForceFlushInterval for the upper one.
+	minForceFlushInterval = 1
+	// highResolutionRetention is how long CloudWatch keeps the datapoints of sub-minute metrics at
+	// their resolution, in seconds.
+	highResolutionRetention = 3 * 3600
+)
+
+// ValidateProtocolBufferSettings checks the buffer and flush settings of the metrics section against
+// the limits of protocol, one of the AskMetricsProtocol protocols, and returns a warning per violation
+// starting with the json path of the setting.
+//
+// For CloudWatch, force_flush_interval must be within the schema bounds and, with sub-minute metrics,
+// within the three hours CloudWatch keeps datapoints at their resolution. For OTLP, the flush settings
+// of the metrics section only drive the CloudWatch output, so they are reported as having no effect.
+func ValidateProtocolBufferSettings(m map[string]interface{}, protocol string) []string {
+	metrics := getMap(m, "metrics")
+	value, set := metrics["force_flush_interval"]
+	path := "metrics.force_flush_interval"
+	switch protocol {
+	case MetricsProtocolCloudWatch:
+		if !set {
+			return nil
+		}
+		interval, ok := toNumber(value)
+		if !ok || interval != float64(int(interval)) {
+			return []string{fmt.Sprintf("%s: %v must be a whole number of seconds", path, value)}
+		}
+		if interval < minForceFlushInterval || interval > maxForceFlushInterval {
+			return []string{fmt.Sprintf("%s: %s must be between %d and %d seconds", path, formatNumber(interval), minForceFlushInterval, maxForceFlushInterval)}
+		}
+		if interval > highResolutionRetention {
+			for _, summary := range SummarizeMetrics(m) {
+				if summary.Interval < 60 {
+					return []string{fmt.Sprintf("%s: %s seconds is longer than the %d hours CloudWatch keeps %s metrics collected every %ds at their resolution, "+
+						"so their sub-minute resolution is lost", path, formatNumber(interval), highResolutionRetention/3600, summary.Plugin, summary.Interval)}
+				}
+			}
+		}
+		return nil
+	case MetricsProtocolOTLP:
+		if set {
+			return []string{fmt.Sprintf("%s: the flush interval only applies to the CloudWatch output and has no effect on metrics exported over OTLP, "+
+				"configure the batching of the OTLP exporter instead", path)}
+		}
+		return nil
+	}
+	return []string{fmt.Sprintf("%q is not a metrics protocol, use %s or %s", protocol, MetricsProtocolCloudWatch, MetricsProtocolOTLP)}
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProtocolBufferSettingsCloudWatch(t *testing.T) {
+	config := func(flushInterval interface{}, collectionInterval int) map[string]interface{} {
+		metrics := map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{
+					"measurement":                 []interface{}{"usage_idle"},
+					"metrics_collection_interval": collectionInterval,
+				},
+			},
+		}
+		if flushInterval != nil {
+			metrics["force_flush_interval"] = flushInterval
+		}
+		return map[string]interface{}{"metrics": metrics}
+	}
+	assert.Empty(t, ValidateProtocolBufferSettings(config(nil, 1), MetricsProtocolCloudWatch))
+	assert.Empty(t, ValidateProtocolBufferSettings(config(60, 1), MetricsProtocolCloudWatch))
+	assert.Empty(t, ValidateProtocolBufferSettings(config(float64(21600), 60), MetricsProtocolCloudWatch))
+
+	warnings := ValidateProtocolBufferSettings(config(0, 60), MetricsProtocolCloudWatch)
+	assert.Equal(t, []string{"metrics.force_flush_interval: 0 must be between 1 and 172800 seconds"}, warnings)
+	warnings = ValidateProtocolBufferSettings(config(200000, 60), MetricsProtocolCloudWatch)
+	assert.Equal(t, []string{"metrics.force_flush_interval: 200000 must be between 1 and 172800 seconds"}, warnings)
+	warnings = ValidateProtocolBufferSettings(config("60s", 60), MetricsProtocolCloudWatch)
+	assert.Equal(t, []string{"metrics.force_flush_interval: 60s must be a whole number of seconds"}, warnings)
+
+	warnings = ValidateProtocolBufferSettings(config(float64(21600), 10), MetricsProtocolCloudWatch)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "metrics.force_flush_interval: 21600 seconds is longer than the 3 hours CloudWatch keeps cpu metrics collected every 10s")
+}
+
+func TestValidateProtocolBufferSettingsOTLP(t *testing.T) {
+	assert.Empty(t, ValidateProtocolBufferSettings(map[string]interface{}{}, MetricsProtocolOTLP))
+
+	warnings := ValidateProtocolBufferSettings(map[string]interface{}{
+		"metrics": map[string]interface{}{"force_flush_interval": 30},
+	}, MetricsProtocolOTLP)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "metrics.force_flush_interval: the flush interval only applies to the CloudWatch output")
+
+	assert.Equal(t, []string{`"grpc" is not a metrics protocol, use cloudwatch or otlp`}, ValidateProtocolBufferSettings(nil, "grpc"))
+}
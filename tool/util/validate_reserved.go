@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// reservedNamespacePrefix is used by the metrics of AWS services, and PutMetricData rejects it.
+	reservedNamespacePrefix = "AWS/"
+	// reservedDimensionPrefix is kept for dimensions set by AWS.
+	reservedDimensionPrefix = "AWS:"
+)
+
+var namespaceKeys = map[string]bool{
+	"namespace":        true,
+	"metric_namespace": true,
+}
+
+type reservedNameIssue struct {
+	path    string
+	message string
+}
+
+// ValidateReservedNames returns a message for every namespace and dimension name in m that uses a name
+// reserved by CloudWatch: namespaces starting with "AWS/", and dimension names starting with "AWS:" or
+// with a colon. Dimension names are taken from append_dimensions, aggregation_dimensions and the
+// dimensions of the EMF metric declarations. Each message starts with the json path of the name.
+func ValidateReservedNames(m map[string]interface{}) []string {
+	var messages []string
+	for _, issue := range reservedNameIssues(m) {
+		messages = append(messages, issue.path+": "+issue.message)
+	}
+	return messages
+}
+
+func reservedNameIssues(m map[string]interface{}) []reservedNameIssue {
+	var issues []reservedNameIssue
+	checkDimension := func(path, name string) {
+		if message := reservedDimensionMessage(name); message != "" {
+			issues = append(issues, reservedNameIssue{path: path, message: message})
+		}
+	}
+	walkConfig(m, "", func(path, key string, value interface{}) {
+		switch {
+		case namespaceKeys[key]:
+			if namespace, ok := value.(string); ok {
+				if message := reservedNamespaceMessage(namespace); message != "" {
+					issues = append(issues, reservedNameIssue{path: path, message: message})
+				}
+			}
+		case key == "append_dimensions":
+			switch dimensions := value.(type) {
+			case map[string]interface{}:
+				for _, name := range sortedKeys(dimensions) {
+					checkDimension(path+"."+name, name)
+				}
+			case map[string]string:
+				names := make([]string, 0, len(dimensions))
+				for name := range dimensions {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					checkDimension(path+"."+name, name)
+				}
+			}
+		case key == "aggregation_dimensions" || key == "dimensions":
+			for i, set := range dimensionSets(value) {
+				for j, name := range set {
+					checkDimension(fmt.Sprintf("%s[%d][%d]", path, i, j), name)
+				}
+			}
+		}
+	})
+	return issues
+}
+
+func reservedNamespaceMessage(namespace string) string {
+	if strings.HasPrefix(namespace, reservedNamespacePrefix) {
+		return fmt.Sprintf("namespace %q starts with %q, which is reserved for AWS services. Use a custom namespace such as CWAgent", namespace, reservedNamespacePrefix)
+	}
+	return ""
+}
+
+func reservedDimensionMessage(name string) string {
+	switch {
+	case strings.HasPrefix(name, reservedDimensionPrefix):
+		return fmt.Sprintf("dimension name %q starts with %q, which is reserved for dimensions set by AWS. Rename the dimension", name, reservedDimensionPrefix)
+	case strings.HasPrefix(name, ":"):
+		return fmt.Sprintf("dimension name %q starts with a colon, which CloudWatch does not allow. Rename the dimension", name)
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validateReservedNames(m map[string]interface{}, report *ValidationReport) {
+	for _, issue := range reservedNameIssues(m) {
+		report.addError(issue.path, "%s", issue.message)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReservedNames(t *testing.T) {
+	var allowed map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"metrics": {
+			"namespace": "CWAgent/AWSApps",
+			"append_dimensions": {"InstanceId": "${aws:InstanceId}"},
+			"aggregation_dimensions": [["InstanceId"], ["AutoScalingGroupName"]],
+			"metrics_collected": {"cpu": {"append_dimensions": {"Team": "AWSPlatform"}}}
+		},
+		"logs": {"metrics_collected": {"prometheus": {"emf_processor": {
+			"metric_namespace": "Prometheus",
+			"metric_declaration": [{"dimensions": [["Service", "Namespace"]]}]
+		}}}}
+	}`), &allowed))
+	assert.Empty(t, ValidateReservedNames(allowed))
+
+	var reserved map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"metrics": {
+			"namespace": "AWS/EC2",
+			"append_dimensions": {"AWS:Team": "platform", "InstanceId": "${aws:InstanceId}"},
+			"aggregation_dimensions": [["InstanceId", ":scope"]],
+			"metrics_collected": {"cpu": {"append_dimensions": {":env": "prod"}}}
+		},
+		"logs": {"metrics_collected": {"prometheus": {"emf_processor": {
+			"metric_namespace": "AWS/Prometheus",
+			"metric_declaration": [{"dimensions": [["AWS:Service"]]}]
+		}}}}
+	}`), &reserved))
+	messages := ValidateReservedNames(reserved)
+	require.Len(t, messages, 6)
+	assert.Equal(t, []string{
+		`logs.metrics_collected.prometheus.emf_processor.metric_declaration[0].dimensions[0][0]: dimension name "AWS:Service" starts with "AWS:", which is reserved for dimensions set by AWS. Rename the dimension`,
+		`logs.metrics_collected.prometheus.emf_processor.metric_namespace: namespace "AWS/Prometheus" starts with "AWS/", which is reserved for AWS services. Use a custom namespace such as CWAgent`,
+		`metrics.aggregation_dimensions[0][1]: dimension name ":scope" starts with a colon, which CloudWatch does not allow. Rename the dimension`,
+		`metrics.append_dimensions.AWS:Team: dimension name "AWS:Team" starts with "AWS:", which is reserved for dimensions set by AWS. Rename the dimension`,
+		`metrics.metrics_collected.cpu.append_dimensions.:env: dimension name ":env" starts with a colon, which CloudWatch does not allow. Rename the dimension`,
+		`metrics.namespace: namespace "AWS/EC2" starts with "AWS/", which is reserved for AWS services. Use a custom namespace such as CWAgent`,
+	}, messages)
+
+	report := ValidateConfig(reserved)
+	assert.Len(t, report.Errors(), 6)
+}
+
+func TestValidateReservedNamesTyped(t *testing.T) {
+	m := map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"append_dimensions":      map[string]string{"AWS:Team": "platform"},
+			"aggregation_dimensions": [][]string{{"InstanceId"}},
+		},
+	}
+	assert.Len(t, ValidateReservedNames(m), 1)
+}
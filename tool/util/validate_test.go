@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"metrics_collection_interval": 60,
+		},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{
+					"metrics_collection_interval": float64(45),
+				},
+				"mem": map[string]interface{}{
+					"metrics_collection_interval": "10s",
+				},
+			},
+		},
+	}
+	report := ValidateConfig(m)
+	assert.False(t, report.Valid())
+	errs := report.Errors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "metrics.metrics_collected.mem.metrics_collection_interval", errs[0].Path)
+	warnings := report.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "metrics.metrics_collected.cpu.metrics_collection_interval", warnings[0].Path)
+}
+
+func TestReadConfigMap(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"agent":{"debug":true}}`), 0644))
+
+	m, err := ReadConfigMap(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"agent": map[string]interface{}{"debug": true}}, m)
+
+	_, err = ReadConfigMap(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestValidateConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "good.json"), []byte(`{"agent":{"metrics_collection_interval":60}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`{"agent":{"metrics_collection_interval":7.5}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"agent":`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(`not a config`), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested.json"), 0755))
+
+	reports, err := ValidateConfigDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, reports, 3)
+	assert.True(t, reports["good.json"].Valid())
+	assert.False(t, reports["bad.json"].Valid())
+	assert.False(t, reports["broken.json"].Valid())
+
+	_, err = ValidateConfigDir(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
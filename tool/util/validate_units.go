@@ -0,0 +1,110 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	unitSeconds = "seconds"
+	unitDays    = "days"
+
+	secondsPerDay = 86400
+	// maxRetentionInDays is the longest retention_in_days CloudWatch Logs accepts, ten years.
+	maxRetentionInDays = 3653
+)
+
+// unitConversion is a unit a time field is commonly entered in by mistake, with the factor from the
+// unit of the field.
+type unitConversion struct {
+	unit   string
+	factor float64
+}
+
+// timeUnitField is a time field of the json config, in unit, with the largest value that is still a
+// plausible setting rather than a value entered in one of the mistaken units.
+type timeUnitField struct {
+	unit     string
+	max      float64
+	mistaken []unitConversion
+}
+
+// timeUnitFields are the numeric time fields of the json config by key.
+var timeUnitFields = map[string]timeUnitField{
+	MapKeyMetricsCollectionInterval: secondsField(3600),
+	"force_flush_interval":          secondsField(3600),
+	"metrics_aggregation_interval":  secondsField(3600),
+	"retention_in_days": {unit: unitDays, max: maxRetentionInDays, mistaken: []unitConversion{
+		{unitSeconds, secondsPerDay},
+		{"hours", 24},
+	}},
+}
+
+// secondsField is a field in seconds, commonly mistaken for milliseconds.
+func secondsField(limit float64) timeUnitField {
+	return timeUnitField{unit: unitSeconds, max: limit, mistaken: []unitConversion{{"milliseconds", 1000}}}
+}
+
+// durationKeys are the time fields that take a duration string with a unit, e.g. "1m".
+var durationKeys = map[string]bool{
+	"sd_frequency": true,
+}
+
+// ValidateTimeUnits returns an advisory warning for every time field of m whose value is implausibly
+// large for the unit of the field, which usually means it was entered in another unit, e.g. 60000
+// milliseconds where metrics_collection_interval expects 60 seconds. Duration strings without a unit
+// are flagged as well. Each warning starts with the json path of the field.
+func ValidateTimeUnits(m map[string]interface{}) []string {
+	var report ValidationReport
+	validateTimeUnits(m, &report)
+	warnings := make([]string, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		warnings = append(warnings, issue.Path+": "+issue.Message)
+	}
+	return warnings
+}
+
+func validateTimeUnits(m map[string]interface{}, report *ValidationReport) {
+	walkConfig(m, "", func(path, key string, value interface{}) {
+		if durationKeys[key] {
+			if s, ok := value.(string); ok {
+				if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+					report.addWarning(path, "%s %q has no unit, use a duration such as %ss", key, s, strings.TrimSpace(s))
+				}
+			}
+			return
+		}
+		field, ok := timeUnitFields[key]
+		if !ok {
+			return
+		}
+		n, ok := toNumber(value)
+		if !ok || n <= field.max {
+			return
+		}
+		if suggestion, from := field.suggest(n); from != "" {
+			report.addWarning(path, "%s %s is implausibly large for a value in %s, did you mean %s (%s converted from %s)?", key, formatNumber(n), field.unit, formatNumber(suggestion), formatNumber(n), from)
+			return
+		}
+		report.addWarning(path, "%s %s is implausibly large for a value in %s, the usual maximum is %s", key, formatNumber(n), field.unit, formatNumber(field.max))
+	})
+}
+
+// suggest converts n from the first mistaken unit that gives a plausible whole value, and returns the
+// converted value with the name of that unit. The unit is empty when no conversion is plausible.
+func (f timeUnitField) suggest(n float64) (float64, string) {
+	for _, conversion := range f.mistaken {
+		converted := n / conversion.factor
+		if converted == float64(int(converted)) && converted >= 1 && converted <= f.max {
+			return converted, conversion.unit
+		}
+	}
+	return 0, ""
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -0,0 +1,67 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTimeUnits(t *testing.T) {
+	m := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"metrics_collection_interval": float64(60000),
+		},
+		"metrics": map[string]interface{}{
+			"force_flush_interval": 60,
+			"metrics_collected": map[string]interface{}{
+				"statsd": map[string]interface{}{
+					"metrics_aggregation_interval": 7200,
+				},
+			},
+		},
+		"logs": map[string]interface{}{
+			"force_flush_interval": 5000,
+			"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{
+					"collect_list": []interface{}{
+						map[string]interface{}{"retention_in_days": 2592000},
+						map[string]interface{}{"retention_in_days": 8760},
+						map[string]interface{}{"retention_in_days": 4000},
+						map[string]interface{}{"retention_in_days": 30},
+					},
+				},
+			},
+		},
+		"ecs": map[string]interface{}{
+			"sd_frequency": "60",
+		},
+	}
+	assert.Equal(t, []string{
+		"agent.metrics_collection_interval: metrics_collection_interval 60000 is implausibly large for a value in seconds, did you mean 60 (60000 converted from milliseconds)?",
+		`ecs.sd_frequency: sd_frequency "60" has no unit, use a duration such as 60s`,
+		"logs.force_flush_interval: force_flush_interval 5000 is implausibly large for a value in seconds, did you mean 5 (5000 converted from milliseconds)?",
+		"logs.logs_collected.files.collect_list[0].retention_in_days: retention_in_days 2592000 is implausibly large for a value in days, did you mean 30 (2592000 converted from seconds)?",
+		"logs.logs_collected.files.collect_list[1].retention_in_days: retention_in_days 8760 is implausibly large for a value in days, did you mean 365 (8760 converted from hours)?",
+		"logs.logs_collected.files.collect_list[2].retention_in_days: retention_in_days 4000 is implausibly large for a value in days, the usual maximum is 3653",
+		"metrics.metrics_collected.statsd.metrics_aggregation_interval: metrics_aggregation_interval 7200 is implausibly large for a value in seconds, the usual maximum is 3600",
+	}, ValidateTimeUnits(m))
+
+	assert.Empty(t, ValidateTimeUnits(map[string]interface{}{
+		"agent": map[string]interface{}{"metrics_collection_interval": 60},
+		"ecs":   map[string]interface{}{"sd_frequency": "1m"},
+	}))
+}
+
+func TestValidateConfigTimeUnits(t *testing.T) {
+	report := ValidateConfig(map[string]interface{}{
+		"logs": map[string]interface{}{"force_flush_interval": 5000},
+	})
+	assert.True(t, report.Valid())
+	warnings := report.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "logs.force_flush_interval", warnings[0].Path)
+}
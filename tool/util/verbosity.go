@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Verbosity int
+
+const (
+	// VerbosityQuiet only prints the questions themselves.
+	VerbosityQuiet Verbosity = iota
+	// VerbosityNormal also prints the short descriptions and hints.
+	VerbosityNormal
+	// VerbosityVerbose also prints the extended guidance.
+	VerbosityVerbose
+)
+
+var verbosityNames = []string{"quiet", "normal", "verbose"}
+
+var verbosity = VerbosityNormal
+
+func (v Verbosity) String() string {
+	if v < VerbosityQuiet || v > VerbosityVerbose {
+		return fmt.Sprintf("Verbosity(%d)", int(v))
+	}
+	return verbosityNames[v]
+}
+
+// ParseVerbosity converts "quiet", "normal" or "verbose" into a Verbosity.
+func ParseVerbosity(name string) (Verbosity, error) {
+	for i, verbosityName := range verbosityNames {
+		if strings.EqualFold(name, verbosityName) {
+			return Verbosity(i), nil
+		}
+	}
+	return VerbosityNormal, fmt.Errorf("unknown verbosity %q, must be one of %v", name, verbosityNames)
+}
+
+// SetVerbosity controls how much explanatory text the wizard prints around its questions.
+func SetVerbosity(v Verbosity) {
+	verbosity = v
+}
+
+func CurVerbosity() Verbosity {
+	return verbosity
+}
+
+// AskVerbosity lets the user pick the wizard verbosity and applies it.
+func AskVerbosity() Verbosity {
+	answer := Choice("How much explanation do you want the wizard to print?", int(verbosity)+1, verbosityNames)
+	v, _ := ParseVerbosity(answer)
+	SetVerbosity(v)
+	return v
+}
+
+// PrintHint prints a short description or hint. It is suppressed in quiet mode.
+func PrintHint(format string, args ...interface{}) {
+	if verbosity >= VerbosityNormal {
+		fmt.Printf(format+"\n", args...)
+	}
+}
+
+// PrintDetail prints extended guidance. It is only shown in verbose mode.
+func PrintDetail(format string, args ...interface{}) {
+	if verbosity >= VerbosityVerbose {
+		fmt.Printf(format+"\n", args...)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	for _, v := range []Verbosity{VerbosityQuiet, VerbosityNormal, VerbosityVerbose} {
+		parsed, err := ParseVerbosity(v.String())
+		assert.NoError(t, err)
+		assert.Equal(t, v, parsed)
+	}
+	parsed, err := ParseVerbosity("VERBOSE")
+	assert.NoError(t, err)
+	assert.Equal(t, VerbosityVerbose, parsed)
+
+	_, err = ParseVerbosity("loud")
+	assert.Error(t, err)
+}
+
+func TestAskVerbosity(t *testing.T) {
+	defer SetVerbosity(CurVerbosity())
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	assert.Equal(t, VerbosityNormal, AskVerbosity())
+
+	testutil.Type(inputChan, "1")
+	assert.Equal(t, VerbosityQuiet, AskVerbosity())
+	assert.Equal(t, VerbosityQuiet, CurVerbosity())
+}
@@ -0,0 +1,167 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const maxWindowsEventID = 65535
+
+// WindowsEventChannels returns the event log channels registered on the host, sorted, or an error when
+// they cannot be listed, e.g. on an OS other than windows.
+func WindowsEventChannels() ([]string, error) {
+	channels, err := windowsEventChannels()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(channels)
+	return channels, nil
+}
+
+// AskWindowsEventChannel prompts for the event log channel to monitor. When channels, the channels of the
+// host, are given, the name must be one of them, and ?text lists the channels whose name contains text.
+func AskWindowsEventChannel(defaultName string, channels []string) (string, error) {
+	question := "Windows event log name:"
+	if len(channels) == 0 {
+		return askValidated(question, defaultName, validateEventChannel)
+	}
+	PrintHint("Enter ?<text> to list the event log channels of this host whose name contains <text>, e.g. ?PowerShell.")
+	for {
+		answer, err := askValidated(question, defaultName, func(answer string) error {
+			if strings.HasPrefix(answer, "?") {
+				return nil
+			}
+			if err := validateEventChannel(answer); err != nil {
+				return err
+			}
+			if findEventChannel(channels, answer) == "" {
+				return fmt.Errorf("%s is not an event log channel of this host, enter ?%s to list the channels matching it", answer, answer)
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if text, ok := strings.CutPrefix(answer, "?"); ok {
+			printEventChannels(channels, text)
+			continue
+		}
+		return findEventChannel(channels, answer), nil
+	}
+}
+
+// AskWindowsEventIDs prompts for the event IDs of the events to monitor. No event ID monitors them all.
+func AskWindowsEventIDs(eventName string) ([]int, error) {
+	answer, err := askValidated(fmt.Sprintf("Which event IDs (separated by commas) of %s do you want to monitor? Leave it empty to monitor every event ID.", eventName), "", func(answer string) error {
+		_, err := parseEventIDs(answer)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseEventIDs(answer)
+}
+
+// AskWindowsEventXPathQuery prompts for the XPath query selecting the events of eventName to monitor,
+// which replaces the filter on the levels and the event IDs.
+func AskWindowsEventXPathQuery(eventName string) (string, error) {
+	PrintHint("The query is the XPath of the filter of an Event Viewer custom view, e.g. *[System[(Level=1 or Level=2) and (EventID=4625)]].")
+	return askValidated(fmt.Sprintf("Which XPath query selects the events of %s to monitor?", eventName), "", ValidateXPathQuery)
+}
+
+// ValidateXPathQuery returns an error when query is not an XPath query the agent can subscribe with: the
+// query must be a single XPath expression, not the QueryList XML wrapping it, with balanced brackets,
+// parentheses and quotes.
+func ValidateXPathQuery(query string) error {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return errors.New("the XPath query must not be empty")
+	}
+	if strings.HasPrefix(query, "<") {
+		return errors.New("enter the XPath expression of the Select element rather than the QueryList XML")
+	}
+	var open []rune
+	var quote rune
+	for _, c := range query {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '(':
+			open = append(open, c)
+		case c == ']' || c == ')':
+			expected := '['
+			if c == ')' {
+				expected = '('
+			}
+			if len(open) == 0 || open[len(open)-1] != expected {
+				return fmt.Errorf("the %c is not opened", c)
+			}
+			open = open[:len(open)-1]
+		}
+	}
+	if quote != 0 {
+		return fmt.Errorf("the quote %c is not closed", quote)
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("the %c is not closed", open[len(open)-1])
+	}
+	return nil
+}
+
+func validateEventChannel(answer string) error {
+	if strings.TrimSpace(answer) == "" {
+		return errors.New("the event log name must not be empty")
+	}
+	return nil
+}
+
+// findEventChannel returns the channel named name, whose case windows ignores, or "".
+func findEventChannel(channels []string, name string) string {
+	for _, channel := range channels {
+		if strings.EqualFold(channel, name) {
+			return channel
+		}
+	}
+	return ""
+}
+
+func printEventChannels(channels []string, text string) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	found := false
+	for _, channel := range channels {
+		if strings.Contains(strings.ToLower(channel), text) {
+			fmt.Println(channel)
+			found = true
+		}
+	}
+	if !found {
+		fmt.Printf("No event log channel of this host contains %s.\n", text)
+	}
+}
+
+// parseEventIDs parses the comma separated event IDs of an answer, each between 0 and 65535.
+func parseEventIDs(answer string) ([]int, error) {
+	var eventIDs []int
+	seen := make(map[int]bool)
+	for _, item := range splitList(answer) {
+		eventID, err := strconv.Atoi(item)
+		if err != nil || eventID < 0 || eventID > maxWindowsEventID {
+			return nil, fmt.Errorf("%q is not an event ID between 0 and %d", item, maxWindowsEventID)
+		}
+		if !seen[eventID] {
+			seen[eventID] = true
+			eventIDs = append(eventIDs, eventID)
+		}
+	}
+	return eventIDs, nil
+}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestAskWindowsEventChannel(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	name, err := AskWindowsEventChannel("System", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "System", name)
+
+	testutil.Type(inputChan, "Custom-Channel")
+	name, err = AskWindowsEventChannel("System", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Custom-Channel", name)
+
+	channels := []string{"Application", "Microsoft-Windows-PowerShell/Operational", "Security", "System"}
+	testutil.Type(inputChan, "?powershell", "PowerShell", "microsoft-windows-powershell/operational")
+	name, err = AskWindowsEventChannel("System", channels)
+	assert.NoError(t, err)
+	assert.Equal(t, "Microsoft-Windows-PowerShell/Operational", name)
+}
+
+func TestAskWindowsEventIDs(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "")
+	eventIDs, err := AskWindowsEventIDs("Security")
+	assert.NoError(t, err)
+	assert.Nil(t, eventIDs)
+
+	testutil.Type(inputChan, "4624,70000", "4624, 4625,4624")
+	eventIDs, err = AskWindowsEventIDs("Security")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4624, 4625}, eventIDs)
+}
+
+func TestAskWindowsEventXPathQuery(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+
+	testutil.Type(inputChan, "", "*[System[(EventID=4624]]", "*[System[(Level=1 or Level=2) and (EventID=4625)]]")
+	query, err := AskWindowsEventXPathQuery("Security")
+	assert.NoError(t, err)
+	assert.Equal(t, "*[System[(Level=1 or Level=2) and (EventID=4625)]]", query)
+}
+
+func TestValidateXPathQuery(t *testing.T) {
+	for _, query := range []string{
+		"*",
+		"*[System[EventID=7036]]",
+		"*[System[Provider[@Name='Service Control Manager'] and (EventID=7036)]]",
+		`*[EventData[Data[@Name="TargetUserName"]="a]b"]]`,
+	} {
+		assert.NoError(t, ValidateXPathQuery(query), query)
+	}
+	for _, query := range []string{
+		"",
+		`<QueryList><Query Id="0"><Select Path="System">*</Select></Query></QueryList>`,
+		"*[System[EventID=7036]",
+		"*[System[EventID=7036)]]",
+		"*[System[Provider[@Name='Service Control Manager]]]",
+	} {
+		assert.Error(t, ValidateXPathQuery(query), query)
+	}
+}
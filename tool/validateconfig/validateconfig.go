@@ -0,0 +1,292 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package validateconfig checks a json config the way the agent would load it, without starting the
+// agent, and reports every problem found at once.
+package validateconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline"
+	translatorUtil "github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const (
+	// offlineRegion stands in for the region of the host when the environment is not checked, so that the
+	// translation does not report it missing.
+	offlineRegion = "us-east-1"
+	probeTimeout  = 5 * time.Second
+)
+
+// Options select how a config is validated.
+type Options struct {
+	// OS is the OS the config is translated for, e.g. linux or windows.
+	OS string
+	// Mode is the mode the agent runs in, e.g. ec2 or onPremise.
+	Mode string
+	// Offline skips resolving the region and the credentials of the host, and the instance metadata
+	// lookups of the translation.
+	Offline bool
+	// ProbeEndpoints sends a request to the CloudWatch and CloudWatch Logs endpoints the config uses.
+	ProbeEndpoints bool
+}
+
+var (
+	sdkRegion      = util.SDKRegion
+	ec2Region      = util.DefaultEC2Region
+	hasCredentials = func() bool {
+		_, _, creds := util.SDKCredentials()
+		return creds != nil
+	}
+	probeEndpoint = probeHTTPS
+)
+
+// translatorMessageRegex matches the error messages of the translator, which locate the error with a
+// slash separated path.
+var translatorMessageRegex = regexp.MustCompile(`^(?:Under path : |The path of the error is : )(.*?) \| Errors? : (.*)$`)
+
+// Validate validates the config at filePath like ValidateContent. The error is the error of reading it.
+func Validate(filePath string, options Options) (util.ValidationReport, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return util.ValidationReport{}, err
+	}
+	return ValidateContent(content, options), nil
+}
+
+// ValidateContent runs the checks the agent runs when it loads a config: the json schema and the
+// translation to the TOML and YAML configs of the agent, as well as the checks of the wizard. Unless
+// options.Offline is set it resolves the region and the credentials of the host the way the wizard
+// does, and with options.ProbeEndpoints it makes sure the endpoints the config sends to can be
+// reached. The issues of every check are reported together, each located by its json path.
+func ValidateContent(content []byte, options Options) util.ValidationReport {
+	var report util.ValidationReport
+	var m map[string]interface{}
+	if err := json.Unmarshal(content, &m); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line := strings.Count(string(content[:syntaxErr.Offset]), "\n") + 1
+			addIssue(&report, util.SeverityError, "", "invalid json at line %d: %v", line, err)
+		} else {
+			addIssue(&report, util.SeverityError, "", "invalid json: %v", err)
+		}
+		return report
+	}
+
+	schemaValid := validateSchema(m, &report)
+	report.Issues = append(report.Issues, util.ValidateConfig(m).Issues...)
+	region := offlineRegion
+	if !options.Offline {
+		region = validateEnvironment(m, options, &report)
+	}
+	// the translator expects a config matching the schema, the agent never translates any other
+	if schemaValid {
+		translate(m, region, options, &report)
+	}
+	if options.ProbeEndpoints && region != "" {
+		probeEndpoints(m, region, &report)
+	}
+	return report
+}
+
+func validateSchema(m map[string]interface{}, report *util.ValidationReport) bool {
+	result, err := cmdutil.RunSchemaValidation(m)
+	if err != nil {
+		addIssue(report, util.SeverityError, "", "the schema validation failed: %v", err)
+		return false
+	}
+	for _, detail := range result.Errors() {
+		addIssue(report, util.SeverityError, schemaPath(detail.Context().String()), "%s", detail.Description())
+	}
+	return result.Valid()
+}
+
+// validateEnvironment resolves the region the agent would send to, from agent.region, the AWS profile
+// or on EC2 the instance metadata, and checks credentials can be found. It returns the region, or ""
+// when none is found.
+func validateEnvironment(m map[string]interface{}, options Options, report *util.ValidationReport) string {
+	region := util.ConfigString(util.ConfigMap(m, "agent"), "region")
+	if region == "" {
+		region = sdkRegion()
+	}
+	if region == "" && !onPremise(options.Mode) {
+		region, _ = ec2Region()
+	}
+	if region == "" {
+		addIssue(report, util.SeverityError, "agent.region", "no region is set: it is read from agent.region, the AWS profile, and on EC2 the instance metadata")
+	} else if !util.IsValidRegion(region) {
+		addIssue(report, util.SeverityWarning, "agent.region", "the region %s is not a known AWS region", region)
+	}
+	if !hasCredentials() {
+		addIssue(report, util.SeverityError, "agent.credentials", "no AWS credentials are found for the agent to send with")
+	}
+	return region
+}
+
+func onPremise(mode string) bool {
+	return mode == config.ModeOnPrem || mode == config.ModeOnPremise
+}
+
+// translate runs the translation of the agent on m merged with the default config, as the agent does,
+// and reports the errors of the translator.
+func translate(m map[string]interface{}, region string, options Options, report *util.ValidationReport) {
+	context.ResetContext()
+	ctx := context.CurrentContext()
+	ctx.SetOs(options.OS)
+	ctx.SetMode(options.Mode)
+	ctx.SetOffline(options.Offline)
+	translator.ResetMessages()
+	agent.Global_Config = *new(agent.Agent)
+	detectRegion := translatorUtil.DetectRegion
+	translatorUtil.DetectRegion = func(string, map[string]string) (string, string) {
+		if region == "" {
+			// already reported with the environment
+			return offlineRegion, config.RegionTypeNotFound
+		}
+		return region, config.RegionTypeCredsMap
+	}
+	// the translator logs its progress, which would bury the report
+	log.SetOutput(io.Discard)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		translatorUtil.DetectRegion = detectRegion
+		if r := recover(); r != nil {
+			addIssue(report, util.SeverityError, "", "the translation stopped: %v", r)
+		}
+		for _, message := range translator.ErrorMessages {
+			addTranslatorMessage(report, message)
+		}
+	}()
+
+	defaultConfig, err := translatorUtil.GetDefaultJsonConfigMap(options.OS, options.Mode)
+	if err != nil {
+		addIssue(report, util.SeverityError, "", "the default config cannot be loaded: %v", err)
+		return
+	}
+	merged, err := jsonconfig.MergeJsonConfigMaps(map[string]map[string]interface{}{"config": m}, defaultConfig, "default")
+	if err != nil {
+		addIssue(report, util.SeverityError, "", "the config cannot be merged with the default config: %v", err)
+		return
+	}
+	if _, err = cmdutil.TranslateJsonMapToTomlConfig(merged); err != nil {
+		// the errors are the messages of the translator
+		return
+	}
+	if _, err = cmdutil.TranslateJsonMapToYamlConfig(merged); err != nil && !errors.Is(err, pipeline.ErrNoPipelines) {
+		addIssue(report, util.SeverityError, "", "the translation to the YAML config failed: %v", err)
+	}
+}
+
+func addTranslatorMessage(report *util.ValidationReport, message string) {
+	match := translatorMessageRegex.FindStringSubmatch(message)
+	if match == nil {
+		addIssue(report, util.SeverityError, "", "%s", message)
+		return
+	}
+	addIssue(report, util.SeverityError, slashPath(match[1]), "%s", match[2])
+}
+
+// probeEndpoints sends a request to the CloudWatch endpoint when the config has metrics and to the
+// CloudWatch Logs one when it has logs, or to their endpoint_override.
+func probeEndpoints(m map[string]interface{}, region string, report *util.ValidationReport) {
+	for _, section := range []struct {
+		key     string
+		service string
+		name    string
+	}{
+		{"metrics", "monitoring", "CloudWatch"},
+		{"logs", "logs", "CloudWatch Logs"},
+	} {
+		sectionMap := util.ConfigMap(m, section.key)
+		if sectionMap == nil {
+			continue
+		}
+		path := section.key
+		url := util.ConfigString(sectionMap, "endpoint_override")
+		if url != "" {
+			path = section.key + ".endpoint_override"
+			if !strings.Contains(url, "://") {
+				url = "https://" + url
+			}
+		} else {
+			endpoint, err := endpoints.DefaultResolver().EndpointFor(section.service, region)
+			if err != nil {
+				addIssue(report, util.SeverityError, path, "the %s endpoint of %s cannot be resolved: %v", section.name, region, err)
+				continue
+			}
+			url = endpoint.URL
+		}
+		if err := probeEndpoint(url); err != nil {
+			addIssue(report, util.SeverityError, path, "the %s endpoint %s cannot be reached: %v", section.name, url, err)
+		}
+	}
+}
+
+// probeHTTPS returns an error when url does not answer. Any answer, even an error status, shows the
+// endpoint can be reached.
+func probeHTTPS(url string) error {
+	client := &http.Client{Timeout: probeTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// schemaPath turns the path of a schema error, e.g. (root).logs.collect_list.0, into a json path like
+// the ones of the wizard checks, e.g. logs.collect_list[0].
+func schemaPath(rawPath string) string {
+	rawPath = strings.TrimPrefix(strings.TrimPrefix(rawPath, "(root)"), ".")
+	if rawPath == "" {
+		return ""
+	}
+	return joinPath(strings.Split(rawPath, "."))
+}
+
+// slashPath turns the path of a translator error, e.g. /logs/collect_list/0/, into a json path.
+func slashPath(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return ""
+	}
+	return joinPath(strings.Split(path, "/"))
+}
+
+func joinPath(segments []string) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil && b.Len() > 0 {
+			fmt.Fprintf(&b, "[%s]", segment)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+func addIssue(report *util.ValidationReport, severity util.Severity, path, format string, args ...interface{}) {
+	report.Issues = append(report.Issues, util.ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...), Severity: severity})
+}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package validateconfig
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func stubEnvironment(t *testing.T, region string, credentials bool, probe func(string) error) {
+	sdkRegionOrig, ec2RegionOrig, hasCredentialsOrig, probeOrig := sdkRegion, ec2Region, hasCredentials, probeEndpoint
+	t.Cleanup(func() {
+		sdkRegion, ec2Region, hasCredentials, probeEndpoint = sdkRegionOrig, ec2RegionOrig, hasCredentialsOrig, probeOrig
+	})
+	sdkRegion = func() string { return region }
+	ec2Region = func() (string, error) { return "", errors.New("not on EC2") }
+	hasCredentials = func() bool { return credentials }
+	probeEndpoint = probe
+}
+
+func issues(report util.ValidationReport) []string {
+	var result []string
+	for _, issue := range report.Issues {
+		result = append(result, string(issue.Severity)+" "+issue.Path+": "+issue.Message)
+	}
+	return result
+}
+
+// TestValidateContentOffline comes first, as the instance metadata is looked up once per process.
+func TestValidateContentOffline(t *testing.T) {
+	var contacted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contacted = append(contacted, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	t.Setenv("AWS_EC2_METADATA_SERVICE_ENDPOINT", server.URL)
+	stubEnvironment(t, "", false, nil)
+	report := ValidateContent([]byte(`{
+		"logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/messages", "log_stream_name": "{instance_id}"}]}}}
+	}`), Options{OS: util.OsTypeLinux, Mode: "ec2", Offline: true})
+	assert.Empty(t, issues(report))
+	assert.Empty(t, contacted, "IMDS was contacted")
+}
+
+func TestValidateContentValid(t *testing.T) {
+	var probed []string
+	stubEnvironment(t, "us-west-2", true, func(url string) error {
+		probed = append(probed, url)
+		return nil
+	})
+	report := ValidateContent([]byte(`{
+		"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}},
+		"logs": {"endpoint_override": "logs.example.com", "logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/messages"}]}}}
+	}`), Options{OS: util.OsTypeLinux, Mode: "ec2", ProbeEndpoints: true})
+	assert.Empty(t, issues(report))
+	assert.True(t, report.Valid())
+	assert.Equal(t, []string{"https://monitoring.us-west-2.amazonaws.com", "https://logs.example.com"}, probed)
+}
+
+func TestValidateContentErrors(t *testing.T) {
+	stubEnvironment(t, "", false, func(url string) error {
+		return errors.New("connection refused")
+	})
+	report := ValidateContent([]byte(`{
+		"agent": {"metrics_collection_interval": "60"},
+		"logs": {"logs_collected": {"files": {"collect_list": [{"log_group_name": "app"}]}}}
+	}`), Options{OS: util.OsTypeLinux, Mode: "onPremise", ProbeEndpoints: true})
+	assert.False(t, report.Valid())
+	assert.ElementsMatch(t, []string{
+		"error agent.metrics_collection_interval: Invalid type. Expected: integer, given: string",
+		"error logs.logs_collected.files.collect_list[0]: file_path is required",
+		"error agent.metrics_collection_interval: metrics_collection_interval must be a whole number of seconds",
+		"error agent.region: no region is set: it is read from agent.region, the AWS profile, and on EC2 the instance metadata",
+		"error agent.credentials: no AWS credentials are found for the agent to send with",
+	}, issues(report))
+
+	// the schema accepts any interval, the ones CloudWatch has no matching period for are only warned about
+	report = ValidateContent([]byte(`{"agent": {"metrics_collection_interval": 15}, "metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}}`), Options{OS: util.OsTypeLinux, Mode: "ec2", Offline: true})
+	assert.True(t, report.Valid())
+	assert.Equal(t, []string{"warning agent.metrics_collection_interval: sub-minute interval 15s must be one of [1 5 10 30] seconds"}, issues(report))
+
+	report = ValidateContent([]byte(`{"agent": {"region": "mars-1"}, "metrics": {"metrics_collected": {}}}`), Options{OS: util.OsTypeLinux, Mode: "ec2", ProbeEndpoints: true})
+	assert.Contains(t, issues(report), "warning agent.region: the region mars-1 is not a known AWS region")
+	assert.Contains(t, issues(report), "error metrics: the CloudWatch endpoint https://monitoring.mars-1.amazonaws.com cannot be reached: connection refused")
+
+	report = ValidateContent([]byte("{\n\"agent\": {,}\n}"), Options{OS: util.OsTypeLinux, Offline: true})
+	assert.Equal(t, []string{"error : invalid json at line 2: invalid character ',' looking for beginning of object key string"}, issues(report))
+}
+
+func TestValidateContentTranslation(t *testing.T) {
+	stubEnvironment(t, "", false, nil)
+	report := ValidateContent([]byte(`{
+		"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent", "mem_bogus"]}}}
+	}`), Options{OS: util.OsTypeLinux, Mode: "ec2", Offline: true})
+	assert.Equal(t, []string{"error metrics.metrics_collected.mem: measurement name mem_bogus is invalid"}, issues(report))
+
+	// the translation state of one config does not carry over to the next
+	report = ValidateContent([]byte(`{"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}}`), Options{OS: util.OsTypeLinux, Mode: "ec2", Offline: true})
+	assert.Empty(t, issues(report))
+}
+
+func TestValidate(t *testing.T) {
+	stubEnvironment(t, "us-east-1", true, nil)
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"agent": {"debug": true}}`), 0600))
+	report, err := Validate(filePath, Options{OS: util.OsTypeLinux, Mode: "ec2"})
+	require.NoError(t, err)
+	assert.Empty(t, issues(report))
+
+	_, err = Validate(filepath.Join(t.TempDir(), "missing.json"), Options{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestJSONPaths(t *testing.T) {
+	assert.Equal(t, "logs.collect_list[0].file_path", schemaPath("(root).logs.collect_list.0.file_path"))
+	assert.Equal(t, "", schemaPath("(root)"))
+	assert.Equal(t, "agent.ruleRegion", slashPath("/agent/ruleRegion/"))
+	assert.Equal(t, "logs.collect_list[1]", slashPath("/logs/collect_list/1/"))
+	assert.Equal(t, "", slashPath("/"))
+}
@@ -50,6 +50,7 @@ type Context struct {
 	runInContainer      bool
 	agentLogFile        string
 	omitHostname        bool
+	offline             bool
 }
 
 func (ctx *Context) Os() string {
@@ -197,3 +198,11 @@ func (ctx *Context) GetOmitHostname() bool {
 func (ctx *Context) SetOmitHostname(omitHostname bool) {
 	ctx.omitHostname = omitHostname
 }
+
+func (ctx *Context) Offline() bool {
+	return ctx.offline
+}
+
+func (ctx *Context) SetOffline(offline bool) {
+	ctx.offline = offline
+}
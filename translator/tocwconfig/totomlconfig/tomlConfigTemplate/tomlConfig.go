@@ -104,11 +104,13 @@ type (
 
 	eventConfig struct {
 		BatchReadSize   int      `toml:"batch_read_size"`
+		EventIDs        []int    `toml:"event_ids"`
 		EventLevels     []string `toml:"event_levels"`
 		EventName       string   `toml:"event_name"`
 		LogGroupName    string   `toml:"log_group_name"`
 		LogStreamName   string   `toml:"log_stream_name"`
 		RetentionInDays int      `toml:"retention_in_days"`
+		XPathQuery      string   `toml:"xpath_query"`
 	}
 
 	logFileConfig struct {
@@ -221,3 +221,54 @@ func TestConflictingRetention(t *testing.T) {
 		assert.Fail(t, error.Error())
 	}
 }
+
+func TestApplyRuleEventFilters(t *testing.T) {
+	c := new(CollectList)
+	var rawJsonString = `
+{
+    "collect_list": [
+      {
+        "event_name": "Security",
+        "event_levels": [
+          "INFORMATION"
+        ],
+        "event_ids": [4624, 4625],
+        "log_group_name": "Security"
+      },
+      {
+        "event_name": "System",
+        "xpath_query": "*[System[EventID=7036]]",
+        "log_group_name": "System"
+      }
+    ]
+}
+`
+	var input interface{}
+
+	var expected = []interface{}{
+		map[string]interface{}{
+			"event_name":        "Security",
+			"event_levels":      []interface{}{"4", "0"},
+			"event_ids":         []int{4624, 4625},
+			"log_group_name":    "Security",
+			"batch_read_size":   BatchReadSizeValue,
+			"retention_in_days": -1,
+			"log_group_class":   "",
+		},
+		map[string]interface{}{
+			"event_name":        "System",
+			"xpath_query":       "*[System[EventID=7036]]",
+			"log_group_name":    "System",
+			"batch_read_size":   BatchReadSizeValue,
+			"retention_in_days": -1,
+			"log_group_class":   "",
+		},
+	}
+
+	translator.ResetMessages()
+	err := json.Unmarshal([]byte(rawJsonString), &input)
+	assert.NoError(t, err)
+	_, actual := c.ApplyRule(input)
+	assert.Equal(t, 0, len(translator.ErrorMessages))
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collectlist
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const EventIDsSectionKey = "event_ids"
+
+type EventIDs struct {
+}
+
+// ApplyRule turns the event_ids of the json config, decoded as float64, into the event IDs the plugin
+// filters the events on.
+func (r *EventIDs) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	rawEventIDs, ok := im[EventIDsSectionKey].([]interface{})
+	if !ok {
+		return
+	}
+	eventIDs := []int{}
+	for _, rawEventID := range rawEventIDs {
+		eventID, ok := rawEventID.(float64)
+		if !ok || eventID != float64(int(eventID)) {
+			translator.AddErrorMessages(GetCurPath()+EventIDsSectionKey, fmt.Sprintf("event_ids value %v is not a valid event ID.", rawEventID))
+			continue
+		}
+		eventIDs = append(eventIDs, int(eventID))
+	}
+	return EventIDsSectionKey, eventIDs
+}
+
+func init() {
+	r := new(EventIDs)
+	RegisterRule(EventIDsSectionKey, r)
+}
@@ -37,6 +37,10 @@ var (
 const allowedRetries = 5
 
 func GetEC2UtilSingleton() *ec2Util {
+	if context.CurrentContext().Offline() {
+		// the metadata stays unknown, and is not cached for a translation that is not offline
+		return &ec2Util{}
+	}
 	once.Do(func() {
 		ec2UtilInstance = initEC2UtilSingleton()
 	})